@@ -17,6 +17,7 @@ import (
 	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
 )
 
+// RolloutClock is the clock of the RolloutHandlers that have no clock of their own, see WithClock.
 var RolloutClock = clock.Clock(clock.RealClock{})
 var maxTimeDuration = time.Duration(math.MaxInt64)
 
@@ -55,6 +56,12 @@ type ClusterRolloutStatus struct {
 	// For succeeded status, tracks when the minSuccessTime (soak time) period ends.
 	// For progressing/failed status, tracks when the timeout occurs.
 	RecheckTime *metav1.Time
+	// Revision is the revision of the workload applied on the cluster (optional field).
+	// Used to determine the clusters to roll back, see RolloutControl.
+	Revision string
+	// PreviousRevision is the revision of the workload applied on the cluster before the Revision (optional field).
+	// The cluster is rolled back to the PreviousRevision.
+	PreviousRevision string
 }
 
 // RolloutResult contains list of clusters that are timeOut, removed and required to rollOut. A
@@ -82,6 +89,10 @@ type RolloutHandler[T any] struct {
 	// placement decision tracker
 	pdTracker  *clusterv1beta1sdk.PlacementDecisionClustersTracker
 	statusFunc ClusterRolloutStatusFunc[T]
+	// ordering orders the clusters to roll out, the clusters are ordered by their names if it is nil.
+	ordering *ClusterOrdering
+	// clock is the clock to check the timeout and the minimum success time, the RolloutClock is used if it is nil.
+	clock clock.Clock
 }
 
 // NewRolloutHandler creates a new RolloutHandler with the given workload type.
@@ -93,6 +104,21 @@ func NewRolloutHandler[T any](pdTracker *clusterv1beta1sdk.PlacementDecisionClus
 	return &RolloutHandler[T]{pdTracker: pdTracker, statusFunc: statusFunc}, nil
 }
 
+// WithClock sets the clock of the handler to check the timeout and the minimum success time of the clusters
+// instead of the RolloutClock.
+func (r *RolloutHandler[T]) WithClock(clock clock.Clock) *RolloutHandler[T] {
+	r.clock = clock
+	return r
+}
+
+// rolloutClock returns the clock of the handler, or the RolloutClock if the handler has no clock.
+func (r *RolloutHandler[T]) rolloutClock() clock.Clock {
+	if r.clock != nil {
+		return r.clock
+	}
+	return RolloutClock
+}
+
 // The inputs are a RolloutStrategy and existingClusterRolloutStatus list.
 // The existing ClusterRolloutStatus list should be created using the ClusterRolloutStatusFunc to determine the current workload rollout status.
 // The existing ClusterRolloutStatus list should contain all the current workloads rollout status such as ToApply, Progressing, Succeeded,
@@ -131,7 +157,7 @@ func (r *RolloutHandler[T]) getRolloutAllClusters(rolloutStrategy clusterv1alpha
 
 	// Check for removed Clusters
 	currentClusterStatus, removedClusterStatus := r.getRemovedClusters(allClusterGroups, existingClusterStatus)
-	rolloutResult := progressivePerCluster(allClusterGroups, len(allClusters), len(allClusters), time.Duration(0), failureTimeout, currentClusterStatus)
+	rolloutResult := progressivePerCluster(allClusterGroups, len(allClusters), len(allClusters), time.Duration(0), failureTimeout, currentClusterStatus, r.sortClusters, r.rolloutClock())
 	rolloutResult.ClustersRemoved = removedClusterStatus
 
 	return &strategy, rolloutResult, nil
@@ -169,7 +195,7 @@ func (r *RolloutHandler[T]) getProgressiveClusters(rolloutStrategy clusterv1alph
 	// Perform progressive rollOut for mandatory decision groups first, tolerating no failures
 	if len(clusterGroups) > 0 {
 		rolloutResult := progressivePerGroup(
-			clusterGroups, intstr.FromInt32(0), minSuccessTime, failureTimeout, currentClusterStatus,
+			clusterGroups, intstr.FromInt32(0), minSuccessTime, failureTimeout, currentClusterStatus, r.sortClusters, r.rolloutClock(),
 		)
 		if len(rolloutResult.ClustersToRollout) > 0 || len(rolloutResult.ClustersTimeOut) > 0 {
 			rolloutResult.ClustersRemoved = removedClusterStatus
@@ -186,7 +212,7 @@ func (r *RolloutHandler[T]) getProgressiveClusters(rolloutStrategy clusterv1alph
 	}
 
 	// Rollout the remaining clusters
-	rolloutResult := progressivePerCluster(clusterGroups, rolloutSize, maxFailures, minSuccessTime, failureTimeout, currentClusterStatus)
+	rolloutResult := progressivePerCluster(clusterGroups, rolloutSize, maxFailures, minSuccessTime, failureTimeout, currentClusterStatus, r.sortClusters, r.rolloutClock())
 	rolloutResult.ClustersRemoved = removedClusterStatus
 
 	return &strategy, rolloutResult, nil
@@ -225,7 +251,7 @@ func (r *RolloutHandler[T]) getProgressivePerGroupClusters(rolloutStrategy clust
 
 	// Perform progressive rollout per group for mandatory decision groups first, tolerating no failures
 	if len(clusterGroups) > 0 {
-		rolloutResult := progressivePerGroup(clusterGroups, intstr.FromInt32(0), minSuccessTime, failureTimeout, currentClusterStatus)
+		rolloutResult := progressivePerGroup(clusterGroups, intstr.FromInt32(0), minSuccessTime, failureTimeout, currentClusterStatus, r.sortClusters, r.rolloutClock())
 
 		if len(rolloutResult.ClustersToRollout) > 0 || len(rolloutResult.ClustersTimeOut) > 0 {
 			rolloutResult.ClustersRemoved = removedClusterStatus
@@ -237,7 +263,7 @@ func (r *RolloutHandler[T]) getProgressivePerGroupClusters(rolloutStrategy clust
 	restClusterGroups := r.pdTracker.ExistingClusterGroupsBesides(groupKeys...)
 
 	// Perform progressive rollout per group for the remaining decision groups
-	rolloutResult := progressivePerGroup(restClusterGroups, maxFailures, minSuccessTime, failureTimeout, currentClusterStatus)
+	rolloutResult := progressivePerGroup(restClusterGroups, maxFailures, minSuccessTime, failureTimeout, currentClusterStatus, r.sortClusters, r.rolloutClock())
 	rolloutResult.ClustersRemoved = removedClusterStatus
 
 	return &strategy, rolloutResult, nil
@@ -265,8 +291,8 @@ func (r *RolloutHandler[T]) getRemovedClusters(clusterGroupsMap clusterv1beta1sd
 }
 
 // progressivePerCluster parses the rollout status for the given clusters and returns the rollout
-// result. It sorts the clusters alphabetically in order to determine the rollout groupings and the
-// rollout group size is determined by the MaxConcurrency setting.
+// result. It sorts the clusters with sortClusters (alphabetically by default) in order to determine
+// the rollout groupings and the rollout group size is determined by the MaxConcurrency setting.
 func progressivePerCluster(
 	clusterGroupsMap clusterv1beta1sdk.ClusterGroupsMap,
 	rolloutSize int,
@@ -274,6 +300,8 @@ func progressivePerCluster(
 	minSuccessTime time.Duration,
 	timeout time.Duration,
 	existingClusterStatus []ClusterRolloutStatus,
+	sortClusters func(clusters []string),
+	clock clock.Clock,
 ) RolloutResult {
 	var rolloutClusters, timeoutClusters []ClusterRolloutStatus
 	existingClusters := make(map[string]bool)
@@ -281,9 +309,7 @@ func progressivePerCluster(
 	failureBreach := false
 
 	// Sort existing cluster status for consistency in case ToApply was determined by the workload applier
-	sort.Slice(existingClusterStatus, func(i, j int) bool {
-		return existingClusterStatus[i].ClusterName < existingClusterStatus[j].ClusterName
-	})
+	sortClusterStatus(existingClusterStatus, sortClusters)
 
 	// Collect existing cluster status and determine any TimeOut statuses
 	for _, status := range existingClusterStatus {
@@ -299,7 +325,7 @@ func progressivePerCluster(
 			// target rollout size to determine whether to return or not first.
 			// The timeoutClusters, as well as failed clusters will be counted into failureCount, the next rollout
 			// will stop if failureCount > maxFailures.
-			rolloutClusters, timeoutClusters = determineRolloutStatus(&status, minSuccessTime, timeout, rolloutClusters, timeoutClusters)
+			rolloutClusters, timeoutClusters = determineRolloutStatus(&status, minSuccessTime, timeout, rolloutClusters, timeoutClusters, clock)
 		}
 
 		// Keep track of TimeOut or Failed clusters and check total against MaxFailures
@@ -315,7 +341,7 @@ func progressivePerCluster(
 				ClustersToRollout: rolloutClusters,
 				ClustersTimeOut:   timeoutClusters,
 				MaxFailureBreach:  failureBreach,
-				RecheckAfter:      minRecheckAfter(rolloutClusters),
+				RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 			}
 		}
 	}
@@ -326,15 +352,15 @@ func progressivePerCluster(
 			ClustersToRollout: rolloutClusters,
 			ClustersTimeOut:   timeoutClusters,
 			MaxFailureBreach:  failureBreach,
-			RecheckAfter:      minRecheckAfter(rolloutClusters),
+			RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 		}
 	}
 
 	clusters := clusterGroupsMap.GetClusters().UnsortedList()
 	clusterToGroupKey := clusterGroupsMap.ClusterToGroupKey()
 
-	// Sort the clusters in alphabetical order to ensure consistency.
-	sort.Strings(clusters)
+	// Sort the clusters to ensure consistency.
+	sortClusters(clusters)
 
 	// Amend clusters to the rollout up to the rollout size
 	for _, cluster := range clusters {
@@ -355,7 +381,7 @@ func progressivePerCluster(
 			return RolloutResult{
 				ClustersToRollout: rolloutClusters,
 				ClustersTimeOut:   timeoutClusters,
-				RecheckAfter:      minRecheckAfter(rolloutClusters),
+				RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 			}
 		}
 	}
@@ -363,7 +389,7 @@ func progressivePerCluster(
 	return RolloutResult{
 		ClustersToRollout: rolloutClusters,
 		ClustersTimeOut:   timeoutClusters,
-		RecheckAfter:      minRecheckAfter(rolloutClusters),
+		RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 	}
 }
 
@@ -373,6 +399,8 @@ func progressivePerGroup(
 	minSuccessTime time.Duration,
 	timeout time.Duration,
 	existingClusterStatus []ClusterRolloutStatus,
+	sortClusters func(clusters []string),
+	clock clock.Clock,
 ) RolloutResult {
 	var rolloutClusters, timeoutClusters []ClusterRolloutStatus
 	existingClusters := make(map[string]RolloutStatus)
@@ -387,7 +415,7 @@ func progressivePerGroup(
 		if status.Status != ToApply {
 			// For progress per group, the existing rollout clusters and timeout clusters status will be recored in existingClusters first,
 			// then go through group by group.
-			rolloutClusters, timeoutClusters = determineRolloutStatus(&status, minSuccessTime, timeout, rolloutClusters, timeoutClusters)
+			rolloutClusters, timeoutClusters = determineRolloutStatus(&status, minSuccessTime, timeout, rolloutClusters, timeoutClusters, clock)
 			existingClusters[status.ClusterName] = status.Status
 		}
 	}
@@ -403,7 +431,7 @@ func progressivePerGroup(
 			maxGroupFailures, _ := calculateRolloutSize(maxFailures, len(subclusters), 0)
 			// Iterate through clusters in the group
 			clusters := subclusters.UnsortedList()
-			sort.Strings(clusters)
+			sortClusters(clusters)
 			for _, cluster := range clusters {
 				if status, ok := existingClusters[cluster]; ok {
 					// Keep track of TimeOut or Failed clusters and check total against MaxFailures
@@ -433,7 +461,7 @@ func progressivePerGroup(
 					ClustersToRollout: rolloutClusters,
 					ClustersTimeOut:   timeoutClusters,
 					MaxFailureBreach:  failureBreach,
-					RecheckAfter:      minRecheckAfter(rolloutClusters),
+					RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 				}
 			}
 		}
@@ -443,7 +471,7 @@ func progressivePerGroup(
 		ClustersToRollout: rolloutClusters,
 		ClustersTimeOut:   timeoutClusters,
 		MaxFailureBreach:  failureBreach,
-		RecheckAfter:      minRecheckAfter(rolloutClusters),
+		RecheckAfter:      minRecheckAfter(rolloutClusters, clock),
 	}
 }
 
@@ -465,6 +493,7 @@ func determineRolloutStatus(
 	timeout time.Duration,
 	rolloutClusters []ClusterRolloutStatus,
 	timeoutClusters []ClusterRolloutStatus,
+	clock clock.Clock,
 ) ([]ClusterRolloutStatus, []ClusterRolloutStatus) {
 
 	switch status.Status {
@@ -473,8 +502,8 @@ func determineRolloutStatus(
 	case Succeeded:
 		// If the cluster succeeded but is still within the MinSuccessTime (i.e. "soak" time),
 		// still add it to the list of rolloutClusters
-		minSuccessTimeTime := calculateRecheckTime(status.LastTransitionTime, minSuccessTime)
-		if minSuccessTimeTime != nil && RolloutClock.Now().Before(minSuccessTimeTime.Time) {
+		minSuccessTimeTime := calculateRecheckTime(status.LastTransitionTime, minSuccessTime, clock)
+		if minSuccessTimeTime != nil && clock.Now().Before(minSuccessTimeTime.Time) {
 			// Set RecheckTime to track when the soak period ends
 			status.RecheckTime = minSuccessTimeTime
 			rolloutClusters = append(rolloutClusters, *status)
@@ -484,10 +513,10 @@ func determineRolloutStatus(
 	case TimeOut, Skip:
 		return rolloutClusters, timeoutClusters
 	default: // For progressing, failed, or unknown status.
-		timeOutTime := calculateRecheckTime(status.LastTransitionTime, timeout)
+		timeOutTime := calculateRecheckTime(status.LastTransitionTime, timeout, clock)
 		status.RecheckTime = timeOutTime
 		// check if current time is before the timeout time
-		if timeOutTime == nil || RolloutClock.Now().Before(timeOutTime.Time) {
+		if timeOutTime == nil || clock.Now().Before(timeOutTime.Time) {
 			rolloutClusters = append(rolloutClusters, *status)
 		} else {
 			status.Status = TimeOut
@@ -499,16 +528,16 @@ func determineRolloutStatus(
 }
 
 // calculateRecheckTime calculates the recheck time by adding a duration to a start time.
-// If startTime is nil, it uses the current time from RolloutClock.
+// If startTime is nil, it uses the current time from the clock.
 // If duration is maxTimeDuration (indicating no timeout/soak period), it returns nil.
-func calculateRecheckTime(startTime *metav1.Time, duration time.Duration) *metav1.Time {
+func calculateRecheckTime(startTime *metav1.Time, duration time.Duration, clock clock.Clock) *metav1.Time {
 	var recheckTime time.Time
 	// if duration is not set (default to maxTimeDuration), the recheck time should not be set
 	if duration == maxTimeDuration {
 		return nil
 	}
 	if startTime == nil {
-		recheckTime = RolloutClock.Now().Add(duration)
+		recheckTime = clock.Now().Add(duration)
 	} else {
 		recheckTime = startTime.Add(duration)
 	}
@@ -607,13 +636,30 @@ func decisionGroupsToGroupKeys(decisionsGroup []clusterv1alpha1.MandatoryDecisio
 	return result
 }
 
-func minRecheckAfter(rolloutClusters []ClusterRolloutStatus) *time.Duration {
+// sortClusterStatus sorts the cluster status in the order of the clusters sorted by sortClusters.
+func sortClusterStatus(clusterStatus []ClusterRolloutStatus, sortClusters func(clusters []string)) {
+	clusters := make([]string, len(clusterStatus))
+	for i, status := range clusterStatus {
+		clusters[i] = status.ClusterName
+	}
+	sortClusters(clusters)
+
+	index := make(map[string]int, len(clusters))
+	for i, cluster := range clusters {
+		index[cluster] = i
+	}
+	sort.SliceStable(clusterStatus, func(i, j int) bool {
+		return index[clusterStatus[i].ClusterName] < index[clusterStatus[j].ClusterName]
+	})
+}
+
+func minRecheckAfter(rolloutClusters []ClusterRolloutStatus, clock clock.Clock) *time.Duration {
 	var minDuration *time.Duration
 
 	for _, r := range rolloutClusters {
 		// Check RecheckTime for both Progressing/Failed clusters (timeout) and Succeeded clusters (soak period)
 		if r.RecheckTime != nil {
-			recheckDuration := r.RecheckTime.Sub(RolloutClock.Now())
+			recheckDuration := r.RecheckTime.Sub(clock.Now())
 			// Only consider positive durations (future recheck times)
 			if recheckDuration > 0 {
 				if minDuration == nil || *minDuration > recheckDuration {
@@ -0,0 +1,279 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
+
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+// RolloutState represents the state of a rollout.
+type RolloutState string
+
+const (
+	// RolloutActive indicates that the rollout proceeds to the next clusters.
+	RolloutActive RolloutState = "Active"
+	// RolloutPaused indicates that the rollout does not start new clusters, the clusters that are already
+	// rolling out are still tracked.
+	RolloutPaused RolloutState = "Paused"
+	// RolloutAborted indicates that the rollout stops, no cluster is rolled out or rolled back.
+	RolloutAborted RolloutState = "Aborted"
+	// RollingBack indicates that the clusters that received the revision are rolled back to their previous
+	// revision.
+	RollingBack RolloutState = "RollingBack"
+)
+
+// RolloutControl controls the state of a rollout. The caller persists the returned state and passes it back
+// on the next call.
+type RolloutControl struct {
+	// State is the current state of the rollout, it is Active if it is not set.
+	State RolloutState
+	// Revision is the revision of the workload that is being rolled out. It is required to roll back, the
+	// clusters whose ClusterRolloutStatus.Revision is the Revision are rolled back to their PreviousRevision.
+	Revision string
+	// PausePoints are the decision groups after which the rollout is paused. The rollout is paused once all the
+	// clusters of a pause point group are rolled out and the rollout would start new clusters. Remove the pause
+	// point and set the state to Active to resume the rollout.
+	PausePoints []clusterv1beta1sdk.GroupKey
+	// OnMaxFailureBreach is the state that an Active rollout transits to once the MaxFailures is breached, the
+	// rollout stays Active if it is not set.
+	OnMaxFailureBreach RolloutState
+}
+
+// RolloutControlResult is the RolloutResult with the state of the rollout.
+type RolloutControlResult struct {
+	RolloutResult
+	// State is the state of the rollout after this call.
+	State RolloutState
+	// PausedAfter is the pause point group that pauses the rollout in this call.
+	PausedAfter *clusterv1beta1sdk.GroupKey
+	// ClustersToRollback is a slice of ClusterRolloutStatus that will be rolled back to their PreviousRevision.
+	ClustersToRollback []ClusterRolloutStatus
+}
+
+// GetRolloutClusterWithControl is the GetRolloutCluster with the state of the rollout.
+//
+//   - Active: the clusters are rolled out as GetRolloutCluster, the rollout is paused at the pause points, and
+//     transits to the OnMaxFailureBreach state if the MaxFailures is breached.
+//   - Paused: only the clusters that already started the rollout are returned in the ClustersToRollout.
+//   - Aborted: no cluster is returned to roll out.
+//   - RollingBack: the clusters that received the revision are returned in the ClustersToRollback in batches
+//     with the same limits of the strategy, in the reverse order of the rollout. A cluster is rolling back if its
+//     PreviousRevision is the revision and it is Progressing. The rollout is Aborted once all the clusters are
+//     rolled back. The clusters that have no PreviousRevision are not rolled back.
+//
+// The ClustersRemoved are returned in all the states.
+func (r *RolloutHandler[T]) GetRolloutClusterWithControl(rolloutStrategy clusterv1alpha1.RolloutStrategy,
+	control RolloutControl, existingClusterStatus []ClusterRolloutStatus) (*clusterv1alpha1.RolloutStrategy, RolloutControlResult, error) {
+	strategy, rolloutResult, err := r.GetRolloutCluster(rolloutStrategy, existingClusterStatus)
+	if err != nil {
+		return strategy, RolloutControlResult{RolloutResult: rolloutResult, State: control.State}, err
+	}
+
+	state := control.State
+	if len(state) == 0 {
+		state = RolloutActive
+	}
+	if state == RolloutActive && rolloutResult.MaxFailureBreach && len(control.OnMaxFailureBreach) != 0 {
+		state = control.OnMaxFailureBreach
+	}
+
+	result := RolloutControlResult{State: state}
+	switch state {
+	case RolloutActive:
+		result.RolloutResult = rolloutResult
+		if pausedAfter := r.pausePoint(control.PausePoints, rolloutResult, existingClusterStatus); pausedAfter != nil {
+			result.State = RolloutPaused
+			result.PausedAfter = pausedAfter
+			result.RolloutResult = pausedRolloutResult(rolloutResult, r.rolloutClock())
+		}
+	case RolloutPaused:
+		result.RolloutResult = pausedRolloutResult(rolloutResult, r.rolloutClock())
+	case RolloutAborted:
+		result.RolloutResult = abortedRolloutResult(rolloutResult)
+	case RollingBack:
+		if len(control.Revision) == 0 {
+			return strategy, result, fmt.Errorf("the revision is required to roll back")
+		}
+		result.RolloutResult = abortedRolloutResult(rolloutResult)
+		result.ClustersToRollback, err = r.getRollbackClusters(strategy, control.Revision, existingClusterStatus)
+		if err != nil {
+			return strategy, result, err
+		}
+		if len(result.ClustersToRollback) == 0 && !rollingBack(control.Revision, existingClusterStatus) {
+			result.State = RolloutAborted
+		}
+	default:
+		return strategy, result, fmt.Errorf("incorrect rollout state %v", state)
+	}
+
+	return strategy, result, nil
+}
+
+// pausePoint returns the first pause point group whose clusters are all rolled out if the rollout would start
+// new clusters.
+func (r *RolloutHandler[T]) pausePoint(pausePoints []clusterv1beta1sdk.GroupKey, rolloutResult RolloutResult,
+	existingClusterStatus []ClusterRolloutStatus) *clusterv1beta1sdk.GroupKey {
+	if len(pausePoints) == 0 {
+		return nil
+	}
+
+	toApply := sets.New[string]()
+	rollingOut := sets.New[string]()
+	for _, status := range rolloutResult.ClustersToRollout {
+		if status.Status == ToApply {
+			toApply.Insert(status.ClusterName)
+			continue
+		}
+		rollingOut.Insert(status.ClusterName)
+	}
+	if toApply.Len() == 0 {
+		return nil
+	}
+
+	started := sets.New[string]()
+	for _, status := range existingClusterStatus {
+		if status.Status != ToApply {
+			started.Insert(status.ClusterName)
+		}
+	}
+
+	for _, pausePoint := range pausePoints {
+		clusters := r.pdTracker.ExistingClusterGroups(pausePoint).GetClusters()
+		if clusters.Len() == 0 || !started.IsSuperset(clusters) || rollingOut.HasAny(clusters.UnsortedList()...) {
+			continue
+		}
+		// the clusters to start are in the pause point group itself
+		if clusters.IsSuperset(toApply) {
+			continue
+		}
+		pausedAfter := pausePoint
+		return &pausedAfter
+	}
+	return nil
+}
+
+// getRollbackClusters returns the next batch of the clusters to roll back. The batch size is limited by the
+// strategy: all the clusters for the All, the MaxConcurrency including the clusters that are rolling back for
+// the Progressive, and one group at a time for the ProgressivePerGroup.
+func (r *RolloutHandler[T]) getRollbackClusters(strategy *clusterv1alpha1.RolloutStrategy, revision string,
+	existingClusterStatus []ClusterRolloutStatus) ([]ClusterRolloutStatus, error) {
+	clusterGroups := r.pdTracker.ExistingClusterGroupsBesides()
+	currentClusterStatus, _ := r.getRemovedClusters(clusterGroups, existingClusterStatus)
+
+	toRollback := map[string]ClusterRolloutStatus{}
+	inFlight := 0
+	for _, status := range currentClusterStatus {
+		switch {
+		case status.Revision == revision && len(status.PreviousRevision) != 0:
+			toRollback[status.ClusterName] = status
+		case status.PreviousRevision == revision && status.Status == Progressing:
+			inFlight++
+		}
+	}
+
+	// roll back in the reverse order of the rollout
+	var groups [][]ClusterRolloutStatus
+	groupKeys := r.rolloutGroupKeys(strategy)
+	for i := len(groupKeys) - 1; i >= 0; i-- {
+		clusters := clusterGroups[groupKeys[i]].UnsortedList()
+		r.sortClusters(clusters)
+		for left, right := 0, len(clusters)-1; left < right; left, right = left+1, right-1 {
+			clusters[left], clusters[right] = clusters[right], clusters[left]
+		}
+
+		var group []ClusterRolloutStatus
+		for _, cluster := range clusters {
+			if status, ok := toRollback[cluster]; ok {
+				status.GroupKey = groupKeys[i]
+				group = append(group, status)
+			}
+		}
+		if len(group) != 0 {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	switch strategy.Type {
+	case clusterv1alpha1.Progressive:
+		total := len(clusterGroups.GetClusters())
+		rollbackSize, err := calculateRolloutSize(strategy.Progressive.MaxConcurrency, total, total)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the provided maxConcurrency: %w", err)
+		}
+
+		var rollbackClusters []ClusterRolloutStatus
+		for _, group := range groups {
+			for _, status := range group {
+				if len(rollbackClusters)+inFlight >= rollbackSize {
+					return rollbackClusters, nil
+				}
+				rollbackClusters = append(rollbackClusters, status)
+			}
+		}
+		return rollbackClusters, nil
+	case clusterv1alpha1.ProgressivePerGroup:
+		if inFlight > 0 {
+			return nil, nil
+		}
+		return groups[0], nil
+	default:
+		var rollbackClusters []ClusterRolloutStatus
+		for _, group := range groups {
+			rollbackClusters = append(rollbackClusters, group...)
+		}
+		return rollbackClusters, nil
+	}
+}
+
+// rolloutGroupKeys returns the group keys in the order of the rollout, the mandatory decision groups first.
+func (r *RolloutHandler[T]) rolloutGroupKeys(strategy *clusterv1alpha1.RolloutStrategy) []clusterv1beta1sdk.GroupKey {
+	var mandatoryDecisionGroups []clusterv1alpha1.MandatoryDecisionGroup
+	switch strategy.Type {
+	case clusterv1alpha1.Progressive:
+		mandatoryDecisionGroups = strategy.Progressive.MandatoryDecisionGroups.MandatoryDecisionGroups
+	case clusterv1alpha1.ProgressivePerGroup:
+		mandatoryDecisionGroups = strategy.ProgressivePerGroup.MandatoryDecisionGroups.MandatoryDecisionGroups
+	}
+
+	groupKeys := decisionGroupsToGroupKeys(mandatoryDecisionGroups)
+	return append(r.pdTracker.ExistingClusterGroups(groupKeys...).GetOrderedGroupKeys(),
+		r.pdTracker.ExistingClusterGroupsBesides(groupKeys...).GetOrderedGroupKeys()...)
+}
+
+// rollingBack returns true if any cluster is rolling back from the revision.
+func rollingBack(revision string, existingClusterStatus []ClusterRolloutStatus) bool {
+	for _, status := range existingClusterStatus {
+		if status.PreviousRevision == revision && status.Status == Progressing {
+			return true
+		}
+	}
+	return false
+}
+
+// pausedRolloutResult keeps the clusters that already started the rollout.
+func pausedRolloutResult(rolloutResult RolloutResult, clock clock.Clock) RolloutResult {
+	var rolloutClusters []ClusterRolloutStatus
+	for _, status := range rolloutResult.ClustersToRollout {
+		if status.Status != ToApply {
+			rolloutClusters = append(rolloutClusters, status)
+		}
+	}
+	rolloutResult.ClustersToRollout = rolloutClusters
+	rolloutResult.RecheckAfter = minRecheckAfter(rolloutClusters, clock)
+	return rolloutResult
+}
+
+// abortedRolloutResult keeps the removed clusters only.
+func abortedRolloutResult(rolloutResult RolloutResult) RolloutResult {
+	return RolloutResult{
+		ClustersRemoved:  rolloutResult.ClustersRemoved,
+		MaxFailureBreach: rolloutResult.MaxFailureBreach,
+	}
+}
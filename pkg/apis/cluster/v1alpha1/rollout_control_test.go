@@ -0,0 +1,190 @@
+package v1alpha1
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+func TestGetRolloutClusterWithControl(t *testing.T) {
+	group0 := clusterv1beta1sdk.GroupKey{GroupName: "group1", GroupIndex: 0}
+	group1 := clusterv1beta1sdk.GroupKey{GroupIndex: 1}
+	group2 := clusterv1beta1sdk.GroupKey{GroupIndex: 2}
+	clusterGroups := map[clusterv1beta1sdk.GroupKey]sets.Set[string]{
+		group0: sets.New[string]("cluster1", "cluster2"),
+		group1: sets.New[string]("cluster3", "cluster4"),
+		group2: sets.New[string]("cluster5", "cluster6"),
+	}
+
+	perGroup := clusterv1alpha1.RolloutStrategy{Type: clusterv1alpha1.ProgressivePerGroup}
+	progressive := clusterv1alpha1.RolloutStrategy{
+		Type: clusterv1alpha1.Progressive,
+		Progressive: &clusterv1alpha1.RolloutProgressive{
+			MaxConcurrency: intstr.FromInt32(2),
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		rolloutStrategy        clusterv1alpha1.RolloutStrategy
+		control                RolloutControl
+		existingClusterStatus  []ClusterRolloutStatus
+		expectState            RolloutState
+		expectPausedAfter      *clusterv1beta1sdk.GroupKey
+		expectRolloutClusters  []ClusterRolloutStatus
+		expectRollbackClusters []ClusterRolloutStatus
+		expectErr              bool
+	}{
+		{
+			name:            "paused after the pause point group",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{PausePoints: []clusterv1beta1sdk.GroupKey{{GroupName: "group1"}}},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Succeeded, LastTransitionTime: &fakeTime_60s},
+				{ClusterName: "cluster2", GroupKey: group0, Status: Succeeded, LastTransitionTime: &fakeTime_60s},
+			},
+			expectState:       RolloutPaused,
+			expectPausedAfter: &clusterv1beta1sdk.GroupKey{GroupName: "group1"},
+		},
+		{
+			name:            "not paused before the pause point group is rolled out",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{PausePoints: []clusterv1beta1sdk.GroupKey{{GroupName: "group1"}}},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Succeeded, LastTransitionTime: &fakeTime_60s},
+				{ClusterName: "cluster2", GroupKey: group0, Status: Progressing, LastTransitionTime: &fakeTime_60s},
+			},
+			expectState: RolloutActive,
+			expectRolloutClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster2", GroupKey: group0, Status: Progressing, LastTransitionTime: &fakeTime_60s},
+			},
+		},
+		{
+			name:            "paused rollout keeps the started clusters",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RolloutPaused},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Progressing, LastTransitionTime: &fakeTime_60s},
+			},
+			expectState: RolloutPaused,
+			expectRolloutClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Progressing, LastTransitionTime: &fakeTime_60s},
+			},
+		},
+		{
+			name:            "aborted rollout",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RolloutAborted},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Progressing, LastTransitionTime: &fakeTime_60s},
+			},
+			expectState: RolloutAborted,
+		},
+		{
+			name: "roll back once the max failures is breached",
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.Progressive,
+				Progressive: &clusterv1alpha1.RolloutProgressive{
+					MaxConcurrency: intstr.FromInt32(2),
+					RolloutConfig:  clusterv1alpha1.RolloutConfig{MaxFailures: intstr.FromInt32(0)},
+				},
+			},
+			control: RolloutControl{Revision: "v2", OnMaxFailureBreach: RollingBack},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", Status: Failed, LastTransitionTime: &fakeTime_60s, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster2", Status: Succeeded, LastTransitionTime: &fakeTime_60s, Revision: "v2", PreviousRevision: "v1"},
+			},
+			expectState: RollingBack,
+			expectRollbackClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster2", GroupKey: group0, Status: Succeeded, LastTransitionTime: &fakeTime_60s, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster1", GroupKey: group0, Status: Failed, LastTransitionTime: &fakeTime_60s, Revision: "v2", PreviousRevision: "v1"},
+			},
+		},
+		{
+			name:            "progressive roll back counts the clusters that are rolling back",
+			rolloutStrategy: progressive,
+			control:         RolloutControl{State: RollingBack, Revision: "v2"},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", Status: Failed, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster2", Status: Progressing, Revision: "v1", PreviousRevision: "v2"},
+				{ClusterName: "cluster3", Status: Succeeded, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster4", Status: Succeeded, Revision: "v2"},
+			},
+			expectState: RollingBack,
+			expectRollbackClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster3", GroupKey: group1, Status: Succeeded, Revision: "v2", PreviousRevision: "v1"},
+			},
+		},
+		{
+			name:            "roll back per group in the reverse order",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RollingBack, Revision: "v2"},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", Status: Succeeded, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster3", Status: Failed, Revision: "v2", PreviousRevision: "v1"},
+			},
+			expectState: RollingBack,
+			expectRollbackClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster3", GroupKey: group1, Status: Failed, Revision: "v2", PreviousRevision: "v1"},
+			},
+		},
+		{
+			name:            "roll back per group waits for the clusters that are rolling back",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RollingBack, Revision: "v2"},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", Status: Succeeded, Revision: "v2", PreviousRevision: "v1"},
+				{ClusterName: "cluster3", Status: Progressing, Revision: "v1", PreviousRevision: "v2"},
+			},
+			expectState: RollingBack,
+		},
+		{
+			name:            "aborted once all the clusters are rolled back",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RollingBack, Revision: "v2"},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", Status: Succeeded, Revision: "v1", PreviousRevision: "v2"},
+				{ClusterName: "cluster3", Status: Succeeded, Revision: "v1", PreviousRevision: "v2"},
+			},
+			expectState: RolloutAborted,
+		},
+		{
+			name:            "roll back without revision",
+			rolloutStrategy: perGroup,
+			control:         RolloutControl{State: RollingBack},
+			expectState:     RollingBack,
+			expectErr:       true,
+		},
+	}
+
+	RolloutClock = testingclock.NewFakeClock(fakeTime.Time)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeGetter := FakePlacementDecisionGetter{}
+			tracker := clusterv1beta1sdk.NewPlacementDecisionClustersTrackerWithGroups(nil, &fakeGetter, clusterGroups)
+			rolloutHandler, _ := NewRolloutHandler(tracker, dummyWorkloadClusterRolloutStatusFunc)
+
+			_, result, err := rolloutHandler.GetRolloutClusterWithControl(test.rolloutStrategy, test.control, test.existingClusterStatus)
+			if test.expectErr != (err != nil) {
+				t.Fatalf("expect error %v, but got %v", test.expectErr, err)
+			}
+			if result.State != test.expectState {
+				t.Errorf("expect state %s, but got %s", test.expectState, result.State)
+			}
+			if !reflect.DeepEqual(result.PausedAfter, test.expectPausedAfter) {
+				t.Errorf("expect paused after %v, but got %v", test.expectPausedAfter, result.PausedAfter)
+			}
+			if !reflect.DeepEqual(result.ClustersToRollout, test.expectRolloutClusters) {
+				t.Errorf("expect rollout clusters %+v, but got %+v", test.expectRolloutClusters, result.ClustersToRollout)
+			}
+			if !reflect.DeepEqual(result.ClustersToRollback, test.expectRollbackClusters) {
+				t.Errorf("expect rollback clusters %+v, but got %+v", test.expectRollbackClusters, result.ClustersToRollback)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	clusterlisterv1alpha1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1alpha1"
+
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cel/library"
+)
+
+// ClusterScoreFunc returns the score of a cluster to order the clusters of a rollout.
+type ClusterScoreFunc func(clusterName string) (float64, error)
+
+// ClusterOrdering orders the candidate clusters of a rollout by their scores instead of their names. The clusters
+// are ordered within each decision group for the ProgressivePerGroup, and within the clusters besides the
+// mandatory decision groups for the Progressive. The clusters that have no score are rolled out after the scored
+// clusters, and the clusters with the same score are ordered by their names.
+type ClusterOrdering struct {
+	// Score returns the score of a cluster, the cluster has no score if an error, NaN or an infinite score is
+	// returned.
+	Score ClusterScoreFunc
+	// Descending rolls out the clusters with the highest scores first, the clusters with the lowest scores are
+	// rolled out first by default.
+	Descending bool
+}
+
+// WithClusterOrdering sets the ordering of the clusters to roll out, the clusters are ordered by their names if it
+// is not set.
+func (r *RolloutHandler[T]) WithClusterOrdering(ordering ClusterOrdering) *RolloutHandler[T] {
+	r.ordering = &ordering
+	return r
+}
+
+// sortClusters sorts the clusters in place with the ordering of the handler.
+func (r *RolloutHandler[T]) sortClusters(clusters []string) {
+	if r.ordering == nil || r.ordering.Score == nil {
+		sort.Strings(clusters)
+		return
+	}
+
+	scores := make(map[string]float64, len(clusters))
+	for _, cluster := range clusters {
+		score, err := r.ordering.Score(cluster)
+		if err != nil {
+			klog.V(4).Infof("failed to get the score of cluster %s: %v", cluster, err)
+			continue
+		}
+		// the clusters cannot be ordered by NaN or infinite scores, they are treated as the clusters without score
+		if !isFinite(score) {
+			klog.V(4).Infof("the score %v of cluster %s is not a finite number", score, cluster)
+			continue
+		}
+		scores[cluster] = score
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		scoreI, scoredI := scores[clusters[i]]
+		scoreJ, scoredJ := scores[clusters[j]]
+		switch {
+		case scoredI != scoredJ:
+			return scoredI
+		case scoreI == scoreJ:
+			return clusters[i] < clusters[j]
+		case r.ordering.Descending:
+			return scoreI > scoreJ
+		default:
+			return scoreI < scoreJ
+		}
+	})
+}
+
+// AddOnPlacementScoreFunc returns the value of the score item in the AddOnPlacementScore of a cluster.
+func AddOnPlacementScoreFunc(scoreLister clusterlisterv1alpha1.AddOnPlacementScoreLister,
+	scoreName, itemName string) ClusterScoreFunc {
+	return func(clusterName string) (float64, error) {
+		score, err := scoreLister.AddOnPlacementScores(clusterName).Get(scoreName)
+		if err != nil {
+			return 0, err
+		}
+		for _, item := range score.Status.Scores {
+			if item.Name == itemName {
+				return float64(item.Value), nil
+			}
+		}
+		return 0, fmt.Errorf("score %s is not found in AddOnPlacementScore %s", itemName, scoreName)
+	}
+}
+
+// LabelScoreFunc returns the numeric value of the label of a cluster, the NaN and infinite values are invalid.
+func LabelScoreFunc(clusterLister clusterlisterv1.ManagedClusterLister, label string) ClusterScoreFunc {
+	return func(clusterName string) (float64, error) {
+		cluster, err := clusterLister.Get(clusterName)
+		if err != nil {
+			return 0, err
+		}
+		value, ok := cluster.Labels[label]
+		if !ok {
+			return 0, fmt.Errorf("label %s is not found", label)
+		}
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, err
+		}
+		if !isFinite(score) {
+			return 0, fmt.Errorf("the value %q of label %s is not a finite number", value, label)
+		}
+		return score, nil
+	}
+}
+
+func isFinite(score float64) bool {
+	return !math.IsNaN(score) && !math.IsInf(score, 0)
+}
+
+// CELScoreFunc returns the value of a CEL expression of a cluster. The expression is evaluated with the
+// managedCluster variable and the scores function of the ManagedClusterLib, and returns a number, e.g.
+// managedCluster.scores("resource-usage").filter(s, s.name == "cpu")[0].value
+func CELScoreFunc(clusterLister clusterlisterv1.ManagedClusterLister,
+	scoreLister clusterlisterv1alpha1.AddOnPlacementScoreLister, expression string) (ClusterScoreFunc, error) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		library.ManagedClusterLib(scoreLister),
+		library.JsonLib(),
+	}, common.BaseEnvOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := common.CompileProgram(env, expression, &library.CostEstimator{}, common.DefaultCostLimit,
+		types.IntType, types.UintType, types.DoubleType, types.DynType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid score expression: %v", err)
+	}
+
+	return func(clusterName string) (float64, error) {
+		cluster, err := clusterLister.Get(clusterName)
+		if err != nil {
+			return 0, err
+		}
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster)
+		if err != nil {
+			return 0, err
+		}
+
+		result, _, err := program.Eval(map[string]interface{}{"managedCluster": obj})
+		if err != nil {
+			return 0, err
+		}
+		score := result.ConvertToType(types.DoubleType)
+		if types.IsError(score) {
+			return 0, fmt.Errorf("the score %v is not a number", result.Value())
+		}
+		return float64(score.(types.Double)), nil
+	}, nil
+}
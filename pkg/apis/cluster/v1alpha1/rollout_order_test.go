@@ -0,0 +1,204 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	testingclock "k8s.io/utils/clock/testing"
+	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	clusterlisterv1alpha1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+func fakeScores(scores map[string]float64) ClusterScoreFunc {
+	return func(clusterName string) (float64, error) {
+		score, ok := scores[clusterName]
+		if !ok {
+			return 0, fmt.Errorf("no score")
+		}
+		return score, nil
+	}
+}
+
+func TestSortClusters(t *testing.T) {
+	scores := fakeScores(map[string]float64{"cluster1": 3, "cluster2": 1, "cluster3": 2, "cluster4": 1})
+	nonFiniteScores := fakeScores(map[string]float64{"cluster1": 3, "cluster2": math.NaN(), "cluster3": math.Inf(-1),
+		"cluster4": 1})
+
+	tests := []struct {
+		name     string
+		ordering *ClusterOrdering
+		expected []string
+	}{
+		{
+			name:     "by names",
+			expected: []string{"cluster1", "cluster2", "cluster3", "cluster4", "cluster5"},
+		},
+		{
+			name:     "by ascending scores",
+			ordering: &ClusterOrdering{Score: scores},
+			expected: []string{"cluster2", "cluster4", "cluster3", "cluster1", "cluster5"},
+		},
+		{
+			name:     "by descending scores",
+			ordering: &ClusterOrdering{Score: scores, Descending: true},
+			expected: []string{"cluster1", "cluster3", "cluster2", "cluster4", "cluster5"},
+		},
+		{
+			name:     "non-finite scores",
+			ordering: &ClusterOrdering{Score: nonFiniteScores},
+			expected: []string{"cluster4", "cluster1", "cluster2", "cluster3", "cluster5"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := &RolloutHandler[dummyWorkload]{ordering: test.ordering}
+			clusters := []string{"cluster5", "cluster4", "cluster3", "cluster2", "cluster1"}
+			handler.sortClusters(clusters)
+			if !reflect.DeepEqual(clusters, test.expected) {
+				t.Errorf("expect %v, but got %v", test.expected, clusters)
+			}
+		})
+	}
+}
+
+func TestGetRolloutClusterWithOrdering(t *testing.T) {
+	group0 := clusterv1beta1sdk.GroupKey{GroupName: "canary", GroupIndex: 0}
+	group1 := clusterv1beta1sdk.GroupKey{GroupIndex: 1}
+	clusterGroups := map[clusterv1beta1sdk.GroupKey]sets.Set[string]{
+		group0: sets.New[string]("cluster1", "cluster2"),
+		group1: sets.New[string]("cluster3", "cluster4", "cluster5", "cluster6"),
+	}
+	ordering := ClusterOrdering{
+		Score: fakeScores(map[string]float64{
+			"cluster1": 2, "cluster2": 1, "cluster3": 40, "cluster4": 30, "cluster5": 20, "cluster6": 10,
+		}),
+	}
+
+	tests := []struct {
+		name                  string
+		rolloutStrategy       clusterv1alpha1.RolloutStrategy
+		existingClusterStatus []ClusterRolloutStatus
+		expectRolloutClusters []ClusterRolloutStatus
+	}{
+		{
+			name: "progressive rolls out the clusters with the lowest scores first",
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.Progressive,
+				Progressive: &clusterv1alpha1.RolloutProgressive{
+					MandatoryDecisionGroups: clusterv1alpha1.MandatoryDecisionGroups{
+						MandatoryDecisionGroups: []clusterv1alpha1.MandatoryDecisionGroup{{GroupName: "canary"}},
+					},
+					MaxConcurrency: intstr.FromInt32(2),
+				},
+			},
+			existingClusterStatus: []ClusterRolloutStatus{
+				{ClusterName: "cluster1", GroupKey: group0, Status: Succeeded},
+				{ClusterName: "cluster2", GroupKey: group0, Status: Succeeded},
+			},
+			expectRolloutClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster6", GroupKey: group1, Status: ToApply},
+				{ClusterName: "cluster5", GroupKey: group1, Status: ToApply},
+			},
+		},
+		{
+			name:            "progressive per group orders the clusters in the group",
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{Type: clusterv1alpha1.ProgressivePerGroup},
+			expectRolloutClusters: []ClusterRolloutStatus{
+				{ClusterName: "cluster2", GroupKey: group0, Status: ToApply},
+				{ClusterName: "cluster1", GroupKey: group0, Status: ToApply},
+			},
+		},
+	}
+
+	RolloutClock = testingclock.NewFakeClock(fakeTime.Time)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeGetter := FakePlacementDecisionGetter{}
+			tracker := clusterv1beta1sdk.NewPlacementDecisionClustersTrackerWithGroups(nil, &fakeGetter, clusterGroups)
+			handler, _ := NewRolloutHandler(tracker, dummyWorkloadClusterRolloutStatusFunc)
+
+			_, result, err := handler.WithClusterOrdering(ordering).GetRolloutCluster(test.rolloutStrategy, test.existingClusterStatus)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.ClustersToRollout, test.expectRolloutClusters) {
+				t.Errorf("expect rollout clusters %+v, but got %+v", test.expectRolloutClusters, result.ClustersToRollout)
+			}
+		})
+	}
+}
+
+func TestClusterScoreFuncs(t *testing.T) {
+	clusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	scoreIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range []interface{}{
+		&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Labels: map[string]string{"risk": "0.5"}}},
+		&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster2", Labels: map[string]string{"risk": "high"}}},
+		&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster3", Labels: map[string]string{"risk": "NaN"}}},
+		&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster4", Labels: map[string]string{"risk": "-Inf"}}},
+	} {
+		if err := clusterIndexer.Add(obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := scoreIndexer.Add(&clusterv1alpha1.AddOnPlacementScore{
+		ObjectMeta: metav1.ObjectMeta{Name: "usage", Namespace: "cluster1"},
+		Status: clusterv1alpha1.AddOnPlacementScoreStatus{
+			Scores: []clusterv1alpha1.AddOnPlacementScoreItem{{Name: "cpu", Value: 30}, {Name: "memory", Value: 60}},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	clusterLister := clusterlisterv1.NewManagedClusterLister(clusterIndexer)
+	scoreLister := clusterlisterv1alpha1.NewAddOnPlacementScoreLister(scoreIndexer)
+
+	celScore, err := CELScoreFunc(clusterLister, scoreLister,
+		`managedCluster.scores("usage").filter(s, s.name == "memory")[0].value / 2`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CELScoreFunc(clusterLister, scoreLister, `"high"`); err == nil {
+		t.Errorf("expect error for the expression that is not a number")
+	}
+
+	tests := []struct {
+		name        string
+		score       ClusterScoreFunc
+		cluster     string
+		expected    float64
+		expectedErr bool
+	}{
+		{name: "addon placement score", score: AddOnPlacementScoreFunc(scoreLister, "usage", "cpu"), cluster: "cluster1", expected: 30},
+		{name: "missing score item", score: AddOnPlacementScoreFunc(scoreLister, "usage", "gpu"), cluster: "cluster1", expectedErr: true},
+		{name: "missing addon placement score", score: AddOnPlacementScoreFunc(scoreLister, "usage", "cpu"), cluster: "cluster2", expectedErr: true},
+		{name: "label", score: LabelScoreFunc(clusterLister, "risk"), cluster: "cluster1", expected: 0.5},
+		{name: "invalid label", score: LabelScoreFunc(clusterLister, "risk"), cluster: "cluster2", expectedErr: true},
+		{name: "missing label", score: LabelScoreFunc(clusterLister, "region"), cluster: "cluster1", expectedErr: true},
+		{name: "NaN label", score: LabelScoreFunc(clusterLister, "risk"), cluster: "cluster3", expectedErr: true},
+		{name: "infinite label", score: LabelScoreFunc(clusterLister, "risk"), cluster: "cluster4", expectedErr: true},
+		{name: "cel", score: celScore, cluster: "cluster1", expected: 30},
+		{name: "cel without score", score: celScore, cluster: "cluster2", expectedErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			score, err := test.score(test.cluster)
+			if test.expectedErr != (err != nil) {
+				t.Fatalf("expect error %v, but got %v", test.expectedErr, err)
+			}
+			if score != test.expected {
+				t.Errorf("expect score %v, but got %v", test.expected, score)
+			}
+		})
+	}
+}
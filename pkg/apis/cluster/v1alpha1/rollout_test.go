@@ -2459,7 +2459,7 @@ func TestDetermineRolloutStatus(t *testing.T) {
 	RolloutClock = testingclock.NewFakeClock(fakeTime.Time)
 	for _, tc := range testCases {
 		var rolloutClusters, timeoutClusters []ClusterRolloutStatus
-		rolloutClusters, timeoutClusters = determineRolloutStatus(&tc.clusterStatus, tc.minSuccessTime, tc.timeout, rolloutClusters, timeoutClusters)
+		rolloutClusters, timeoutClusters = determineRolloutStatus(&tc.clusterStatus, tc.minSuccessTime, tc.timeout, rolloutClusters, timeoutClusters, RolloutClock)
 		if !reflect.DeepEqual(rolloutClusters, tc.expectRolloutClusters) {
 			t.Errorf("Case: %v Failed to run NewRolloutHandler.\nExpect rollout clusters: %+v\nActual rollout clusters: %+v", tc.name, tc.expectRolloutClusters, rolloutClusters)
 			return
@@ -2530,7 +2530,7 @@ func TestMinRecheckAfter(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			result := minRecheckAfter(tc.rolloutClusters)
+			result := minRecheckAfter(tc.rolloutClusters, RolloutClock)
 
 			if tc.expectedRecheckAfter == nil {
 				if result != nil {
@@ -0,0 +1,301 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
+
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+// defaultMaxSimulationSteps limits the steps of a simulation in case the strategy never completes.
+const defaultMaxSimulationSteps = 100000
+
+// ClusterOutcome is the outcome of the workload on a cluster once it is applied.
+type ClusterOutcome struct {
+	// Status is the final status of the workload, Succeeded or Failed. The workload never completes if the status
+	// is Progressing.
+	Status RolloutStatus
+	// Duration is the time that the workload takes to reach the final status after it is applied.
+	Duration time.Duration
+}
+
+// OutcomeModel returns the outcome of the workload on a cluster, it is called once a cluster is applied.
+type OutcomeModel func(clusterName string) ClusterOutcome
+
+// ScriptedOutcomes returns an OutcomeModel with the outcomes of the clusters, the default outcome is returned for
+// the clusters that are not in the outcomes.
+func ScriptedOutcomes(outcomes map[string]ClusterOutcome, defaultOutcome ClusterOutcome) OutcomeModel {
+	return func(clusterName string) ClusterOutcome {
+		if outcome, ok := outcomes[clusterName]; ok {
+			return outcome
+		}
+		return defaultOutcome
+	}
+}
+
+// RandomOutcomes returns an OutcomeModel in which a cluster fails with the failureRate, never completes with the
+// hangRate, and takes a random duration between the minDuration and maxDuration. The outcomes are the same for
+// the same seed.
+func RandomOutcomes(seed int64, failureRate, hangRate float64, minDuration, maxDuration time.Duration) OutcomeModel {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec // the simulation does not need a secure random number
+	return func(clusterName string) ClusterOutcome {
+		duration := minDuration
+		if maxDuration > minDuration {
+			duration = duration + time.Duration(r.Int63n(int64(maxDuration-minDuration)))
+		}
+
+		p := r.Float64()
+		switch {
+		case p < hangRate:
+			return ClusterOutcome{Status: Progressing, Duration: duration}
+		case p < hangRate+failureRate:
+			return ClusterOutcome{Status: Failed, Duration: duration}
+		default:
+			return ClusterOutcome{Status: Succeeded, Duration: duration}
+		}
+	}
+}
+
+// SimulationEventType is the type of a simulation event.
+type SimulationEventType string
+
+const (
+	// SimulationRollout means the workload is applied to the clusters of a batch.
+	SimulationRollout SimulationEventType = "Rollout"
+	// SimulationSucceeded means the workload succeeds on the clusters.
+	SimulationSucceeded SimulationEventType = "Succeeded"
+	// SimulationFailed means the workload fails on the clusters.
+	SimulationFailed SimulationEventType = "Failed"
+	// SimulationTimeOut means the clusters time out.
+	SimulationTimeOut SimulationEventType = "TimeOut"
+	// SimulationMaxFailureBreach means the MaxFailures is breached and the rollout stops.
+	SimulationMaxFailureBreach SimulationEventType = "MaxFailureBreach"
+)
+
+// SimulationEvent is an event on the timeline of a simulation.
+type SimulationEvent struct {
+	Time     time.Time
+	Type     SimulationEventType
+	Clusters []string
+}
+
+func (e SimulationEvent) String() string {
+	return fmt.Sprintf("%s %s %v", e.Time.Format(time.RFC3339), e.Type, e.Clusters)
+}
+
+// SimulationResult is the result of a simulation.
+type SimulationResult struct {
+	// Timeline is the ordered events of the simulation.
+	Timeline []SimulationEvent
+	// Duration is the time from the start to the last event.
+	Duration time.Duration
+	// Succeeded, Failed and TimedOut are the clusters by their final status.
+	Succeeded []string
+	Failed    []string
+	TimedOut  []string
+	// Progressing are the clusters that are applied and never complete.
+	Progressing []string
+	// Pending are the clusters that are never applied.
+	Pending []string
+	// MaxFailureBreach is true if the MaxFailures is breached at the end.
+	MaxFailureBreach bool
+}
+
+// Batches returns the clusters of the rollout events in order.
+func (r *SimulationResult) Batches() [][]string {
+	var batches [][]string
+	for _, event := range r.Timeline {
+		if event.Type == SimulationRollout {
+			batches = append(batches, event.Clusters)
+		}
+	}
+	return batches
+}
+
+// RolloutSimulator simulates a rollout strategy by driving a RolloutHandler with a fake clock, the RolloutClock is
+// not changed, so the simulations are able to run in parallel with each other and with a real rollout.
+type RolloutSimulator struct {
+	// ClusterGroups are the decision groups of the clusters.
+	ClusterGroups clusterv1beta1sdk.ClusterGroupsMap
+	// Outcomes returns the outcome of the workload on a cluster.
+	Outcomes OutcomeModel
+	// StartTime is the time that the simulation starts, it is the current time if it is not set.
+	StartTime time.Time
+	// MaxSteps limits the number of the RolloutHandler calls, the default is 100000.
+	MaxSteps int
+}
+
+type simulatedCluster struct {
+	appliedTime time.Time
+	outcome     ClusterOutcome
+	status      RolloutStatus
+}
+
+// Simulate runs the rollout strategy until no more cluster can be applied and no cluster status changes.
+func (s *RolloutSimulator) Simulate(rolloutStrategy clusterv1alpha1.RolloutStrategy) (*SimulationResult, error) {
+	startTime := s.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
+	maxSteps := s.MaxSteps
+	if maxSteps <= 0 {
+		maxSteps = defaultMaxSimulationSteps
+	}
+
+	fakeClock := testingclock.NewFakeClock(startTime)
+	tracker := clusterv1beta1sdk.NewPlacementDecisionClustersTrackerWithGroups(nil, nil, s.ClusterGroups)
+	handler, err := NewRolloutHandler(tracker, func(clusterName string, status ClusterRolloutStatus) (ClusterRolloutStatus, error) {
+		return status, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	handler.WithClock(fakeClock)
+
+	result := &SimulationResult{}
+	clusters := map[string]*simulatedCluster{}
+	timedOut := sets.New[string]()
+	lastEventTime := startTime
+	addEvent := func(eventTime time.Time, eventType SimulationEventType, names []string) {
+		sort.Strings(names)
+		result.Timeline = append(result.Timeline, SimulationEvent{Time: eventTime, Type: eventType, Clusters: names})
+		lastEventTime = eventTime
+	}
+
+	var rolloutResult RolloutResult
+	for step := 0; ; step++ {
+		if step >= maxSteps {
+			return result, fmt.Errorf("the simulation does not complete in %d steps", maxSteps)
+		}
+		now := fakeClock.Now()
+
+		// the clock always stops at the completion time of the clusters, so the clusters complete at now.
+		var succeeded, failed []string
+		for name, cluster := range clusters {
+			if cluster.status != Progressing || cluster.outcome.Status == Progressing ||
+				now.Before(cluster.completionTime()) {
+				continue
+			}
+			cluster.status = cluster.outcome.Status
+			if cluster.status == Succeeded {
+				succeeded = append(succeeded, name)
+			} else {
+				failed = append(failed, name)
+			}
+		}
+		if len(succeeded) != 0 {
+			addEvent(now, SimulationSucceeded, succeeded)
+		}
+		if len(failed) != 0 {
+			addEvent(now, SimulationFailed, failed)
+		}
+
+		var existingClusterStatus []ClusterRolloutStatus
+		for _, name := range sets.List(sets.KeySet(clusters)) {
+			cluster := clusters[name]
+			status := ClusterRolloutStatus{ClusterName: name, Status: cluster.status}
+			lastTransitionTime := metav1.NewTime(cluster.appliedTime)
+			if cluster.status != Progressing {
+				lastTransitionTime = metav1.NewTime(cluster.completionTime())
+			}
+			status.LastTransitionTime = &lastTransitionTime
+			existingClusterStatus = append(existingClusterStatus, status)
+		}
+
+		previousBreach := rolloutResult.MaxFailureBreach
+		_, rolloutResult, err = handler.GetRolloutCluster(rolloutStrategy, existingClusterStatus)
+		if err != nil {
+			return result, err
+		}
+
+		var newTimeOut []string
+		currentTimeOut := sets.New[string]()
+		for _, status := range rolloutResult.ClustersTimeOut {
+			currentTimeOut.Insert(status.ClusterName)
+			if !timedOut.Has(status.ClusterName) {
+				newTimeOut = append(newTimeOut, status.ClusterName)
+			}
+		}
+		timedOut = currentTimeOut
+		if len(newTimeOut) != 0 {
+			addEvent(now, SimulationTimeOut, newTimeOut)
+		}
+		if rolloutResult.MaxFailureBreach && !previousBreach {
+			addEvent(now, SimulationMaxFailureBreach, nil)
+		}
+
+		var batch []string
+		for _, status := range rolloutResult.ClustersToRollout {
+			if status.Status != ToApply {
+				continue
+			}
+			clusters[status.ClusterName] = &simulatedCluster{
+				appliedTime: now,
+				outcome:     s.Outcomes(status.ClusterName),
+				status:      Progressing,
+			}
+			batch = append(batch, status.ClusterName)
+		}
+		if len(batch) != 0 {
+			addEvent(now, SimulationRollout, batch)
+			// check the status of the applied clusters at the same time
+			continue
+		}
+
+		next := s.nextTime(now, clusters, rolloutResult.RecheckAfter)
+		if next == nil {
+			break
+		}
+		fakeClock.SetTime(*next)
+	}
+
+	result.Duration = lastEventTime.Sub(startTime)
+	result.MaxFailureBreach = rolloutResult.MaxFailureBreach
+	for _, name := range sets.List(s.ClusterGroups.GetClusters()) {
+		cluster, ok := clusters[name]
+		switch {
+		case !ok:
+			result.Pending = append(result.Pending, name)
+		case timedOut.Has(name):
+			result.TimedOut = append(result.TimedOut, name)
+		case cluster.status == Succeeded:
+			result.Succeeded = append(result.Succeeded, name)
+		case cluster.status == Failed:
+			result.Failed = append(result.Failed, name)
+		default:
+			result.Progressing = append(result.Progressing, name)
+		}
+	}
+	return result, nil
+}
+
+// nextTime returns the earliest time after now that a cluster completes or the rollout should be rechecked.
+func (s *RolloutSimulator) nextTime(now time.Time, clusters map[string]*simulatedCluster, recheckAfter *time.Duration) *time.Time {
+	var next *time.Time
+	earlier := func(t time.Time) {
+		if t.After(now) && (next == nil || t.Before(*next)) {
+			next = &t
+		}
+	}
+
+	for _, cluster := range clusters {
+		if cluster.status == Progressing && cluster.outcome.Status != Progressing {
+			earlier(cluster.completionTime())
+		}
+	}
+	if recheckAfter != nil {
+		earlier(now.Add(*recheckAfter))
+	}
+	return next
+}
+
+func (c *simulatedCluster) completionTime() time.Time {
+	return c.appliedTime.Add(c.outcome.Duration)
+}
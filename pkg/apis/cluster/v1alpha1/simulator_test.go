@@ -0,0 +1,176 @@
+package v1alpha1
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+func TestRolloutSimulator(t *testing.T) {
+	start := fakeTime.Time
+	at := func(d time.Duration) time.Time {
+		return start.Add(d)
+	}
+	succeed := ClusterOutcome{Status: Succeeded, Duration: time.Minute}
+
+	tests := []struct {
+		name            string
+		clusterGroups   clusterv1beta1sdk.ClusterGroupsMap
+		outcomes        OutcomeModel
+		rolloutStrategy clusterv1alpha1.RolloutStrategy
+		expectTimeline  []SimulationEvent
+		expectResult    SimulationResult
+	}{
+		{
+			name: "progressive per group with min success time",
+			clusterGroups: clusterv1beta1sdk.ClusterGroupsMap{
+				{GroupIndex: 0}: sets.New[string]("cluster1", "cluster2"),
+				{GroupIndex: 1}: sets.New[string]("cluster3", "cluster4"),
+			},
+			outcomes: ScriptedOutcomes(nil, succeed),
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.ProgressivePerGroup,
+				ProgressivePerGroup: &clusterv1alpha1.RolloutProgressivePerGroup{
+					RolloutConfig: clusterv1alpha1.RolloutConfig{MinSuccessTime: metav1.Duration{Duration: 30 * time.Second}},
+				},
+			},
+			expectTimeline: []SimulationEvent{
+				{Time: at(0), Type: SimulationRollout, Clusters: []string{"cluster1", "cluster2"}},
+				{Time: at(time.Minute), Type: SimulationSucceeded, Clusters: []string{"cluster1", "cluster2"}},
+				{Time: at(90 * time.Second), Type: SimulationRollout, Clusters: []string{"cluster3", "cluster4"}},
+				{Time: at(150 * time.Second), Type: SimulationSucceeded, Clusters: []string{"cluster3", "cluster4"}},
+			},
+			expectResult: SimulationResult{
+				Duration:  150 * time.Second,
+				Succeeded: []string{"cluster1", "cluster2", "cluster3", "cluster4"},
+			},
+		},
+		{
+			name: "progressive stops once the max failures is breached",
+			clusterGroups: clusterv1beta1sdk.ClusterGroupsMap{
+				{GroupIndex: 0}: sets.New[string]("cluster1", "cluster2", "cluster3", "cluster4"),
+			},
+			outcomes: ScriptedOutcomes(map[string]ClusterOutcome{
+				"cluster2": {Status: Failed, Duration: time.Minute},
+			}, succeed),
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{
+				Type: clusterv1alpha1.Progressive,
+				Progressive: &clusterv1alpha1.RolloutProgressive{
+					RolloutConfig:  clusterv1alpha1.RolloutConfig{ProgressDeadline: "2m"},
+					MaxConcurrency: intstr.FromInt32(2),
+				},
+			},
+			expectTimeline: []SimulationEvent{
+				{Time: at(0), Type: SimulationRollout, Clusters: []string{"cluster1", "cluster2"}},
+				{Time: at(time.Minute), Type: SimulationSucceeded, Clusters: []string{"cluster1"}},
+				{Time: at(time.Minute), Type: SimulationFailed, Clusters: []string{"cluster2"}},
+				{Time: at(time.Minute), Type: SimulationMaxFailureBreach},
+				{Time: at(3 * time.Minute), Type: SimulationTimeOut, Clusters: []string{"cluster2"}},
+			},
+			expectResult: SimulationResult{
+				Duration:         3 * time.Minute,
+				Succeeded:        []string{"cluster1"},
+				TimedOut:         []string{"cluster2"},
+				Pending:          []string{"cluster3", "cluster4"},
+				MaxFailureBreach: true,
+			},
+		},
+		{
+			name: "all with a cluster that never completes",
+			clusterGroups: clusterv1beta1sdk.ClusterGroupsMap{
+				{GroupIndex: 0}: sets.New[string]("cluster1", "cluster2"),
+			},
+			outcomes: ScriptedOutcomes(map[string]ClusterOutcome{
+				"cluster1": {Status: Progressing},
+			}, succeed),
+			rolloutStrategy: clusterv1alpha1.RolloutStrategy{Type: clusterv1alpha1.All},
+			expectTimeline: []SimulationEvent{
+				{Time: at(0), Type: SimulationRollout, Clusters: []string{"cluster1", "cluster2"}},
+				{Time: at(time.Minute), Type: SimulationSucceeded, Clusters: []string{"cluster2"}},
+			},
+			expectResult: SimulationResult{
+				Duration:    time.Minute,
+				Succeeded:   []string{"cluster2"},
+				Progressing: []string{"cluster1"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the simulations do not share the RolloutClock, so they are able to run in parallel
+			t.Parallel()
+			simulator := &RolloutSimulator{ClusterGroups: test.clusterGroups, Outcomes: test.outcomes, StartTime: start}
+			result, err := simulator.Simulate(test.rolloutStrategy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result.Timeline, test.expectTimeline) {
+				t.Errorf("expect timeline %v, but got %v", test.expectTimeline, result.Timeline)
+			}
+			result.Timeline = nil
+			if !reflect.DeepEqual(*result, test.expectResult) {
+				t.Errorf("expect result %+v, but got %+v", test.expectResult, *result)
+			}
+		})
+	}
+}
+
+func TestRolloutSimulatorWithRandomOutcomes(t *testing.T) {
+	clusterGroups := clusterv1beta1sdk.ClusterGroupsMap{}
+	for i := 0; i < 500; i++ {
+		groupKey := clusterv1beta1sdk.GroupKey{GroupIndex: int32(i / 100)}
+		if _, ok := clusterGroups[groupKey]; !ok {
+			clusterGroups[groupKey] = sets.New[string]()
+		}
+		clusterGroups[groupKey].Insert(fmt.Sprintf("cluster%03d", i))
+	}
+	rolloutStrategy := clusterv1alpha1.RolloutStrategy{
+		Type: clusterv1alpha1.ProgressivePerGroup,
+		ProgressivePerGroup: &clusterv1alpha1.RolloutProgressivePerGroup{
+			RolloutConfig: clusterv1alpha1.RolloutConfig{
+				MinSuccessTime:   metav1.Duration{Duration: time.Minute},
+				ProgressDeadline: "10m",
+				MaxFailures:      intstr.FromString("5%"),
+			},
+		},
+	}
+
+	originalClock := RolloutClock
+	simulate := func() *SimulationResult {
+		simulator := &RolloutSimulator{
+			ClusterGroups: clusterGroups,
+			Outcomes:      RandomOutcomes(1, 0.02, 0.01, time.Minute, 5*time.Minute),
+			StartTime:     fakeTime.Time,
+		}
+		result, err := simulator.Simulate(rolloutStrategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+
+	result := simulate()
+	if RolloutClock != originalClock {
+		t.Errorf("expect the rollout clock is not changed")
+	}
+	if !reflect.DeepEqual(result, simulate()) {
+		t.Errorf("expect the same result with the same seed")
+	}
+
+	total := len(result.Succeeded) + len(result.Failed) + len(result.TimedOut) + len(result.Progressing) + len(result.Pending)
+	if total != 500 {
+		t.Errorf("expect 500 clusters in the result, but got %d", total)
+	}
+	if len(result.Batches()) != 5 {
+		t.Errorf("expect 5 batches, but got %v", len(result.Batches()))
+	}
+}
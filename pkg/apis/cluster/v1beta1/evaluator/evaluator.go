@@ -0,0 +1,566 @@
+package evaluator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/clock"
+
+	clusterlisterv1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1"
+	clusterlisterv1alpha1 "open-cluster-management.io/api/client/cluster/listers/cluster/v1alpha1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+	clusterv1beta2sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta2"
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cel/library"
+)
+
+// FilterType is the step of the evaluation that filters out a cluster.
+type FilterType string
+
+const (
+	// FilterClusterSet means the cluster is not in the ManagedClusterSets bound to the placement namespace, or it
+	// is being deleted.
+	FilterClusterSet FilterType = "ClusterSet"
+	// FilterPredicate means the cluster does not match any predicate of the placement.
+	FilterPredicate FilterType = "Predicate"
+	// FilterToleration means the placement does not tolerate a taint of the cluster.
+	FilterToleration FilterType = "Toleration"
+	// FilterNumberOfClusters means the cluster is feasible but not in the top NumberOfClusters clusters.
+	FilterNumberOfClusters FilterType = "NumberOfClusters"
+)
+
+// ClusterExplanation explains why a cluster is selected or not.
+type ClusterExplanation struct {
+	ClusterName string
+	// Selected is true if the cluster is in the decisions.
+	Selected bool
+	// FilteredBy is the step that filters out the cluster, it is empty if the cluster is selected.
+	FilteredBy FilterType
+	Reason     string
+	// Scores are the weighted scores of the prioritizers, the keys are the built-in prioritizer names or
+	// AddOn/<resourceName>/<scoreName>. The scores are only computed for the clusters that pass the filters.
+	Scores     map[string]int64
+	TotalScore int64
+	// GroupKey is the decision group of the selected cluster.
+	GroupKey clusterv1beta1sdk.GroupKey
+}
+
+// DecisionGroup is a decision group of the placement.
+type DecisionGroup struct {
+	GroupKey clusterv1beta1sdk.GroupKey
+	Clusters []string
+}
+
+// PlacementEvaluation is the result of a placement evaluation.
+type PlacementEvaluation struct {
+	// DecisionGroups are the decision groups ordered by their indexes, there is one empty group if no cluster is
+	// selected.
+	DecisionGroups []DecisionGroup
+	// Clusters explain all the clusters of the evaluator, ordered by their names.
+	Clusters                 []ClusterExplanation
+	NumberOfSelectedClusters int32
+	// Satisfied is false if fewer clusters than the NumberOfClusters are selected.
+	Satisfied bool
+}
+
+// ClusterGroups returns the selected clusters by their decision groups.
+func (e *PlacementEvaluation) ClusterGroups() clusterv1beta1sdk.ClusterGroupsMap {
+	clusterGroups := clusterv1beta1sdk.ClusterGroupsMap{}
+	for _, group := range e.DecisionGroups {
+		clusterGroups[group.GroupKey] = sets.New[string](group.Clusters...)
+	}
+	return clusterGroups
+}
+
+// Explanation returns the explanation of a cluster.
+func (e *PlacementEvaluation) Explanation(clusterName string) (ClusterExplanation, bool) {
+	for _, explanation := range e.Clusters {
+		if explanation.ClusterName == clusterName {
+			return explanation, true
+		}
+	}
+	return ClusterExplanation{}, false
+}
+
+// EvaluatorOption configures a PlacementEvaluator.
+type EvaluatorOption func(*PlacementEvaluator)
+
+// WithExistingDecisions sets the clusters in the current decisions of the placement, they are kept by the Steady
+// prioritizer and not filtered by the NoSelectIfNew taints.
+func WithExistingDecisions(clusters ...string) EvaluatorOption {
+	return func(e *PlacementEvaluator) {
+		e.existingDecisions = sets.New[string](clusters...)
+	}
+}
+
+// WithDecisionCounts sets the number of the placement decisions that select each cluster, they are used by the
+// Balance prioritizer.
+func WithDecisionCounts(decisionCounts map[string]int) EvaluatorOption {
+	return func(e *PlacementEvaluator) {
+		e.decisionCounts = decisionCounts
+	}
+}
+
+// WithClock sets the clock to check the expiration of the tolerations and the AddOnPlacementScores.
+func WithClock(clock clock.PassiveClock) EvaluatorOption {
+	return func(e *PlacementEvaluator) {
+		e.clock = clock
+	}
+}
+
+// PlacementEvaluator evaluates a Placement spec against a snapshot of the ManagedClusters, ManagedClusterSets,
+// ManagedClusterSetBindings and AddOnPlacementScores without the placement controller.
+//
+// A Placement is evaluated in order by:
+//  1. the clusters of the ManagedClusterSets that are bound to the placement namespace and listed in the
+//     ClusterSets of the placement, the clusters that are being deleted are excluded.
+//  2. the predicates, a cluster matches a predicate if it matches the label selector, the claim selector and all
+//     the CEL expressions of the predicate, and it passes if it matches any predicate.
+//  3. the tolerations of the NoSelect and NoSelectIfNew taints.
+//  4. the prioritizers with their weights, the Balance and Steady prioritizers are enabled with weight 1 in the
+//     Additive mode, and the clusters are ordered by their total scores and then their names.
+//  5. the NumberOfClusters, and the decision groups splitting of the selected clusters.
+//
+// The SpreadPolicy is not evaluated.
+type PlacementEvaluator struct {
+	clusters           []*clusterv1.ManagedCluster
+	clusterSets        map[string]*clusterv1beta2.ManagedClusterSet
+	clusterSetBindings clusterSetBindingsGetter
+	clusterLister      clusterlisterv1.ManagedClusterLister
+	scoreLister        clusterlisterv1alpha1.AddOnPlacementScoreLister
+	existingDecisions  sets.Set[string]
+	decisionCounts     map[string]int
+	clock              clock.PassiveClock
+}
+
+// NewPlacementEvaluator creates a PlacementEvaluator with a snapshot of the cluster resources.
+func NewPlacementEvaluator(
+	clusters []*clusterv1.ManagedCluster,
+	clusterSets []*clusterv1beta2.ManagedClusterSet,
+	clusterSetBindings []*clusterv1beta2.ManagedClusterSetBinding,
+	scores []*clusterv1alpha1.AddOnPlacementScore,
+	opts ...EvaluatorOption) (*PlacementEvaluator, error) {
+	clusterIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, cluster := range clusters {
+		if err := clusterIndexer.Add(cluster); err != nil {
+			return nil, err
+		}
+	}
+	scoreIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc,
+		cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, score := range scores {
+		if err := scoreIndexer.Add(score); err != nil {
+			return nil, err
+		}
+	}
+
+	sortedClusters := append([]*clusterv1.ManagedCluster{}, clusters...)
+	sort.Slice(sortedClusters, func(i, j int) bool {
+		return sortedClusters[i].Name < sortedClusters[j].Name
+	})
+
+	e := &PlacementEvaluator{
+		clusters:           sortedClusters,
+		clusterSets:        map[string]*clusterv1beta2.ManagedClusterSet{},
+		clusterSetBindings: clusterSetBindings,
+		clusterLister:      clusterlisterv1.NewManagedClusterLister(clusterIndexer),
+		scoreLister:        clusterlisterv1alpha1.NewAddOnPlacementScoreLister(scoreIndexer),
+		existingDecisions:  sets.New[string](),
+		decisionCounts:     map[string]int{},
+		clock:              clock.RealClock{},
+	}
+	for _, clusterSet := range clusterSets {
+		e.clusterSets[clusterSet.Name] = clusterSet
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e, nil
+}
+
+// Evaluate evaluates the placement. An error is returned if the placement is misconfigured, e.g. it has an
+// invalid selector or an unknown prioritizer.
+func (e *PlacementEvaluator) Evaluate(placement *clusterv1beta1.Placement) (*PlacementEvaluation, error) {
+	predicates, err := e.compilePredicates(placement.Spec.Predicates)
+	if err != nil {
+		return nil, err
+	}
+	prioritizers, err := e.prioritizers(placement.Spec.PrioritizerPolicy)
+	if err != nil {
+		return nil, err
+	}
+	clusterSetClusters, err := e.clusterSetClusters(placement)
+	if err != nil {
+		return nil, err
+	}
+
+	explanations := map[string]*ClusterExplanation{}
+	var feasible []*clusterv1.ManagedCluster
+	for _, cluster := range e.clusters {
+		explanation := &ClusterExplanation{ClusterName: cluster.Name}
+		explanations[cluster.Name] = explanation
+
+		switch {
+		case !clusterSetClusters.Has(cluster.Name):
+			explanation.FilteredBy = FilterClusterSet
+			explanation.Reason = "the cluster is not in the ManagedClusterSets bound to the placement namespace"
+		case cluster.DeletionTimestamp != nil:
+			explanation.FilteredBy = FilterClusterSet
+			explanation.Reason = "the cluster is being deleted"
+		default:
+			if reason := matchPredicates(predicates, cluster); len(reason) != 0 {
+				explanation.FilteredBy = FilterPredicate
+				explanation.Reason = reason
+				continue
+			}
+			if reason := e.tolerate(placement.Spec.Tolerations, cluster); len(reason) != 0 {
+				explanation.FilteredBy = FilterToleration
+				explanation.Reason = reason
+				continue
+			}
+			feasible = append(feasible, cluster)
+		}
+	}
+
+	for _, p := range prioritizers {
+		scores, err := p.score(feasible)
+		if err != nil {
+			return nil, fmt.Errorf("failed to score clusters with prioritizer %s: %w", p.name, err)
+		}
+		for _, cluster := range feasible {
+			explanation := explanations[cluster.Name]
+			if explanation.Scores == nil {
+				explanation.Scores = map[string]int64{}
+			}
+			score := int64(p.weight) * scores[cluster.Name]
+			explanation.Scores[p.name] = score
+			explanation.TotalScore += score
+		}
+	}
+	sort.SliceStable(feasible, func(i, j int) bool {
+		scoreI, scoreJ := explanations[feasible[i].Name].TotalScore, explanations[feasible[j].Name].TotalScore
+		if scoreI != scoreJ {
+			return scoreI > scoreJ
+		}
+		return feasible[i].Name < feasible[j].Name
+	})
+
+	selected := feasible
+	numberOfClusters := placement.Spec.NumberOfClusters
+	if numberOfClusters != nil && len(selected) > int(*numberOfClusters) {
+		for _, cluster := range selected[*numberOfClusters:] {
+			explanations[cluster.Name].FilteredBy = FilterNumberOfClusters
+			explanations[cluster.Name].Reason = fmt.Sprintf("the cluster is not in the top %d clusters", *numberOfClusters)
+		}
+		selected = selected[:*numberOfClusters]
+	}
+
+	decisionGroups, err := splitDecisionGroups(placement.Spec.DecisionStrategy.GroupStrategy, selected)
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range decisionGroups {
+		for _, cluster := range group.Clusters {
+			explanations[cluster].Selected = true
+			explanations[cluster].GroupKey = group.GroupKey
+		}
+	}
+
+	evaluation := &PlacementEvaluation{
+		DecisionGroups:           decisionGroups,
+		NumberOfSelectedClusters: int32(len(selected)), //nolint:gosec // the number of clusters fits in int32
+		Satisfied:                numberOfClusters == nil || len(selected) == int(*numberOfClusters),
+	}
+	for _, cluster := range e.clusters {
+		evaluation.Clusters = append(evaluation.Clusters, *explanations[cluster.Name])
+	}
+	return evaluation, nil
+}
+
+// clusterSetClusters returns the clusters of the ManagedClusterSets that are bound to the placement namespace and
+// listed in the ClusterSets of the placement.
+func (e *PlacementEvaluator) clusterSetClusters(placement *clusterv1beta1.Placement) (sets.Set[string], error) {
+	bindings, err := clusterv1beta2sdk.GetBoundManagedClusterSetBindings(placement.Namespace, e.clusterSetBindings)
+	if err != nil {
+		return nil, err
+	}
+
+	eligibleClusterSets := sets.New[string]()
+	for _, binding := range bindings {
+		eligibleClusterSets.Insert(binding.Spec.ClusterSet)
+	}
+	if len(placement.Spec.ClusterSets) != 0 {
+		eligibleClusterSets = eligibleClusterSets.Intersection(sets.New[string](placement.Spec.ClusterSets...))
+	}
+
+	clusters := sets.New[string]()
+	for _, clusterSetName := range sets.List(eligibleClusterSets) {
+		clusterSet, ok := e.clusterSets[clusterSetName]
+		if !ok {
+			continue
+		}
+		clusterSetClusters, err := clusterv1beta2sdk.GetClustersFromClusterSet(clusterSet, e.clusterLister)
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range clusterSetClusters {
+			clusters.Insert(cluster.Name)
+		}
+	}
+	return clusters, nil
+}
+
+type compiledPredicate struct {
+	labelSelector labels.Selector
+	claimSelector labels.Selector
+	expressions   []string
+	programs      []cel.Program
+}
+
+func (e *PlacementEvaluator) compilePredicates(predicates []clusterv1beta1.ClusterPredicate) ([]compiledPredicate, error) {
+	if len(predicates) == 0 {
+		return nil, nil
+	}
+
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		library.ManagedClusterLib(e.scoreLister),
+		library.JsonLib(),
+	}, common.BaseEnvOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var compiled []compiledPredicate
+	for i, predicate := range predicates {
+		selector := predicate.RequiredClusterSelector
+		labelSelector, claimSelector, err := buildSelectors(selector.LabelSelector, selector.ClaimSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of predicate %d: %w", i, err)
+		}
+
+		c := compiledPredicate{labelSelector: labelSelector, claimSelector: claimSelector}
+		for _, expression := range selector.CelSelector.CelExpressions {
+			program, err := common.CompileProgram(env, expression, &library.CostEstimator{},
+				common.DefaultCostLimit, types.BoolType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CEL expression %q of predicate %d: %v", expression, i, err)
+			}
+			c.expressions = append(c.expressions, expression)
+			c.programs = append(c.programs, program)
+		}
+		compiled = append(compiled, c)
+	}
+	return compiled, nil
+}
+
+// matchPredicates returns the reasons why the cluster does not match any predicate, it is empty if the cluster
+// matches a predicate.
+func matchPredicates(predicates []compiledPredicate, cluster *clusterv1.ManagedCluster) string {
+	if len(predicates) == 0 {
+		return ""
+	}
+
+	var reasons []string
+	for i, predicate := range predicates {
+		reason := predicate.match(cluster)
+		if len(reason) == 0 {
+			return ""
+		}
+		reasons = append(reasons, fmt.Sprintf("predicate %d: %s", i, reason))
+	}
+	return strings.Join(reasons, "; ")
+}
+
+func (p compiledPredicate) match(cluster *clusterv1.ManagedCluster) string {
+	if !p.labelSelector.Matches(labels.Set(cluster.Labels)) {
+		return "the labels do not match"
+	}
+	if !p.claimSelector.Matches(clusterClaims(cluster)) {
+		return "the claims do not match"
+	}
+	if len(p.programs) == 0 {
+		return ""
+	}
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(cluster)
+	if err != nil {
+		return fmt.Sprintf("failed to convert the cluster: %v", err)
+	}
+	for i, program := range p.programs {
+		result, _, err := program.Eval(map[string]interface{}{"managedCluster": obj})
+		if err != nil {
+			return fmt.Sprintf("the CEL expression %q failed: %v", p.expressions[i], err)
+		}
+		if matched, ok := result.Value().(bool); !ok || !matched {
+			return fmt.Sprintf("the CEL expression %q is false", p.expressions[i])
+		}
+	}
+	return ""
+}
+
+// tolerate returns the reason why a taint of the cluster is not tolerated, it is empty if all the taints are
+// tolerated. The PreferNoSelect taints are ignored, and the NoSelectIfNew taints are ignored for the clusters in
+// the existing decisions.
+func (e *PlacementEvaluator) tolerate(tolerations []clusterv1beta1.Toleration, cluster *clusterv1.ManagedCluster) string {
+	for _, taint := range cluster.Spec.Taints {
+		switch taint.Effect {
+		case clusterv1.TaintEffectPreferNoSelect:
+			continue
+		case clusterv1.TaintEffectNoSelectIfNew:
+			if e.existingDecisions.Has(cluster.Name) {
+				continue
+			}
+		}
+		if !e.tolerated(tolerations, taint) {
+			return fmt.Sprintf("the taint %s=%s:%s is not tolerated", taint.Key, taint.Value, taint.Effect)
+		}
+	}
+	return ""
+}
+
+func (e *PlacementEvaluator) tolerated(tolerations []clusterv1beta1.Toleration, taint clusterv1.Taint) bool {
+	for _, toleration := range tolerations {
+		if len(toleration.Effect) != 0 && toleration.Effect != taint.Effect {
+			continue
+		}
+		if len(toleration.Key) != 0 && toleration.Key != taint.Key {
+			continue
+		}
+		switch toleration.Operator {
+		case clusterv1beta1.TolerationOpExists:
+		case "", clusterv1beta1.TolerationOpEqual:
+			if len(toleration.Key) == 0 || toleration.Value != taint.Value {
+				continue
+			}
+		default:
+			continue
+		}
+		if toleration.TolerationSeconds != nil && !taint.TimeAdded.IsZero() {
+			expiration := taint.TimeAdded.Add(time.Duration(*toleration.TolerationSeconds) * time.Second)
+			if !e.clock.Now().Before(expiration) {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// splitDecisionGroups splits the selected clusters into the decision groups. The clusters matching a decision
+// group are split by the ClustersPerDecisionGroup, and the rest clusters are split into the groups without names.
+func splitDecisionGroups(strategy clusterv1beta1.GroupStrategy, selected []*clusterv1.ManagedCluster) ([]DecisionGroup, error) {
+	clusters := append([]*clusterv1.ManagedCluster{}, selected...)
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	groupSize := len(clusters)
+	if strategy.ClustersPerDecisionGroup != (intstr.IntOrString{}) {
+		size, err := intstr.GetScaledValueFromIntOrPercent(&strategy.ClustersPerDecisionGroup, len(clusters), true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ClustersPerDecisionGroup: %w", err)
+		}
+		groupSize = size
+	}
+	if groupSize < 1 {
+		groupSize = 1
+	}
+
+	var decisionGroups []DecisionGroup
+	addGroups := func(groupName string, names []string) {
+		for start := 0; start < len(names); start += groupSize {
+			end := start + groupSize
+			if end > len(names) {
+				end = len(names)
+			}
+			decisionGroups = append(decisionGroups, DecisionGroup{
+				GroupKey: clusterv1beta1sdk.GroupKey{
+					GroupName:  groupName,
+					GroupIndex: int32(len(decisionGroups)), //nolint:gosec // the number of groups fits in int32
+				},
+				Clusters: names[start:end],
+			})
+		}
+	}
+
+	grouped := sets.New[string]()
+	for _, group := range strategy.DecisionGroups {
+		labelSelector, claimSelector, err := buildSelectors(group.ClusterSelector.LabelSelector, group.ClusterSelector.ClaimSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector of decision group %s: %w", group.GroupName, err)
+		}
+
+		var names []string
+		for _, cluster := range clusters {
+			if grouped.Has(cluster.Name) || !labelSelector.Matches(labels.Set(cluster.Labels)) ||
+				!claimSelector.Matches(clusterClaims(cluster)) {
+				continue
+			}
+			grouped.Insert(cluster.Name)
+			names = append(names, cluster.Name)
+		}
+		addGroups(group.GroupName, names)
+	}
+
+	var names []string
+	for _, cluster := range clusters {
+		if !grouped.Has(cluster.Name) {
+			names = append(names, cluster.Name)
+		}
+	}
+	addGroups("", names)
+
+	if len(decisionGroups) == 0 {
+		decisionGroups = append(decisionGroups, DecisionGroup{})
+	}
+	return decisionGroups, nil
+}
+
+func buildSelectors(labelSelector metav1.LabelSelector,
+	claimSelector clusterv1beta1.ClusterClaimSelector) (labels.Selector, labels.Selector, error) {
+	ls, err := metav1.LabelSelectorAsSelector(&labelSelector)
+	if err != nil {
+		return nil, nil, err
+	}
+	cs, err := metav1.LabelSelectorAsSelector(&metav1.LabelSelector{MatchExpressions: claimSelector.MatchExpressions})
+	if err != nil {
+		return nil, nil, err
+	}
+	return ls, cs, nil
+}
+
+func clusterClaims(cluster *clusterv1.ManagedCluster) labels.Set {
+	claims := labels.Set{}
+	for _, claim := range cluster.Status.ClusterClaims {
+		claims[claim.Name] = claim.Value
+	}
+	return claims
+}
+
+type clusterSetBindingsGetter []*clusterv1beta2.ManagedClusterSetBinding
+
+func (g clusterSetBindingsGetter) List(namespace string,
+	selector labels.Selector) ([]*clusterv1beta2.ManagedClusterSetBinding, error) {
+	var bindings []*clusterv1beta2.ManagedClusterSetBinding
+	for _, binding := range g {
+		if binding.Namespace == namespace && selector.Matches(labels.Set(binding.Labels)) {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings, nil
+}
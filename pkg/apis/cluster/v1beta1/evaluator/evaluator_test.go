@@ -0,0 +1,393 @@
+package evaluator
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	testingclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	clusterv1beta2 "open-cluster-management.io/api/cluster/v1beta2"
+
+	clusterv1beta1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+)
+
+func newCluster(name, clusterSet string, labels map[string]string, claims map[string]string,
+	taints ...clusterv1.Taint) *clusterv1.ManagedCluster {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{clusterv1beta2.ClusterSetLabel: clusterSet},
+		},
+		Spec: clusterv1.ManagedClusterSpec{Taints: taints},
+	}
+	for k, v := range labels {
+		cluster.Labels[k] = v
+	}
+	for k, v := range claims {
+		cluster.Status.ClusterClaims = append(cluster.Status.ClusterClaims, clusterv1.ManagedClusterClaim{Name: k, Value: v})
+	}
+	return cluster
+}
+
+func newClusterSet(name string) *clusterv1beta2.ManagedClusterSet {
+	return &clusterv1beta2.ManagedClusterSet{ObjectMeta: metav1.ObjectMeta{Name: name}}
+}
+
+func newBinding(namespace, clusterSet string, bound bool) *clusterv1beta2.ManagedClusterSetBinding {
+	binding := &clusterv1beta2.ManagedClusterSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterSet, Namespace: namespace},
+		Spec:       clusterv1beta2.ManagedClusterSetBindingSpec{ClusterSet: clusterSet},
+	}
+	if bound {
+		binding.Status.Conditions = []metav1.Condition{{Type: clusterv1beta2.ClusterSetBindingBoundType, Status: metav1.ConditionTrue}}
+	}
+	return binding
+}
+
+func newScore(cluster, name string, items map[string]int32) *clusterv1alpha1.AddOnPlacementScore {
+	score := &clusterv1alpha1.AddOnPlacementScore{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cluster}}
+	for k, v := range items {
+		score.Status.Scores = append(score.Status.Scores, clusterv1alpha1.AddOnPlacementScoreItem{Name: k, Value: v})
+	}
+	return score
+}
+
+func TestEvaluate(t *testing.T) {
+	prod := map[string]string{"env": "prod"}
+	us := map[string]string{"region": "us"}
+	deleting := newCluster("cluster6", "prod", prod, us)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	evaluator, err := NewPlacementEvaluator(
+		[]*clusterv1.ManagedCluster{
+			newCluster("cluster1", "prod", map[string]string{"env": "prod", "canary": "true"}, us),
+			newCluster("cluster2", "prod", prod, map[string]string{"region": "eu"},
+				clusterv1.Taint{Key: "maintenance", Effect: clusterv1.TaintEffectNoSelect}),
+			newCluster("cluster3", "prod", prod, us),
+			newCluster("cluster4", "prod", map[string]string{"env": "dev"}, us),
+			newCluster("cluster5", "dev", prod, us),
+			deleting,
+			newCluster("cluster7", "prod", prod, map[string]string{"region": "ap"}),
+			newCluster("cluster8", "prod", prod, us),
+			newCluster("cluster9", "prod", map[string]string{"env": "prod", "blocked": "true"}, us),
+			newCluster("cluster10", "prod", prod, us,
+				clusterv1.Taint{Key: "gpu", Value: "none", Effect: clusterv1.TaintEffectNoSelectIfNew}),
+		},
+		[]*clusterv1beta2.ManagedClusterSet{newClusterSet("prod"), newClusterSet("dev")},
+		[]*clusterv1beta2.ManagedClusterSetBinding{
+			newBinding("default", "prod", true),
+			newBinding("default", "dev", false),
+			newBinding("other", "dev", true),
+		},
+		[]*clusterv1alpha1.AddOnPlacementScore{
+			newScore("cluster1", "usage", map[string]int32{"cpu": 20}),
+			newScore("cluster3", "usage", map[string]int32{"cpu": 80}),
+		},
+		WithExistingDecisions("cluster2"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	placement := &clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"},
+		Spec: clusterv1beta1.PlacementSpec{
+			NumberOfClusters: ptr.To[int32](3),
+			Predicates: []clusterv1beta1.ClusterPredicate{{
+				RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: prod},
+					ClaimSelector: clusterv1beta1.ClusterClaimSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "region", Operator: metav1.LabelSelectorOpIn, Values: []string{"us", "eu"}},
+						},
+					},
+					CelSelector: clusterv1beta1.ClusterCelSelector{
+						CelExpressions: []string{`!("blocked" in managedCluster.metadata.labels)`},
+					},
+				},
+			}},
+			Tolerations: []clusterv1beta1.Toleration{
+				{Key: "maintenance", Operator: clusterv1beta1.TolerationOpExists},
+			},
+			PrioritizerPolicy: clusterv1beta1.PrioritizerPolicy{
+				Configurations: []clusterv1beta1.PrioritizerConfig{{
+					ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{
+						Type:  clusterv1beta1.ScoreCoordinateTypeAddOn,
+						AddOn: &clusterv1beta1.AddOnScore{ResourceName: "usage", ScoreName: "cpu"},
+					},
+					Weight: 2,
+				}},
+			},
+			DecisionStrategy: clusterv1beta1.DecisionStrategy{
+				GroupStrategy: clusterv1beta1.GroupStrategy{
+					DecisionGroups: []clusterv1beta1.DecisionGroup{{
+						GroupName: "canary",
+						ClusterSelector: clusterv1beta1.GroupClusterSelector{
+							LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+						},
+					}},
+					ClustersPerDecisionGroup: intstr.FromString("34%"),
+				},
+			},
+		},
+	}
+
+	evaluation, err := evaluator.Evaluate(placement)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canary := clusterv1beta1sdk.GroupKey{GroupName: "canary", GroupIndex: 0}
+	group1 := clusterv1beta1sdk.GroupKey{GroupIndex: 1}
+	expectDecisionGroups := []DecisionGroup{
+		{GroupKey: canary, Clusters: []string{"cluster1"}},
+		{GroupKey: group1, Clusters: []string{"cluster2", "cluster3"}},
+	}
+	if !reflect.DeepEqual(evaluation.DecisionGroups, expectDecisionGroups) {
+		t.Errorf("expect decision groups %v, but got %v", expectDecisionGroups, evaluation.DecisionGroups)
+	}
+	if evaluation.NumberOfSelectedClusters != 3 || !evaluation.Satisfied {
+		t.Errorf("expect 3 clusters are selected and satisfied, but got %d, %v",
+			evaluation.NumberOfSelectedClusters, evaluation.Satisfied)
+	}
+
+	expectExplanations := map[string]struct {
+		filteredBy FilterType
+		groupKey   clusterv1beta1sdk.GroupKey
+		totalScore int64
+	}{
+		"cluster1":  {groupKey: canary, totalScore: 140},
+		"cluster2":  {groupKey: group1, totalScore: 200},
+		"cluster3":  {groupKey: group1, totalScore: 260},
+		"cluster4":  {filteredBy: FilterPredicate},
+		"cluster5":  {filteredBy: FilterClusterSet},
+		"cluster6":  {filteredBy: FilterClusterSet},
+		"cluster7":  {filteredBy: FilterPredicate},
+		"cluster8":  {filteredBy: FilterNumberOfClusters, totalScore: 100},
+		"cluster9":  {filteredBy: FilterPredicate},
+		"cluster10": {filteredBy: FilterToleration},
+	}
+	if len(evaluation.Clusters) != len(expectExplanations) {
+		t.Fatalf("expect %d explanations, but got %d", len(expectExplanations), len(evaluation.Clusters))
+	}
+	for _, explanation := range evaluation.Clusters {
+		expect := expectExplanations[explanation.ClusterName]
+		if explanation.FilteredBy != expect.filteredBy || explanation.Selected != (len(expect.filteredBy) == 0) ||
+			explanation.GroupKey != expect.groupKey || explanation.TotalScore != expect.totalScore {
+			t.Errorf("unexpected explanation of %s: %+v", explanation.ClusterName, explanation)
+		}
+		if len(explanation.FilteredBy) != 0 && len(explanation.Reason) == 0 {
+			t.Errorf("expect the reason of %s", explanation.ClusterName)
+		}
+	}
+
+	explanation, _ := evaluation.Explanation("cluster3")
+	expectScores := map[string]int64{PrioritizerBalance: 100, PrioritizerSteady: 0, "AddOn/usage/cpu": 160}
+	if !reflect.DeepEqual(explanation.Scores, expectScores) {
+		t.Errorf("expect scores %v, but got %v", expectScores, explanation.Scores)
+	}
+	explanation, _ = evaluation.Explanation("cluster9")
+	if explanation.Reason != `predicate 0: the CEL expression "!(\"blocked\" in managedCluster.metadata.labels)" is false` {
+		t.Errorf("unexpected reason of cluster9: %s", explanation.Reason)
+	}
+
+	// the cluster10 is kept once it is in the existing decisions
+	evaluator.existingDecisions.Insert("cluster10")
+	evaluation, err = evaluator.Evaluate(placement)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation, _ := evaluation.Explanation("cluster10"); explanation.FilteredBy == FilterToleration {
+		t.Errorf("expect cluster10 tolerated, but got %+v", explanation)
+	}
+}
+
+func TestEvaluatePrioritizers(t *testing.T) {
+	now := time.Now()
+	clusters := []*clusterv1.ManagedCluster{
+		newCluster("cluster1", "default", nil, nil),
+		newCluster("cluster2", "default", nil, nil),
+		newCluster("cluster3", "default", nil, nil),
+	}
+	for i, cpu := range []string{"2", "8", "4"} {
+		clusters[i].Status.Allocatable = clusterv1.ResourceList{clusterv1.ResourceCPU: resource.MustParse(cpu)}
+	}
+	expiredScore := newScore("cluster1", "usage", map[string]int32{"cpu": 100})
+	expiredScore.Status.ValidUntil = &metav1.Time{Time: now.Add(-time.Minute)}
+
+	tests := []struct {
+		name           string
+		policy         clusterv1beta1.PrioritizerPolicy
+		options        []EvaluatorOption
+		expectClusters []string
+	}{
+		{
+			name:           "additive without configurations",
+			expectClusters: []string{"cluster1", "cluster2"},
+		},
+		{
+			name:           "balance",
+			options:        []EvaluatorOption{WithDecisionCounts(map[string]int{"cluster1": 4, "cluster2": 2})},
+			expectClusters: []string{"cluster3", "cluster2"},
+		},
+		{
+			name: "exact with allocatable cpu",
+			policy: clusterv1beta1.PrioritizerPolicy{
+				Mode: clusterv1beta1.PrioritizerPolicyModeExact,
+				Configurations: []clusterv1beta1.PrioritizerConfig{{
+					ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{BuiltIn: PrioritizerResourceAllocatableCPU},
+					Weight:          1,
+				}},
+			},
+			options:        []EvaluatorOption{WithExistingDecisions("cluster1")},
+			expectClusters: []string{"cluster2", "cluster3"},
+		},
+		{
+			name: "steady overrides the expired addon score",
+			policy: clusterv1beta1.PrioritizerPolicy{
+				Configurations: []clusterv1beta1.PrioritizerConfig{
+					{
+						ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{
+							Type:  clusterv1beta1.ScoreCoordinateTypeAddOn,
+							AddOn: &clusterv1beta1.AddOnScore{ResourceName: "usage", ScoreName: "cpu"},
+						},
+						Weight: 1,
+					},
+					{ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{BuiltIn: PrioritizerSteady}, Weight: 3},
+				},
+			},
+			options:        []EvaluatorOption{WithExistingDecisions("cluster3")},
+			expectClusters: []string{"cluster3", "cluster1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			evaluator, err := NewPlacementEvaluator(clusters,
+				[]*clusterv1beta2.ManagedClusterSet{newClusterSet("default")},
+				[]*clusterv1beta2.ManagedClusterSetBinding{newBinding("default", "default", true)},
+				[]*clusterv1alpha1.AddOnPlacementScore{expiredScore},
+				append(test.options, WithClock(testingclock.NewFakePassiveClock(now)))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			evaluation, err := evaluator.Evaluate(&clusterv1beta1.Placement{
+				ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"},
+				Spec: clusterv1beta1.PlacementSpec{
+					NumberOfClusters:  ptr.To[int32](2),
+					PrioritizerPolicy: test.policy,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var selected []string
+			for _, explanation := range evaluation.Clusters {
+				if explanation.Selected {
+					selected = append(selected, explanation.ClusterName)
+				}
+			}
+			var expectClusters []string
+			for _, cluster := range clusters {
+				for _, expect := range test.expectClusters {
+					if cluster.Name == expect {
+						expectClusters = append(expectClusters, expect)
+					}
+				}
+			}
+			if !reflect.DeepEqual(selected, expectClusters) {
+				t.Errorf("expect selected clusters %v, but got %v", expectClusters, selected)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithoutClusters(t *testing.T) {
+	evaluator, err := NewPlacementEvaluator(nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluation, err := evaluator.Evaluate(&clusterv1beta1.Placement{
+		ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"},
+		Spec:       clusterv1beta1.PlacementSpec{NumberOfClusters: ptr.To[int32](1)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(evaluation.DecisionGroups, []DecisionGroup{{}}) || evaluation.Satisfied {
+		t.Errorf("expect one empty decision group and not satisfied, but got %+v", evaluation)
+	}
+}
+
+func TestEvaluateMisconfigured(t *testing.T) {
+	tests := []struct {
+		name string
+		spec clusterv1beta1.PlacementSpec
+	}{
+		{
+			name: "invalid cel expression",
+			spec: clusterv1beta1.PlacementSpec{Predicates: []clusterv1beta1.ClusterPredicate{{
+				RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+					CelSelector: clusterv1beta1.ClusterCelSelector{CelExpressions: []string{"managedCluster."}},
+				},
+			}}},
+		},
+		{
+			name: "cel expression is not a bool",
+			spec: clusterv1beta1.PlacementSpec{Predicates: []clusterv1beta1.ClusterPredicate{{
+				RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+					CelSelector: clusterv1beta1.ClusterCelSelector{CelExpressions: []string{`"true"`}},
+				},
+			}}},
+		},
+		{
+			name: "invalid label selector",
+			spec: clusterv1beta1.PlacementSpec{Predicates: []clusterv1beta1.ClusterPredicate{{
+				RequiredClusterSelector: clusterv1beta1.ClusterSelector{
+					LabelSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "env", Operator: "Unknown"},
+					}},
+				},
+			}}},
+		},
+		{
+			name: "unknown built-in prioritizer",
+			spec: clusterv1beta1.PlacementSpec{PrioritizerPolicy: clusterv1beta1.PrioritizerPolicy{
+				Configurations: []clusterv1beta1.PrioritizerConfig{
+					{ScoreCoordinate: &clusterv1beta1.ScoreCoordinate{BuiltIn: "Unknown"}, Weight: 1},
+				},
+			}},
+		},
+		{
+			name: "invalid clusters per decision group",
+			spec: clusterv1beta1.PlacementSpec{DecisionStrategy: clusterv1beta1.DecisionStrategy{
+				GroupStrategy: clusterv1beta1.GroupStrategy{ClustersPerDecisionGroup: intstr.FromString("abc")},
+			}},
+		},
+	}
+
+	evaluator, err := NewPlacementEvaluator(nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := evaluator.Evaluate(&clusterv1beta1.Placement{
+				ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"},
+				Spec:       test.spec,
+			})
+			if err == nil {
+				t.Errorf("expect error, but got nil")
+			}
+		})
+	}
+}
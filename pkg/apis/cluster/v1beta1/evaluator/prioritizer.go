@@ -0,0 +1,202 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+
+	clusterv1alpha1sdk "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1alpha1"
+)
+
+// The built-in prioritizers.
+const (
+	// PrioritizerBalance scores the clusters selected by fewer placement decisions higher.
+	PrioritizerBalance = "Balance"
+	// PrioritizerSteady scores the clusters in the existing decisions of the placement higher.
+	PrioritizerSteady = "Steady"
+	// PrioritizerResourceAllocatableCPU scores the clusters with more allocatable CPU higher.
+	PrioritizerResourceAllocatableCPU = "ResourceAllocatableCPU"
+	// PrioritizerResourceAllocatableMemory scores the clusters with more allocatable memory higher.
+	PrioritizerResourceAllocatableMemory = "ResourceAllocatableMemory"
+)
+
+type prioritizer struct {
+	name   string
+	weight int32
+	// score returns the scores of the clusters in the range -100 to 100.
+	score func(clusters []*clusterv1.ManagedCluster) (map[string]int64, error)
+}
+
+// prioritizers returns the prioritizers of the policy with their weights, the prioritizers with weight 0 are
+// skipped.
+func (e *PlacementEvaluator) prioritizers(policy clusterv1beta1.PrioritizerPolicy) ([]prioritizer, error) {
+	var prioritizers []prioritizer
+	indexes := map[string]int{}
+	add := func(p prioritizer) {
+		if i, ok := indexes[p.name]; ok {
+			prioritizers[i].weight = p.weight
+			return
+		}
+		indexes[p.name] = len(prioritizers)
+		prioritizers = append(prioritizers, p)
+	}
+
+	if policy.Mode != clusterv1beta1.PrioritizerPolicyModeExact {
+		add(prioritizer{name: PrioritizerBalance, weight: 1, score: e.balanceScores})
+		add(prioritizer{name: PrioritizerSteady, weight: 1, score: e.steadyScores})
+	}
+
+	for _, config := range policy.Configurations {
+		if config.ScoreCoordinate == nil {
+			return nil, fmt.Errorf("the scoreCoordinate of the prioritizer is required")
+		}
+
+		switch config.ScoreCoordinate.Type {
+		case "", clusterv1beta1.ScoreCoordinateTypeBuiltIn:
+			score, err := e.builtInScoreFunc(config.ScoreCoordinate.BuiltIn)
+			if err != nil {
+				return nil, err
+			}
+			add(prioritizer{name: config.ScoreCoordinate.BuiltIn, weight: config.Weight, score: score})
+		case clusterv1beta1.ScoreCoordinateTypeAddOn:
+			addOn := config.ScoreCoordinate.AddOn
+			if addOn == nil {
+				return nil, fmt.Errorf("the addOn of the AddOn prioritizer is required")
+			}
+			add(prioritizer{
+				name:   fmt.Sprintf("AddOn/%s/%s", addOn.ResourceName, addOn.ScoreName),
+				weight: config.Weight,
+				score: func(clusters []*clusterv1.ManagedCluster) (map[string]int64, error) {
+					return e.addOnScores(clusters, addOn.ResourceName, addOn.ScoreName)
+				},
+			})
+		default:
+			return nil, fmt.Errorf("incorrect scoreCoordinate type %s", config.ScoreCoordinate.Type)
+		}
+	}
+
+	var weighted []prioritizer
+	for _, p := range prioritizers {
+		if p.weight != 0 {
+			weighted = append(weighted, p)
+		}
+	}
+	return weighted, nil
+}
+
+func (e *PlacementEvaluator) builtInScoreFunc(name string) (func([]*clusterv1.ManagedCluster) (map[string]int64, error), error) {
+	switch name {
+	case PrioritizerBalance:
+		return e.balanceScores, nil
+	case PrioritizerSteady:
+		return e.steadyScores, nil
+	case PrioritizerResourceAllocatableCPU:
+		return func(clusters []*clusterv1.ManagedCluster) (map[string]int64, error) {
+			return allocatableScores(clusters, clusterv1.ResourceCPU)
+		}, nil
+	case PrioritizerResourceAllocatableMemory:
+		return func(clusters []*clusterv1.ManagedCluster) (map[string]int64, error) {
+			return allocatableScores(clusters, clusterv1.ResourceMemory)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown built-in prioritizer %s", name)
+	}
+}
+
+// balanceScores scores a cluster by the number of the placement decisions that select it, the cluster selected
+// by the most decisions scores -100, and the cluster selected by no decision scores 100.
+func (e *PlacementEvaluator) balanceScores(clusters []*clusterv1.ManagedCluster) (map[string]int64, error) {
+	maxCount := 0
+	for _, count := range e.decisionCounts {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	scores := map[string]int64{}
+	for _, cluster := range clusters {
+		scores[cluster.Name] = clusterv1alpha1sdk.MaxScore
+		if count := e.decisionCounts[cluster.Name]; count > 0 && maxCount > 0 {
+			scores[cluster.Name] = clusterv1alpha1sdk.MaxScore -
+				int64(count*(clusterv1alpha1sdk.MaxScore-clusterv1alpha1sdk.MinScore)/maxCount)
+		}
+	}
+	return scores, nil
+}
+
+// steadyScores scores the clusters in the existing decisions 100, and the other clusters 0.
+func (e *PlacementEvaluator) steadyScores(clusters []*clusterv1.ManagedCluster) (map[string]int64, error) {
+	scores := map[string]int64{}
+	for _, cluster := range clusters {
+		if e.existingDecisions.Has(cluster.Name) {
+			scores[cluster.Name] = clusterv1alpha1sdk.MaxScore
+		} else {
+			scores[cluster.Name] = 0
+		}
+	}
+	return scores, nil
+}
+
+// allocatableScores normalizes the allocatable resource of the clusters between the minimum and the maximum, all
+// the clusters score 100 if they have the same allocatable resource.
+func allocatableScores(clusters []*clusterv1.ManagedCluster, resource clusterv1.ResourceName) (map[string]int64, error) {
+	values := map[string]float64{}
+	var min, max float64
+	for i, cluster := range clusters {
+		quantity := cluster.Status.Allocatable[resource]
+		value := quantity.AsApproximateFloat64()
+		values[cluster.Name] = value
+		if i == 0 || value < min {
+			min = value
+		}
+		if i == 0 || value > max {
+			max = value
+		}
+	}
+
+	scores := map[string]int64{}
+	normalizer := clusterv1alpha1sdk.NewScoreNormalizer(min, max)
+	for name, value := range values {
+		if min == max {
+			scores[name] = clusterv1alpha1sdk.MaxScore
+			continue
+		}
+		score, err := normalizer.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		scores[name] = int64(score)
+	}
+	return scores, nil
+}
+
+// addOnScores returns the score items of the AddOnPlacementScores, the clusters without the score or with an
+// expired score get 0.
+func (e *PlacementEvaluator) addOnScores(clusters []*clusterv1.ManagedCluster,
+	resourceName, scoreName string) (map[string]int64, error) {
+	scores := map[string]int64{}
+	for _, cluster := range clusters {
+		scores[cluster.Name] = 0
+
+		score, err := e.scoreLister.AddOnPlacementScores(cluster.Name).Get(resourceName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if score.Status.ValidUntil != nil && !e.clock.Now().Before(score.Status.ValidUntil.Time) {
+			continue
+		}
+		for _, item := range score.Status.Scores {
+			if item.Name == scoreName {
+				scores[cluster.Name] = int64(item.Value)
+				break
+			}
+		}
+	}
+	return scores, nil
+}
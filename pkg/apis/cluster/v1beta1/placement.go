@@ -8,6 +8,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
 
 	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
 )
@@ -23,6 +24,16 @@ type PlacementDecisionClustersTracker struct {
 	clusterGroupsIndexToName       map[int32]string
 	clusterGroupsNameToIndex       map[string][]int32
 	lock                           sync.RWMutex
+
+	// The fields below are only used by the tracker created with NewPlacementDecisionClustersTrackerWithInformer.
+	incremental      bool
+	registration     cache.ResourceEventHandlerRegistration
+	decisions        map[string]trackedDecision
+	clusterDecisions map[string]sets.Set[string]
+	groupDecisions   map[GroupKey]int
+	addedClusters    sets.Set[string]
+	deletedClusters  sets.Set[string]
+	changeHandlers   []ClusterChangeHandler
 }
 
 type GroupKey struct {
@@ -64,6 +75,13 @@ func (pdct *PlacementDecisionClustersTracker) Refresh() error {
 	pdct.lock.Lock()
 	defer pdct.lock.Unlock()
 
+	// The decision cluster groups are kept up to date by the informer, only reset the cluster changes.
+	if pdct.incremental {
+		pdct.addedClusters = sets.New[string]()
+		pdct.deletedClusters = sets.New[string]()
+		return nil
+	}
+
 	if pdct.placement == nil || pdct.placementDecisionGetter == nil {
 		return nil
 	}
@@ -102,7 +120,13 @@ func (pdct *PlacementDecisionClustersTracker) Refresh() error {
 }
 
 // GetClusterChanges updates the tracker's decisionClusters and returns added and deleted cluster names.
+// The tracker created with NewPlacementDecisionClustersTrackerWithInformer returns the cluster names added and
+// deleted since the last call of GetClusterChanges or Refresh.
 func (pdct *PlacementDecisionClustersTracker) GetClusterChanges() (sets.Set[string], sets.Set[string], error) {
+	if pdct.incremental {
+		return pdct.popClusterChanges()
+	}
+
 	// Get existing clusters
 	existingScheduledClusters := pdct.existingScheduledClusterGroups.GetClusters()
 
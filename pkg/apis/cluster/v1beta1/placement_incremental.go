@@ -0,0 +1,298 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+// ClusterChangeType is the type of a decision cluster change.
+type ClusterChangeType string
+
+const (
+	// ClusterAdded means the cluster is added to the decisions of the placement.
+	ClusterAdded ClusterChangeType = "Added"
+	// ClusterRemoved means the cluster is removed from the decisions of the placement.
+	ClusterRemoved ClusterChangeType = "Removed"
+	// ClusterRegrouped means the cluster is still in the decisions of the placement but in another decision group.
+	ClusterRegrouped ClusterChangeType = "Regrouped"
+)
+
+// ClusterChange is a change of a decision cluster of the placement.
+type ClusterChange struct {
+	Type        ClusterChangeType
+	ClusterName string
+	// GroupKey is the decision group of the cluster after the change, it is the decision group that the cluster is
+	// removed from for the ClusterRemoved. The group with the lowest index is used if the cluster is in several
+	// decision groups.
+	GroupKey GroupKey
+	// PreviousGroupKey is the decision group of the cluster before the change, it is only set for the
+	// ClusterRegrouped.
+	PreviousGroupKey GroupKey
+}
+
+// ClusterChangeHandler handles the decision cluster changes caused by a PlacementDecision event. The changes are
+// ordered by the cluster names. The handler is called without the lock of the tracker, so it is able to query the
+// tracker, and it should return quickly since it blocks the informer.
+type ClusterChangeHandler func(changes []ClusterChange)
+
+// PlacementDecisionEventSource is the source of the PlacementDecision events, e.g. the PlacementDecision informer.
+type PlacementDecisionEventSource interface {
+	AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error)
+}
+
+type trackedDecision struct {
+	groupKey GroupKey
+	clusters sets.Set[string]
+}
+
+// NewPlacementDecisionClustersTrackerWithInformer initializes a PlacementDecisionClustersTracker that maintains the
+// decision cluster groups of the placement incrementally from the PlacementDecision events instead of relisting the
+// PlacementDecisions. Refresh does not relist the PlacementDecisions, and GetClusterChanges returns the clusters
+// added and deleted since the last call. Use HasSynced to wait for the existing PlacementDecisions to be tracked.
+func NewPlacementDecisionClustersTrackerWithInformer(placement *clusterv1beta1.Placement,
+	source PlacementDecisionEventSource) (*PlacementDecisionClustersTracker, error) {
+	if placement == nil {
+		return nil, fmt.Errorf("placement is required")
+	}
+
+	pdct := &PlacementDecisionClustersTracker{
+		placement:                      placement,
+		existingScheduledClusterGroups: ClusterGroupsMap{},
+		incremental:                    true,
+		decisions:                      map[string]trackedDecision{},
+		clusterDecisions:               map[string]sets.Set[string]{},
+		groupDecisions:                 map[GroupKey]int{},
+		addedClusters:                  sets.New[string](),
+		deletedClusters:                sets.New[string](),
+	}
+	pdct.generateGroupsNameIndex()
+
+	registration, err := source.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			decision, ok := toPlacementDecision(obj)
+			return ok && decision.Namespace == placement.Namespace &&
+				decision.Labels[clusterv1beta1.PlacementLabel] == placement.Name
+		},
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				decision, _ := toPlacementDecision(obj)
+				pdct.onDecision(decision.Name, decision)
+			},
+			UpdateFunc: func(_, newObj interface{}) {
+				decision, _ := toPlacementDecision(newObj)
+				pdct.onDecision(decision.Name, decision)
+			},
+			DeleteFunc: func(obj interface{}) {
+				decision, _ := toPlacementDecision(obj)
+				pdct.onDecision(decision.Name, nil)
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add PlacementDecision event handler: %w", err)
+	}
+	pdct.registration = registration
+
+	return pdct, nil
+}
+
+// AddClusterChangeHandler registers a handler of the decision cluster changes. The handler only receives the
+// changes after it is registered, register it before the informer starts to receive all the clusters as added.
+func (pdct *PlacementDecisionClustersTracker) AddClusterChangeHandler(handler ClusterChangeHandler) {
+	pdct.lock.Lock()
+	defer pdct.lock.Unlock()
+
+	pdct.changeHandlers = append(pdct.changeHandlers, handler)
+}
+
+// HasSynced returns true once the existing PlacementDecisions in the informer are tracked. It always returns true
+// for the tracker that is not created with NewPlacementDecisionClustersTrackerWithInformer.
+func (pdct *PlacementDecisionClustersTracker) HasSynced() bool {
+	if pdct.registration == nil {
+		return true
+	}
+	return pdct.registration.HasSynced()
+}
+
+func (pdct *PlacementDecisionClustersTracker) popClusterChanges() (sets.Set[string], sets.Set[string], error) {
+	pdct.lock.Lock()
+	defer pdct.lock.Unlock()
+
+	added, deleted := pdct.addedClusters, pdct.deletedClusters
+	pdct.addedClusters = sets.New[string]()
+	pdct.deletedClusters = sets.New[string]()
+	return added, deleted, nil
+}
+
+// onDecision updates the decision cluster groups with a PlacementDecision, the decision is nil if it is deleted,
+// and notifies the handlers of the changes.
+func (pdct *PlacementDecisionClustersTracker) onDecision(name string, decision *clusterv1beta1.PlacementDecision) {
+	pdct.lock.Lock()
+	changes, err := pdct.updateDecision(name, decision)
+	handlers := pdct.changeHandlers
+	pdct.lock.Unlock()
+
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to track PlacementDecision %s/%s: %w",
+			pdct.placement.Namespace, name, err))
+		return
+	}
+	if len(changes) == 0 {
+		return
+	}
+	for _, handler := range handlers {
+		handler(changes)
+	}
+}
+
+// updateDecision only recomputes the clusters and the groups of the decision. The updated cluster groups are
+// copied instead of modified in place since the query API returns them to the callers.
+func (pdct *PlacementDecisionClustersTracker) updateDecision(name string,
+	decision *clusterv1beta1.PlacementDecision) ([]ClusterChange, error) {
+	oldDecision, existed := pdct.decisions[name]
+	var newDecision trackedDecision
+	if decision != nil {
+		groupKey, err := parseGroupKeyFromDecision(decision)
+		if err != nil {
+			return nil, err
+		}
+		newDecision = trackedDecision{groupKey: groupKey, clusters: sets.New[string]()}
+		for _, sd := range decision.Status.Decisions {
+			newDecision.clusters.Insert(sd.ClusterName)
+		}
+		if existed && oldDecision.groupKey == newDecision.groupKey && oldDecision.clusters.Equal(newDecision.clusters) {
+			return nil, nil
+		}
+	} else if !existed {
+		return nil, nil
+	}
+
+	affectedClusters := sets.New[string]()
+	affectedGroups := sets.New[GroupKey]()
+	if existed {
+		affectedClusters = affectedClusters.Union(oldDecision.clusters)
+		affectedGroups.Insert(oldDecision.groupKey)
+	}
+	if decision != nil {
+		affectedClusters = affectedClusters.Union(newDecision.clusters)
+		affectedGroups.Insert(newDecision.groupKey)
+	}
+	previousGroupKeys := map[string]sets.Set[GroupKey]{}
+	for cluster := range affectedClusters {
+		previousGroupKeys[cluster] = pdct.groupKeysOf(cluster)
+	}
+
+	if existed {
+		for cluster := range oldDecision.clusters {
+			pdct.clusterDecisions[cluster].Delete(name)
+			if pdct.clusterDecisions[cluster].Len() == 0 {
+				delete(pdct.clusterDecisions, cluster)
+			}
+		}
+		pdct.groupDecisions[oldDecision.groupKey]--
+		delete(pdct.decisions, name)
+	}
+	if decision != nil {
+		for cluster := range newDecision.clusters {
+			if _, ok := pdct.clusterDecisions[cluster]; !ok {
+				pdct.clusterDecisions[cluster] = sets.New[string]()
+			}
+			pdct.clusterDecisions[cluster].Insert(name)
+		}
+		pdct.groupDecisions[newDecision.groupKey]++
+		pdct.decisions[name] = newDecision
+	}
+
+	groupsChanged := false
+	for groupKey := range affectedGroups {
+		if pdct.groupDecisions[groupKey] == 0 {
+			delete(pdct.groupDecisions, groupKey)
+			delete(pdct.existingScheduledClusterGroups, groupKey)
+			groupsChanged = true
+			continue
+		}
+
+		clusters, ok := pdct.existingScheduledClusterGroups[groupKey]
+		if ok {
+			clusters = clusters.Clone()
+		} else {
+			clusters = sets.New[string]()
+			groupsChanged = true
+		}
+		for cluster := range affectedClusters {
+			if pdct.groupKeysOf(cluster).Has(groupKey) {
+				clusters.Insert(cluster)
+			} else {
+				clusters.Delete(cluster)
+			}
+		}
+		pdct.existingScheduledClusterGroups[groupKey] = clusters
+	}
+	if groupsChanged {
+		pdct.generateGroupsNameIndex()
+	}
+
+	var changes []ClusterChange
+	for _, cluster := range sets.List(affectedClusters) {
+		previous, current := previousGroupKeys[cluster], pdct.groupKeysOf(cluster)
+		switch {
+		case previous.Len() == 0 && current.Len() != 0:
+			changes = append(changes, ClusterChange{Type: ClusterAdded, ClusterName: cluster, GroupKey: firstGroupKey(current)})
+			if pdct.deletedClusters.Has(cluster) {
+				pdct.deletedClusters.Delete(cluster)
+			} else {
+				pdct.addedClusters.Insert(cluster)
+			}
+		case previous.Len() != 0 && current.Len() == 0:
+			changes = append(changes, ClusterChange{Type: ClusterRemoved, ClusterName: cluster, GroupKey: firstGroupKey(previous)})
+			if pdct.addedClusters.Has(cluster) {
+				pdct.addedClusters.Delete(cluster)
+			} else {
+				pdct.deletedClusters.Insert(cluster)
+			}
+		case previous.Len() != 0 && firstGroupKey(previous) != firstGroupKey(current):
+			changes = append(changes, ClusterChange{
+				Type:             ClusterRegrouped,
+				ClusterName:      cluster,
+				GroupKey:         firstGroupKey(current),
+				PreviousGroupKey: firstGroupKey(previous),
+			})
+		}
+	}
+	return changes, nil
+}
+
+// groupKeysOf returns the decision groups of a cluster.
+func (pdct *PlacementDecisionClustersTracker) groupKeysOf(cluster string) sets.Set[GroupKey] {
+	groupKeys := sets.New[GroupKey]()
+	for name := range pdct.clusterDecisions[cluster] {
+		groupKeys.Insert(pdct.decisions[name].groupKey)
+	}
+	return groupKeys
+}
+
+// firstGroupKey returns the group with the lowest index.
+func firstGroupKey(groupKeys sets.Set[GroupKey]) GroupKey {
+	var first GroupKey
+	found := false
+	for groupKey := range groupKeys {
+		if !found || groupKey.GroupIndex < first.GroupIndex {
+			first = groupKey
+			found = true
+		}
+	}
+	return first
+}
+
+func toPlacementDecision(obj interface{}) (*clusterv1beta1.PlacementDecision, bool) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	decision, ok := obj.(*clusterv1beta1.PlacementDecision)
+	return decision, ok
+}
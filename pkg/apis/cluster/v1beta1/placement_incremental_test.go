@@ -0,0 +1,185 @@
+package v1beta1
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	clusterfake "open-cluster-management.io/api/client/cluster/clientset/versioned/fake"
+	clusterinformers "open-cluster-management.io/api/client/cluster/informers/externalversions"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+)
+
+type fakeEventSource struct {
+	handler cache.ResourceEventHandler
+}
+
+func (f *fakeEventSource) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	f.handler = handler
+	return nil, nil
+}
+
+func newNamedFakePlacementDecision(name, placementName, groupName string, groupIndex int, clusterNames ...string) *clusterv1beta1.PlacementDecision {
+	decision := newFakePlacementDecision(placementName, groupName, groupIndex, clusterNames...)
+	decision.Name = name
+	decision.Namespace = "default"
+	return decision
+}
+
+func TestPlacementDecisionClustersTrackerWithInformer(t *testing.T) {
+	placement := &clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"}}
+	canary := GroupKey{GroupName: "canary", GroupIndex: 0}
+	group1 := GroupKey{GroupIndex: 1}
+
+	decision1 := newNamedFakePlacementDecision("decision1", "placement1", "canary", 0, "cluster1")
+	decision2 := newNamedFakePlacementDecision("decision2", "placement1", "", 1, "cluster2", "cluster3")
+	decision2Updated := newNamedFakePlacementDecision("decision2", "placement1", "", 1, "cluster1", "cluster3", "cluster4")
+	otherPlacementDecision := newNamedFakePlacementDecision("decision3", "placement2", "", 0, "cluster5")
+
+	steps := []struct {
+		name                string
+		event               func(handler cache.ResourceEventHandler)
+		expectChanges       []ClusterChange
+		expectClusterGroups ClusterGroupsMap
+	}{
+		{
+			name:          "add decision of the canary group",
+			event:         func(handler cache.ResourceEventHandler) { handler.OnAdd(decision1, true) },
+			expectChanges: []ClusterChange{{Type: ClusterAdded, ClusterName: "cluster1", GroupKey: canary}},
+			expectClusterGroups: ClusterGroupsMap{
+				canary: sets.New[string]("cluster1"),
+			},
+		},
+		{
+			name:  "add decision of group 1",
+			event: func(handler cache.ResourceEventHandler) { handler.OnAdd(decision2, true) },
+			expectChanges: []ClusterChange{
+				{Type: ClusterAdded, ClusterName: "cluster2", GroupKey: group1},
+				{Type: ClusterAdded, ClusterName: "cluster3", GroupKey: group1},
+			},
+			expectClusterGroups: ClusterGroupsMap{
+				canary: sets.New[string]("cluster1"),
+				group1: sets.New[string]("cluster2", "cluster3"),
+			},
+		},
+		{
+			name:  "ignore decision of other placement",
+			event: func(handler cache.ResourceEventHandler) { handler.OnAdd(otherPlacementDecision, false) },
+			expectClusterGroups: ClusterGroupsMap{
+				canary: sets.New[string]("cluster1"),
+				group1: sets.New[string]("cluster2", "cluster3"),
+			},
+		},
+		{
+			name:  "update decision of group 1",
+			event: func(handler cache.ResourceEventHandler) { handler.OnUpdate(decision2, decision2Updated) },
+			expectChanges: []ClusterChange{
+				{Type: ClusterRemoved, ClusterName: "cluster2", GroupKey: group1},
+				{Type: ClusterAdded, ClusterName: "cluster4", GroupKey: group1},
+			},
+			expectClusterGroups: ClusterGroupsMap{
+				canary: sets.New[string]("cluster1"),
+				group1: sets.New[string]("cluster1", "cluster3", "cluster4"),
+			},
+		},
+		{
+			name: "delete decision of the canary group",
+			event: func(handler cache.ResourceEventHandler) {
+				handler.OnDelete(cache.DeletedFinalStateUnknown{Key: "default/decision1", Obj: decision1})
+			},
+			expectChanges: []ClusterChange{
+				{Type: ClusterRegrouped, ClusterName: "cluster1", GroupKey: group1, PreviousGroupKey: canary},
+			},
+			expectClusterGroups: ClusterGroupsMap{
+				group1: sets.New[string]("cluster1", "cluster3", "cluster4"),
+			},
+		},
+	}
+
+	source := &fakeEventSource{}
+	tracker, err := NewPlacementDecisionClustersTrackerWithInformer(placement, source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tracker.HasSynced() {
+		t.Errorf("expect the tracker without registration is synced")
+	}
+
+	var changes []ClusterChange
+	tracker.AddClusterChangeHandler(func(c []ClusterChange) {
+		// the handler is able to query the tracker
+		tracker.ExistingClusterGroupsBesides()
+		changes = c
+	})
+
+	for _, step := range steps {
+		changes = nil
+		step.event(source.handler)
+		if !reflect.DeepEqual(changes, step.expectChanges) {
+			t.Errorf("%s: expect changes %v, but got %v", step.name, step.expectChanges, changes)
+		}
+		if clusterGroups := tracker.ExistingClusterGroups(canary, group1); !reflect.DeepEqual(clusterGroups, step.expectClusterGroups) {
+			t.Errorf("%s: expect cluster groups %v, but got %v", step.name, step.expectClusterGroups, clusterGroups)
+		}
+	}
+
+	added, deleted, err := tracker.GetClusterChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !added.Equal(sets.New[string]("cluster1", "cluster3", "cluster4")) || !deleted.Equal(sets.New[string]()) {
+		t.Errorf("expect added clusters cluster1, cluster3, cluster4, but got added %v and deleted %v", added, deleted)
+	}
+	if err := tracker.Refresh(); err != nil {
+		t.Fatal(err)
+	}
+	added, deleted, _ = tracker.GetClusterChanges()
+	if added.Len() != 0 || deleted.Len() != 0 {
+		t.Errorf("expect no changes after refresh, but got added %v and deleted %v", added, deleted)
+	}
+	if clusterGroups := tracker.ExistingClusterGroups(GroupKey{GroupName: "canary"}); len(clusterGroups) != 0 {
+		t.Errorf("expect the canary group is removed, but got %v", clusterGroups)
+	}
+}
+
+func TestPlacementDecisionClustersTrackerWithInformerSync(t *testing.T) {
+	placement := &clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "placement1", Namespace: "default"}}
+	clusterClient := clusterfake.NewSimpleClientset(
+		newNamedFakePlacementDecision("decision1", "placement1", "", 0, "cluster1", "cluster2"))
+	informerFactory := clusterinformers.NewSharedInformerFactory(clusterClient, 10*time.Minute)
+
+	tracker, err := NewPlacementDecisionClustersTrackerWithInformer(placement,
+		informerFactory.Cluster().V1beta1().PlacementDecisions().Informer())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), tracker.HasSynced) {
+		t.Fatal("failed to sync the tracker")
+	}
+
+	added, _, _ := tracker.GetClusterChanges()
+	if !added.Equal(sets.New[string]("cluster1", "cluster2")) {
+		t.Errorf("expect added clusters cluster1, cluster2, but got %v", added)
+	}
+
+	_, err = clusterClient.ClusterV1beta1().PlacementDecisions("default").Create(ctx,
+		newNamedFakePlacementDecision("decision2", "placement1", "", 1, "cluster3"), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		return tracker.ExistingClusterGroups(GroupKey{GroupIndex: 1}).GetClusters().Has("cluster3"), nil
+	})
+	if err != nil {
+		t.Errorf("expect cluster3 in group 1: %v", err)
+	}
+}
@@ -15,21 +15,42 @@ import (
 	"k8s.io/klog/v2"
 
 	workv1client "open-cluster-management.io/api/client/work/clientset/versioned"
+	workinformers "open-cluster-management.io/api/client/work/informers/externalversions/work/v1"
 	worklister "open-cluster-management.io/api/client/work/listers/work/v1"
 	workapiv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work"
 )
 
 type WorkApplier struct {
 	cache      *workCache
+	dryRun     bool
 	getWork    func(ctx context.Context, namespace, name string) (*workapiv1.ManifestWork, error)
 	deleteWork func(ctx context.Context, namespace, name string) error
 	patchWork  func(ctx context.Context, namespace, name string, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error)
 	createWork func(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error)
+
+	// dryRunPatchWork and dryRunCreateWork return the work as it would be after the patch or the creation
+	// without persisting it.
+	dryRunPatchWork  func(ctx context.Context, existing *workapiv1.ManifestWork, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error)
+	dryRunCreateWork func(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error)
+}
+
+// WorkApplierOption configures a WorkApplier.
+type WorkApplierOption func(*WorkApplier)
+
+// WithDryRun makes the WorkApplier only compute the result of the apply, the works are never created, patched
+// or deleted.
+func WithDryRun() WorkApplierOption {
+	return func(w *WorkApplier) {
+		w.dryRun = true
+	}
 }
 
 func NewWorkApplierWithTypedClient(workClient workv1client.Interface,
-	workLister worklister.ManifestWorkLister) *WorkApplier {
-	return &WorkApplier{
+	workLister worklister.ManifestWorkLister, opts ...WorkApplierOption) *WorkApplier {
+	dryRunAll := []string{metav1.DryRunAll}
+	applier := &WorkApplier{
 		cache: newWorkCache(),
 		getWork: func(ctx context.Context, namespace, name string) (*workapiv1.ManifestWork, error) {
 			return workLister.ManifestWorks(namespace).Get(name)
@@ -43,35 +64,103 @@ func NewWorkApplierWithTypedClient(workClient workv1client.Interface,
 		patchWork: func(ctx context.Context, namespace, name string, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error) {
 			return workClient.WorkV1().ManifestWorks(namespace).Patch(ctx, name, pt, data, metav1.PatchOptions{})
 		},
+		// the kube-apiserver runs the admission and defaulting for the dry-run requests, so the result is what
+		// would be persisted.
+		dryRunPatchWork: func(ctx context.Context, existing *workapiv1.ManifestWork, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error) {
+			return workClient.WorkV1().ManifestWorks(existing.Namespace).Patch(
+				ctx, existing.Name, pt, data, metav1.PatchOptions{DryRun: dryRunAll})
+		},
+		dryRunCreateWork: func(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error) {
+			return workClient.WorkV1().ManifestWorks(work.Namespace).Create(ctx, work, metav1.CreateOptions{DryRun: dryRunAll})
+		},
+	}
+	for _, opt := range opts {
+		opt(applier)
 	}
+	return applier
 }
 
+// NewWorkApplierWithClientHolder returns a WorkApplier that applies the works with the cloudevents work clients,
+// the existing works are read from the given informer.
+//
+// The cloudevents clients do not support the dry-run requests, so the dry-run result is computed by patching
+// the existing work locally.
+func NewWorkApplierWithClientHolder(clientHolder *work.ClientHolder,
+	workInformer workinformers.ManifestWorkInformer, opts ...WorkApplierOption) *WorkApplier {
+	workLister := workInformer.Lister()
+	applier := &WorkApplier{
+		cache: newWorkCache(),
+		getWork: func(ctx context.Context, namespace, name string) (*workapiv1.ManifestWork, error) {
+			return workLister.ManifestWorks(namespace).Get(name)
+		},
+		deleteWork: func(ctx context.Context, namespace, name string) error {
+			return clientHolder.ManifestWorks(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		},
+		createWork: func(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error) {
+			return clientHolder.ManifestWorks(work.Namespace).Create(ctx, work, metav1.CreateOptions{})
+		},
+		patchWork: func(ctx context.Context, namespace, name string, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error) {
+			return clientHolder.ManifestWorks(namespace).Patch(ctx, name, pt, data, metav1.PatchOptions{})
+		},
+		dryRunPatchWork: localPatchWork,
+		dryRunCreateWork: func(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error) {
+			return work.DeepCopy(), nil
+		},
+	}
+	for _, opt := range opts {
+		opt(applier)
+	}
+	return applier
+}
+
+// Apply creates the work if it does not exist, or patches the existing work if it is changed. In dry-run mode,
+// the work is not persisted and the returned work is the work as it would be after the apply.
 func (w *WorkApplier) Apply(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, error) {
+	applied, _, err := w.apply(ctx, work, false)
+	return applied, err
+}
+
+// ApplyWithDiff applies the work as Apply does, and returns the differences between the existing work and the
+// applied work as well. In dry-run mode, the diff previews the changes without persisting them.
+func (w *WorkApplier) ApplyWithDiff(ctx context.Context, work *workapiv1.ManifestWork) (*workapiv1.ManifestWork, *WorkDiff, error) {
+	return w.apply(ctx, work, true)
+}
+
+func (w *WorkApplier) apply(ctx context.Context, work *workapiv1.ManifestWork, withDiff bool) (*workapiv1.ManifestWork, *WorkDiff, error) {
 	existingWork, err := w.getWork(ctx, work.Namespace, work.Name)
 	existingWork = existingWork.DeepCopy()
 	if errors.IsNotFound(err) {
+		if w.dryRun {
+			created, err := w.dryRunCreateWork(ctx, work)
+			if err != nil {
+				return nil, nil, err
+			}
+			return created, newWorkDiff(nil, created, withDiff), nil
+		}
+
 		existingWork, err = w.createWork(ctx, work)
 		switch {
 		case errors.IsAlreadyExists(err):
-			return work, nil
+			// the work is created by others, it is not changed by this apply
+			return work, newWorkDiff(work, work, withDiff), nil
 		case err != nil:
-			return nil, err
+			return nil, nil, err
 		default:
 			w.cache.updateCache(work, existingWork)
-			return existingWork, nil
+			return existingWork, newWorkDiff(nil, existingWork, withDiff), nil
 		}
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if w.cache.safeToSkipApply(work, existingWork) {
-		return existingWork, nil
+		return existingWork, newWorkDiff(existingWork, existingWork, withDiff), nil
 	}
 
 	if ManifestWorkEqual(work, existingWork) {
-		return existingWork, nil
+		return existingWork, newWorkDiff(existingWork, existingWork, withDiff), nil
 	}
 
 	oldData, err := json.Marshal(&workapiv1.ManifestWork{
@@ -83,7 +172,7 @@ func (w *WorkApplier) Apply(ctx context.Context, work *workapiv1.ManifestWork) (
 		Spec: existingWork.Spec,
 	})
 	if err != nil {
-		return existingWork, err
+		return existingWork, nil, err
 	}
 
 	newData, err := json.Marshal(&workapiv1.ManifestWork{
@@ -97,24 +186,38 @@ func (w *WorkApplier) Apply(ctx context.Context, work *workapiv1.ManifestWork) (
 		Spec: work.Spec,
 	})
 	if err != nil {
-		return existingWork, err
+		return existingWork, nil, err
 	}
 
 	patchBytes, err := jsonpatch.CreateMergePatch(oldData, newData)
 	if err != nil {
-		return existingWork, fmt.Errorf("failed to create patch for addon %s: %w", existingWork.Name, err)
+		return existingWork, nil, fmt.Errorf("failed to create patch for addon %s: %w", existingWork.Name, err)
+	}
+
+	if w.dryRun {
+		klog.V(4).Infof("Dry-run patching work %s/%s with %s", existingWork.Namespace, existingWork.Name, string(patchBytes))
+		updated, err := w.dryRunPatchWork(ctx, existingWork, types.MergePatchType, patchBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return updated, newWorkDiff(existingWork, updated, withDiff), nil
 	}
 
 	klog.V(2).Infof("Patching work %s/%s with %s", existingWork.Namespace, existingWork.Name, string(patchBytes))
 	updated, err := w.patchWork(ctx, existingWork.Namespace, existingWork.Name, types.MergePatchType, patchBytes)
 	if err == nil {
 		w.cache.updateCache(work, existingWork)
-		return updated, nil
+		return updated, newWorkDiff(existingWork, updated, withDiff), nil
 	}
-	return nil, err
+	return nil, nil, err
 }
 
+// Delete deletes the work, it is a no-op in dry-run mode.
 func (w *WorkApplier) Delete(ctx context.Context, namespace, name string) error {
+	if w.dryRun {
+		return nil
+	}
+
 	err := w.deleteWork(ctx, namespace, name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -127,6 +230,28 @@ func (w *WorkApplier) Delete(ctx context.Context, namespace, name string) error
 	return nil
 }
 
+// localPatchWork returns the existing work with the merge patch applied.
+func localPatchWork(_ context.Context, existing *workapiv1.ManifestWork, pt types.PatchType, data []byte) (*workapiv1.ManifestWork, error) {
+	if pt != types.MergePatchType {
+		return nil, fmt.Errorf("unsupported patch type %s", pt)
+	}
+
+	existingData, err := json.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+	patchedData, err := jsonpatch.MergePatch(existingData, data)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &workapiv1.ManifestWork{}
+	if err := json.Unmarshal(patchedData, patched); err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
 func shouldUpdateMap(required, existing map[string]string) bool {
 	if len(required) != len(existing) {
 		return true
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
 	clienttesting "k8s.io/client-go/testing"
 	fakework "open-cluster-management.io/api/client/work/clientset/versioned/fake"
 	workinformers "open-cluster-management.io/api/client/work/informers/externalversions"
@@ -376,6 +377,13 @@ func TestCreateWork(t *testing.T) {
 	if workApplier.cache.safeToSkipApply(work, work) {
 		t.Errorf("should not create work")
 	}
+	_, diff, err := workApplier.ApplyWithDiff(context.TODO(), work)
+	if err != nil {
+		t.Errorf("failed to apply work with err %v", err)
+	}
+	if diff == nil || !diff.Empty() {
+		t.Errorf("expected an empty diff for the work that is created by others, but got %v", diff)
+	}
 	fakeWorkClient.ReactionChain = []clienttesting.Reactor{}
 	_, err = workApplier.Apply(context.TODO(), work)
 	if err != nil {
@@ -385,3 +393,81 @@ func TestCreateWork(t *testing.T) {
 		t.Errorf("should create work")
 	}
 }
+
+func TestWorkApplierDryRun(t *testing.T) {
+	fakeWorkClient := fakework.NewSimpleClientset()
+	workInformerFactory := workinformers.NewSharedInformerFactory(fakeWorkClient, 10*time.Minute)
+	workInformer := workInformerFactory.Work().V1().ManifestWorks()
+	workApplier := NewWorkApplierWithTypedClient(fakeWorkClient, workInformer.Lister(), WithDryRun())
+
+	work := newFakeWork("test", "test", newUnstructured("batch/v1", "Job", "default", "test"))
+	_, diff, err := workApplier.ApplyWithDiff(context.TODO(), work)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertActions(t, fakeWorkClient.Actions(), "create")
+	createAction := fakeWorkClient.Actions()[0].(clienttesting.CreateActionImpl)
+	if len(createAction.GetCreateOptions().DryRun) == 0 {
+		t.Errorf("expected dry-run create, but got %v", createAction.GetCreateOptions())
+	}
+	if !diff.Created || len(diff.Manifests) != 1 || diff.Manifests[0].Action != ManifestAdded {
+		t.Errorf("unexpected diff %v", diff)
+	}
+
+	if err := workInformer.Informer().GetStore().Add(work); err != nil {
+		t.Fatal(err)
+	}
+
+	newWork := newFakeWork("test", "test", newUnstructured("batch/v1", "Job", "default", "test"))
+	newWork.Spec.DeleteOption = &workapiv1.DeleteOption{PropagationPolicy: workapiv1.DeletePropagationPolicyTypeOrphan}
+	fakeWorkClient.ClearActions()
+	_, diff, err = workApplier.ApplyWithDiff(context.TODO(), newWork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertActions(t, fakeWorkClient.Actions(), "patch")
+	patchAction := fakeWorkClient.Actions()[0].(clienttesting.PatchActionImpl)
+	if len(patchAction.GetPatchOptions().DryRun) == 0 {
+		t.Errorf("expected dry-run patch, but got %v", patchAction.GetPatchOptions())
+	}
+	if !apiequality.Semantic.DeepEqual(diff.Fields.Added, []string{"spec.deleteOption"}) {
+		t.Errorf("unexpected diff %v", diff)
+	}
+
+	// the works are not cached in dry-run mode
+	fakeWorkClient.ClearActions()
+	if _, err := workApplier.Apply(context.TODO(), newWork); err != nil {
+		t.Fatal(err)
+	}
+	assertActions(t, fakeWorkClient.Actions(), "patch")
+
+	fakeWorkClient.ClearActions()
+	if err := workApplier.Delete(context.TODO(), work.Namespace, work.Name); err != nil {
+		t.Fatal(err)
+	}
+	assertNoActions(t, fakeWorkClient.Actions())
+}
+
+func TestLocalPatchWork(t *testing.T) {
+	work := newFakeWork("test", "test", newUnstructured("batch/v1", "Job", "default", "test"))
+	work.ResourceVersion = "1"
+
+	patched, err := localPatchWork(context.TODO(), work, types.MergePatchType,
+		[]byte(`{"metadata":{"labels":{"app":"test"}},"spec":{"deleteOption":{"propagationPolicy":"Orphan"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Labels["app"] != "test" || patched.ResourceVersion != "1" {
+		t.Errorf("unexpected patched work metadata %v", patched.ObjectMeta)
+	}
+	if patched.Spec.DeleteOption == nil || patched.Spec.DeleteOption.PropagationPolicy != workapiv1.DeletePropagationPolicyTypeOrphan {
+		t.Errorf("unexpected patched work spec %v", patched.Spec)
+	}
+	if work.Labels != nil {
+		t.Errorf("expected the existing work is not changed")
+	}
+
+	if _, err := localPatchWork(context.TODO(), work, types.JSONPatchType, []byte(`[]`)); err == nil {
+		t.Errorf("expected error for the unsupported patch type")
+	}
+}
@@ -0,0 +1,237 @@
+package applier
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// ManifestAction is the change of a manifest in a work.
+type ManifestAction string
+
+const (
+	// ManifestAdded means the manifest is added to the work.
+	ManifestAdded ManifestAction = "Added"
+	// ManifestRemoved means the manifest is removed from the work.
+	ManifestRemoved ManifestAction = "Removed"
+	// ManifestChanged means the fields of the manifest are changed.
+	ManifestChanged ManifestAction = "Changed"
+)
+
+// FieldDiff lists the paths of the added, removed and changed fields, e.g. spec.template.spec.containers[0].image.
+type FieldDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+// Empty returns true if no fields are added, removed or changed.
+func (d FieldDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// ManifestDiff is the difference of a manifest between the existing work and the applied work. The manifests are
+// matched by their group, kind, namespace and name.
+type ManifestDiff struct {
+	Group     string         `json:"group,omitempty"`
+	Version   string         `json:"version"`
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace,omitempty"`
+	Name      string         `json:"name"`
+	Action    ManifestAction `json:"action"`
+
+	// FieldDiff is only set for the changed manifest.
+	FieldDiff `json:",inline"`
+}
+
+// WorkDiff is the difference between the existing work and the applied work.
+type WorkDiff struct {
+	// Created is true if the work does not exist before the apply.
+	Created bool `json:"created,omitempty"`
+	// Fields is the difference of the work fields other than the manifests, e.g. the labels, the annotations,
+	// the delete option or the manifest configs.
+	Fields FieldDiff `json:"fields,omitempty"`
+	// Manifests are the differences of the added, removed and changed manifests.
+	Manifests []ManifestDiff `json:"manifests,omitempty"`
+}
+
+// Empty returns true if the apply does not change the work.
+func (d *WorkDiff) Empty() bool {
+	return d == nil || (!d.Created && d.Fields.Empty() && len(d.Manifests) == 0)
+}
+
+type manifestKey struct {
+	group, kind, namespace, name string
+}
+
+// newWorkDiff returns the difference between the existing work and the applied work, the existing work is nil if
+// the work is created. It returns nil if the diff is not required.
+func newWorkDiff(existing, applied *workapiv1.ManifestWork, required bool) *WorkDiff {
+	if !required {
+		return nil
+	}
+
+	diff := &WorkDiff{}
+	if existing == nil {
+		diff.Created = true
+		existing = &workapiv1.ManifestWork{}
+	} else {
+		diff.Fields = diffWorkFields(existing, applied)
+	}
+
+	existingManifests := decodeManifests(existing)
+	existingKeys := map[manifestKey]*unstructured.Unstructured{}
+	for _, obj := range existingManifests {
+		existingKeys[keyOfManifest(obj)] = obj
+	}
+	appliedManifests := decodeManifests(applied)
+	appliedKeys := map[manifestKey]bool{}
+	for _, obj := range appliedManifests {
+		key := keyOfManifest(obj)
+		appliedKeys[key] = true
+
+		existingObj, ok := existingKeys[key]
+		if !ok {
+			diff.Manifests = append(diff.Manifests, newManifestDiff(obj, ManifestAdded))
+			continue
+		}
+
+		fieldDiff := FieldDiff{}
+		diffFields("", existingObj.Object, obj.Object, &fieldDiff)
+		if fieldDiff.Empty() {
+			continue
+		}
+		manifestDiff := newManifestDiff(obj, ManifestChanged)
+		manifestDiff.FieldDiff = fieldDiff
+		diff.Manifests = append(diff.Manifests, manifestDiff)
+	}
+	for _, obj := range existingManifests {
+		if !appliedKeys[keyOfManifest(obj)] {
+			diff.Manifests = append(diff.Manifests, newManifestDiff(obj, ManifestRemoved))
+		}
+	}
+	return diff
+}
+
+// diffWorkFields compares the fields that the applier manages except the manifests.
+func diffWorkFields(existing, applied *workapiv1.ManifestWork) FieldDiff {
+	toUnstructured := func(work *workapiv1.ManifestWork) map[string]interface{} {
+		managed := &workapiv1.ManifestWork{Spec: *work.Spec.DeepCopy()}
+		managed.Labels = work.Labels
+		managed.Annotations = work.Annotations
+		managed.OwnerReferences = work.OwnerReferences
+		managed.Spec.Workload.Manifests = nil
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(managed)
+		if err != nil {
+			klog.Errorf("failed to convert work %s/%s to unstructured, err: %v", work.Namespace, work.Name, err)
+			return nil
+		}
+		// the conversion adds an empty creationTimestamp
+		unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+		return obj
+	}
+
+	fieldDiff := FieldDiff{}
+	diffFields("", toUnstructured(existing), toUnstructured(applied), &fieldDiff)
+	return fieldDiff
+}
+
+func decodeManifests(work *workapiv1.ManifestWork) []*unstructured.Unstructured {
+	objs := []*unstructured.Unstructured{}
+	for _, manifest := range mutateWork(work).Spec.Workload.Manifests {
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			klog.Errorf("failed to unmarshal work manifest, err: %v", err)
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
+func keyOfManifest(obj *unstructured.Unstructured) manifestKey {
+	gvk := obj.GroupVersionKind()
+	return manifestKey{group: gvk.Group, kind: gvk.Kind, namespace: obj.GetNamespace(), name: obj.GetName()}
+}
+
+func newManifestDiff(obj *unstructured.Unstructured, action ManifestAction) ManifestDiff {
+	gvk := obj.GroupVersionKind()
+	return ManifestDiff{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Action:    action,
+	}
+}
+
+// diffFields compares the maps by keys and the lists by indexes recursively, other values are compared as a whole.
+func diffFields(path string, existing, applied interface{}, diff *FieldDiff) {
+	switch existingValue := existing.(type) {
+	case map[string]interface{}:
+		appliedValue, ok := applied.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := map[string]bool{}
+		for key := range existingValue {
+			keys[key] = true
+		}
+		for key := range appliedValue {
+			keys[key] = true
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for key := range keys {
+			sortedKeys = append(sortedKeys, key)
+		}
+		sort.Strings(sortedKeys)
+
+		for _, key := range sortedKeys {
+			fieldPath := key
+			if len(path) != 0 {
+				fieldPath = path + "." + key
+			}
+			existingField, inExisting := existingValue[key]
+			appliedField, inApplied := appliedValue[key]
+			switch {
+			case !inExisting:
+				diff.Added = append(diff.Added, fieldPath)
+			case !inApplied:
+				diff.Removed = append(diff.Removed, fieldPath)
+			default:
+				diffFields(fieldPath, existingField, appliedField, diff)
+			}
+		}
+		return
+	case []interface{}:
+		appliedValue, ok := applied.([]interface{})
+		if !ok {
+			break
+		}
+
+		for i := 0; i < len(existingValue) || i < len(appliedValue); i++ {
+			fieldPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(existingValue):
+				diff.Added = append(diff.Added, fieldPath)
+			case i >= len(appliedValue):
+				diff.Removed = append(diff.Removed, fieldPath)
+			default:
+				diffFields(fieldPath, existingValue[i], appliedValue[i], diff)
+			}
+		}
+		return
+	}
+
+	if !equality.Semantic.DeepEqual(existing, applied) {
+		diff.Changed = append(diff.Changed, path)
+	}
+}
@@ -0,0 +1,124 @@
+package applier
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func TestNewWorkDiff(t *testing.T) {
+	newDeployment := func(replicas int64, image string) *unstructured.Unstructured {
+		obj := newUnstructured("apps/v1", "Deployment", "default", "app")
+		_ = unstructured.SetNestedField(obj.Object, replicas, "spec", "replicas")
+		_ = unstructured.SetNestedSlice(obj.Object, []interface{}{
+			map[string]interface{}{"name": "app", "image": image},
+		}, "spec", "template", "spec", "containers")
+		return obj
+	}
+	newWork := func(objs ...runtime.Object) *workapiv1.ManifestWork {
+		work := newFakeWork("test", "cluster1", objs[0])
+		for _, obj := range objs[1:] {
+			work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, newFakeWork("", "", obj).Spec.Workload.Manifests...)
+		}
+		return work
+	}
+
+	cases := []struct {
+		name     string
+		existing *workapiv1.ManifestWork
+		applied  *workapiv1.ManifestWork
+		expected *WorkDiff
+	}{
+		{
+			name:     "created",
+			applied:  newWork(newDeployment(1, "app:v1")),
+			expected: &WorkDiff{Created: true, Manifests: []ManifestDiff{{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "app", Action: ManifestAdded}}},
+		},
+		{
+			name:     "not changed",
+			existing: newWork(newDeployment(1, "app:v1")),
+			applied:  newWork(newDeployment(1, "app:v1")),
+			expected: &WorkDiff{},
+		},
+		{
+			name:     "manifest changed",
+			existing: newWork(newDeployment(1, "app:v1")),
+			applied: func() *workapiv1.ManifestWork {
+				obj := newDeployment(2, "app:v2")
+				obj.SetLabels(map[string]string{"app": "test"})
+				unstructured.RemoveNestedField(obj.Object, "spec", "template")
+				return newWork(obj)
+			}(),
+			expected: &WorkDiff{Manifests: []ManifestDiff{{
+				Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "app", Action: ManifestChanged,
+				FieldDiff: FieldDiff{
+					Added:   []string{"metadata.labels"},
+					Removed: []string{"spec.template"},
+					Changed: []string{"spec.replicas"},
+				},
+			}}},
+		},
+		{
+			name:     "list item changed",
+			existing: newWork(newDeployment(1, "app:v1")),
+			applied:  newWork(newDeployment(1, "app:v2")),
+			expected: &WorkDiff{Manifests: []ManifestDiff{{
+				Group: "apps", Version: "v1", Kind: "Deployment", Namespace: "default", Name: "app", Action: ManifestChanged,
+				FieldDiff: FieldDiff{Changed: []string{"spec.template.spec.containers[0].image"}},
+			}}},
+		},
+		{
+			name:     "manifests added and removed",
+			existing: newWork(newDeployment(1, "app:v1"), newUnstructured("v1", "ConfigMap", "default", "cm1")),
+			applied:  newWork(newUnstructured("v1", "ConfigMap", "default", "cm2"), newDeployment(1, "app:v1")),
+			expected: &WorkDiff{Manifests: []ManifestDiff{
+				{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm2", Action: ManifestAdded},
+				{Version: "v1", Kind: "ConfigMap", Namespace: "default", Name: "cm1", Action: ManifestRemoved},
+			}},
+		},
+		{
+			name: "work fields changed",
+			existing: func() *workapiv1.ManifestWork {
+				work := newWork(newDeployment(1, "app:v1"))
+				work.Annotations = map[string]string{"hash": "1"}
+				return work
+			}(),
+			applied: func() *workapiv1.ManifestWork {
+				work := newWork(newDeployment(1, "app:v1"))
+				work.Annotations = map[string]string{"hash": "2"}
+				work.Labels = map[string]string{"app": "test"}
+				work.Spec.DeleteOption = &workapiv1.DeleteOption{PropagationPolicy: workapiv1.DeletePropagationPolicyTypeOrphan}
+				work.OwnerReferences = []metav1.OwnerReference{}
+				return work
+			}(),
+			expected: &WorkDiff{Fields: FieldDiff{
+				Added:   []string{"metadata.labels", "spec.deleteOption"},
+				Changed: []string{"metadata.annotations.hash"},
+			}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			diff := newWorkDiff(c.existing, c.applied, true)
+			if !reflect.DeepEqual(diff, c.expected) {
+				t.Errorf("expected %#v, but got %#v", c.expected, diff)
+			}
+			if diff.Empty() != reflect.DeepEqual(c.expected, &WorkDiff{}) {
+				t.Errorf("unexpected empty diff %v", diff.Empty())
+			}
+		})
+	}
+
+	if diff := newWorkDiff(nil, newWork(newDeployment(1, "app:v1")), false); diff != nil {
+		t.Errorf("expected no diff, but got %v", diff)
+	}
+	if !(*WorkDiff)(nil).Empty() {
+		t.Errorf("expected the nil diff is empty")
+	}
+}
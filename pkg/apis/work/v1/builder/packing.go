@@ -0,0 +1,286 @@
+package builder
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+// PackingStrategy decides how the manifests are packed into the manifestWorks.
+type PackingStrategy string
+
+const (
+	// PackingStrategyGreedy keeps the existing manifests in their manifestWorks, and fills the new manifests into
+	// the manifestWork with the max buffer.
+	PackingStrategyGreedy PackingStrategy = "Greedy"
+
+	// PackingStrategyStableHash keeps the existing manifests in their manifestWorks, and fills a new manifest into
+	// the first manifestWork that has enough buffer in the order ranked by hashing the manifest key with the
+	// manifestWork name, so a manifest is always packed into the same manifestWork for the same set of works.
+	// The manifestWork whose manifests outgrow the limit is split by moving out the manifests that rank it the
+	// lowest.
+	PackingStrategyStableHash PackingStrategy = "StableHash"
+)
+
+// ManifestMove is a manifest that is moved from an existing manifestWork to another manifestWork.
+type ManifestMove struct {
+	Group     string
+	Version   string
+	Kind      string
+	Namespace string
+	Name      string
+	From      string
+	To        string
+}
+
+type keyedManifest struct {
+	key      manifestKey
+	manifest workapiv1.Manifest
+	size     int
+}
+
+type packedWork struct {
+	work      *workapiv1.ManifestWork
+	manifests []keyedManifest
+	// size is the total size of the manifests in the manifestWork
+	size int
+}
+
+func (p *packedWork) add(m keyedManifest) {
+	p.work.Spec.Workload.Manifests = append(p.work.Spec.Workload.Manifests, m.manifest)
+	p.manifests = append(p.manifests, m)
+	p.size = p.size + m.size
+}
+
+func (p *packedWork) empty() bool {
+	return len(p.manifests) == 0
+}
+
+// buildStableHashManifestWorks packs the manifests with the PackingStrategyStableHash.
+func (f *internalWorkBuilder) buildStableHashManifestWorks(objects []runtime.Object) (appliedWorks, deletedWorks []*workapiv1.ManifestWork, err error) {
+	requiredMapper, err := generateRequiredManifestMapper(objects)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate required mapper.err %v", err)
+	}
+
+	var works []*packedWork
+	var pending []keyedManifest
+	workNames := sets.New[string]()
+
+	// keep the existing manifests in their works, and split the works that outgrow the limit.
+	for _, existingWork := range f.existingManifestWorks {
+		work := &packedWork{work: f.initManifestWorkWithName(existingWork.Name)}
+		workNames.Insert(existingWork.Name)
+
+		var kept []keyedManifest
+		keptSize := 0
+		for _, manifest := range existingWork.Spec.Workload.Manifests {
+			key, err := generateManifestKey(manifest)
+			if err != nil {
+				return nil, nil, err
+			}
+			required, ok := requiredMapper[key]
+			if !ok {
+				continue
+			}
+			delete(requiredMapper, key)
+			kept = append(kept, keyedManifest{key: key, manifest: required, size: required.Size()})
+			keptSize = keptSize + required.Size()
+		}
+
+		evicted := sets.New[manifestKey]()
+		if keptSize >= f.workBuilder.manifestLimit && len(kept) > 1 {
+			ranked := make([]keyedManifest, len(kept))
+			copy(ranked, kept)
+			sort.SliceStable(ranked, func(i, j int) bool {
+				return rankScore(existingWork.Name, ranked[i].key) < rankScore(existingWork.Name, ranked[j].key)
+			})
+			for i := 0; keptSize >= f.workBuilder.manifestLimit && i < len(ranked)-1; i++ {
+				evicted.Insert(ranked[i].key)
+				keptSize = keptSize - ranked[i].size
+				pending = append(pending, ranked[i])
+			}
+		}
+
+		// keep the order of the existing manifests to avoid changing the work spec
+		for _, m := range kept {
+			if !evicted.Has(m.key) {
+				work.add(m)
+			}
+		}
+		works = append(works, work)
+	}
+
+	for key, manifest := range requiredMapper {
+		pending = append(pending, keyedManifest{key: key, manifest: manifest, size: manifest.Size()})
+	}
+
+	// sort from big to small by size, and by the key for the same size to make the result stable.
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].size != pending[j].size {
+			return pending[j].size < pending[i].size
+		}
+		return hashKey(pending[i].key) < hashKey(pending[j].key)
+	})
+
+	nextIndex := 0
+	for _, m := range pending {
+		if work := f.rankedFit(works, m, nil); work != nil {
+			work.add(m)
+			continue
+		}
+
+		// no work has enough buffer, create a new work with an unused name.
+		newWork := f.initManifestWork(nextIndex)
+		for workNames.Has(newWork.Name) {
+			nextIndex = nextIndex + 1
+			newWork = f.initManifestWork(nextIndex)
+		}
+		workNames.Insert(newWork.Name)
+		packed := &packedWork{work: newWork}
+		packed.add(m)
+		works = append(works, packed)
+	}
+
+	if f.compactionThreshold > 0 {
+		f.compact(works)
+	}
+
+	for _, work := range works {
+		if work.empty() {
+			deletedWorks = append(deletedWorks, work.work)
+			continue
+		}
+		appliedWorks = append(appliedWorks, work.work)
+	}
+	return appliedWorks, deletedWorks, nil
+}
+
+// compact merges the works whose manifests size is less than the compaction threshold of the limit into the
+// other works from the smallest one, a work is only merged if all of its manifests can be moved.
+func (f *internalWorkBuilder) compact(works []*packedWork) {
+	threshold := int(float64(f.workBuilder.manifestLimit) * f.compactionThreshold)
+
+	var underfilled []*packedWork
+	for _, work := range works {
+		if !work.empty() && work.size < threshold {
+			underfilled = append(underfilled, work)
+		}
+	}
+	sort.SliceStable(underfilled, func(i, j int) bool {
+		if underfilled[i].size != underfilled[j].size {
+			return underfilled[i].size < underfilled[j].size
+		}
+		return underfilled[i].work.Name < underfilled[j].work.Name
+	})
+
+	for _, source := range underfilled {
+		// plan the moves with the sizes of the targets, and only apply them if all the manifests are moved.
+		planned := map[*packedWork]int{}
+		targets := make([]*packedWork, len(source.manifests))
+		for i, m := range source.manifests {
+			target := f.rankedFit(works, m, func(work *packedWork) bool {
+				if work == source || work.empty() {
+					return false
+				}
+				return work.size+planned[work]+m.size < f.workBuilder.manifestLimit
+			})
+			if target == nil {
+				targets = nil
+				break
+			}
+			planned[target] = planned[target] + m.size
+			targets[i] = target
+		}
+		if targets == nil {
+			continue
+		}
+
+		for i, m := range source.manifests {
+			targets[i].add(m)
+		}
+		source.work.Spec.Workload.Manifests = nil
+		source.manifests = nil
+		source.size = 0
+	}
+}
+
+// rankedFit returns the first work that fits the manifest in the order ranked by the manifest key and the work
+// name. By default, a work fits the manifest if it has enough buffer.
+func (f *internalWorkBuilder) rankedFit(works []*packedWork, m keyedManifest, fits func(work *packedWork) bool) *packedWork {
+	if fits == nil {
+		fits = func(work *packedWork) bool {
+			return work.size+m.size < f.workBuilder.manifestLimit
+		}
+	}
+
+	ranked := make([]*packedWork, len(works))
+	copy(ranked, works)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return rankScore(ranked[i].work.Name, m.key) > rankScore(ranked[j].work.Name, m.key)
+	})
+	for _, work := range ranked {
+		if fits(work) {
+			return work
+		}
+	}
+	return nil
+}
+
+// manifestMoves returns the manifests that are in the existing works and are packed into other works.
+func (f *internalWorkBuilder) manifestMoves(appliedWorks []*workapiv1.ManifestWork) ([]ManifestMove, error) {
+	existing := map[manifestKey]string{}
+	for _, work := range f.existingManifestWorks {
+		for _, manifest := range work.Spec.Workload.Manifests {
+			key, err := generateManifestKey(manifest)
+			if err != nil {
+				return nil, err
+			}
+			existing[key] = work.Name
+		}
+	}
+
+	var moves []ManifestMove
+	for _, work := range appliedWorks {
+		for _, manifest := range work.Spec.Workload.Manifests {
+			key, err := generateManifestKey(manifest)
+			if err != nil {
+				return nil, err
+			}
+			from, ok := existing[key]
+			if !ok || from == work.Name {
+				continue
+			}
+			moves = append(moves, ManifestMove{
+				Group:     key.gvk.Group,
+				Version:   key.gvk.Version,
+				Kind:      key.gvk.Kind,
+				Namespace: key.namespace,
+				Name:      key.name,
+				From:      from,
+				To:        work.Name,
+			})
+		}
+	}
+	return moves, nil
+}
+
+// hashKey returns the string to hash a manifest, the version is excluded so the manifest is not moved when its
+// version is changed.
+func hashKey(key manifestKey) string {
+	return fmt.Sprintf("%s/%s/%s/%s", key.gvk.Group, key.gvk.Kind, key.namespace, key.name)
+}
+
+// rankScore is the rendezvous hashing score of a manifest for a work.
+func rankScore(workName string, key manifestKey) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(workName))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(hashKey(key)))
+	return h.Sum64()
+}
@@ -0,0 +1,166 @@
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workapiv1 "open-cluster-management.io/api/work/v1"
+)
+
+func toExistingWorks(works []*workapiv1.ManifestWork) []workapiv1.ManifestWork {
+	var existingWorks []workapiv1.ManifestWork
+	for _, work := range works {
+		existingWorks = append(existingWorks, *work)
+	}
+	return existingWorks
+}
+
+func workManifestNames(t *testing.T, works []*workapiv1.ManifestWork) map[string][]string {
+	names := map[string][]string{}
+	for _, work := range works {
+		for _, manifest := range work.Spec.Workload.Manifests {
+			key, err := generateManifestKey(manifest)
+			assert.NoError(t, err)
+			names[work.Name] = append(names[work.Name], key.name)
+		}
+	}
+	return names
+}
+
+func TestStableHashPacking(t *testing.T) {
+	builder := NewWorkBuilder().WithManifestsLimit(30 * 1024)
+	objects := []runtime.Object{
+		newFakeCRD("test1", 5*1024),
+		newFakeCRD("test2", 7*1024),
+		newFakeCRD("test3", 10*1024),
+		newFakeCRD("test4", 6*1024),
+		newFakeCRD("test5", 8*1024),
+		newFakeCRD("test6", 1*1024),
+	}
+
+	// the result does not depend on the order of the objects
+	applied, deleted, err := builder.Build(objects, generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash))
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	reversed := []runtime.Object{}
+	for i := len(objects) - 1; i >= 0; i-- {
+		reversed = append(reversed, objects[i])
+	}
+	reversedApplied, _, err := builder.Build(reversed, generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash))
+	assert.NoError(t, err)
+	assert.Equal(t, workManifestNames(t, applied), workManifestNames(t, reversedApplied))
+	for _, work := range applied {
+		size := 0
+		for _, manifest := range work.Spec.Workload.Manifests {
+			size = size + manifest.Size()
+		}
+		assert.Less(t, size, 24*1024)
+	}
+
+	// the updated and added manifests do not move the existing manifests
+	var moves []ManifestMove
+	updatedObjects := append([]runtime.Object{newFakeCRD("test7", 2*1024)}, objects[1:]...)
+	updatedObjects[1] = newFakeCRD("test2", 8*1024)
+	updated, deleted, err := builder.Build(updatedObjects, generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash),
+		ExistingManifestWorksOption(toExistingWorks(applied)),
+		ManifestMovesOption(func(m []ManifestMove) { moves = m }))
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.Empty(t, moves)
+	before, after := workManifestNames(t, applied), workManifestNames(t, updated)
+	for work, names := range before {
+		for _, name := range names {
+			if name != "test1" {
+				assert.Contains(t, after[work], name)
+			}
+		}
+	}
+}
+
+func TestStableHashPackingSplit(t *testing.T) {
+	existingWork := workapiv1.ManifestWork{
+		ObjectMeta: generateManifestWorkObjectMeta(0),
+		Spec: workapiv1.ManifestWorkSpec{
+			Workload: workapiv1.ManifestsTemplate{
+				Manifests: []workapiv1.Manifest{
+					newFakeManifest(newFakeCRD("test1", 10*1024)),
+					newFakeManifest(newFakeCRD("test2", 10*1024)),
+				},
+			},
+		},
+	}
+
+	var moves []ManifestMove
+	applied, deleted, err := NewWorkBuilder().WithManifestsLimit(30*1024).Build(
+		[]runtime.Object{newFakeCRD("test1", 15*1024), newFakeCRD("test2", 15*1024)},
+		generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash),
+		ExistingManifestWorksOption([]workapiv1.ManifestWork{existingWork}),
+		ManifestMovesOption(func(m []ManifestMove) { moves = m }))
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.Equal(t, 2, len(applied))
+	assert.Equal(t, "test-work-0", applied[0].Name)
+	assert.Equal(t, 1, len(applied[0].Spec.Workload.Manifests))
+	assert.Equal(t, "test-work-1", applied[1].Name)
+	assert.Equal(t, 1, len(applied[1].Spec.Workload.Manifests))
+	assert.Equal(t, 1, len(moves))
+	assert.Equal(t, "test-work-0", moves[0].From)
+	assert.Equal(t, "test-work-1", moves[0].To)
+	assert.Equal(t, "CustomResourceDefinition", moves[0].Kind)
+}
+
+func TestStableHashPackingCompaction(t *testing.T) {
+	newWork := func(index int, objects ...runtime.Object) workapiv1.ManifestWork {
+		work := workapiv1.ManifestWork{ObjectMeta: generateManifestWorkObjectMeta(index)}
+		for _, obj := range objects {
+			work.Spec.Workload.Manifests = append(work.Spec.Workload.Manifests, newFakeManifest(obj))
+		}
+		return work
+	}
+	existingWorks := []workapiv1.ManifestWork{
+		newWork(0, newFakeCRD("test1", 10*1024)),
+		newWork(1, newFakeCRD("test2", 2*1024), newFakeCRD("test3", 1*1024)),
+		newWork(2, newFakeCRD("test4", 15*1024)),
+	}
+	objects := []runtime.Object{
+		newFakeCRD("test1", 10*1024),
+		newFakeCRD("test2", 2*1024),
+		newFakeCRD("test3", 1*1024),
+		newFakeCRD("test4", 15*1024),
+	}
+
+	// without compaction, the works are not changed
+	applied, deleted, err := NewWorkBuilder().WithManifestsLimit(30*1024).Build(objects, generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash),
+		ExistingManifestWorksOption(existingWorks))
+	assert.NoError(t, err)
+	assert.Empty(t, deleted)
+	assert.Equal(t, 3, len(applied))
+
+	var moves []ManifestMove
+	applied, deleted, err = NewWorkBuilder().WithManifestsLimit(30*1024).Build(objects, generateManifestWorkObjectMeta,
+		PackingStrategyOption(PackingStrategyStableHash),
+		CompactionOption(0.5),
+		ExistingManifestWorksOption(existingWorks),
+		ManifestMovesOption(func(m []ManifestMove) { moves = m }))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(deleted))
+	assert.Equal(t, "test-work-1", deleted[0].Name)
+	assert.Equal(t, 2, len(applied))
+	assert.Equal(t, 2, len(moves))
+	for _, move := range moves {
+		assert.Equal(t, "test-work-1", move.From)
+	}
+}
+
+func TestUnsupportedPackingStrategy(t *testing.T) {
+	_, _, err := NewWorkBuilder().Build([]runtime.Object{newFakeCRD("test1", 1024)}, generateManifestWorkObjectMeta,
+		PackingStrategyOption("Unknown"))
+	assert.Error(t, err)
+}
@@ -56,6 +56,9 @@ type internalWorkBuilder struct {
 	existingManifestWorks          []workapiv1.ManifestWork
 	manifestConfigOption           []workapiv1.ManifestConfigOption
 	annotations                    map[string]string
+	packingStrategy                PackingStrategy
+	compactionThreshold            float64
+	reportManifestMoves            func(moves []ManifestMove)
 }
 type WorkBuilderOption func(*internalWorkBuilder) *internalWorkBuilder
 
@@ -94,6 +97,34 @@ func ManifestAnnotations(annotations map[string]string) WorkBuilderOption {
 	}
 }
 
+// PackingStrategyOption sets the strategy to pack the manifests into the manifestWorks,
+// the default strategy is PackingStrategyGreedy.
+func PackingStrategyOption(strategy PackingStrategy) WorkBuilderOption {
+	return func(builder *internalWorkBuilder) *internalWorkBuilder {
+		builder.packingStrategy = strategy
+		return builder
+	}
+}
+
+// CompactionOption merges the manifestWorks whose manifests size is less than the threshold ratio of the manifests
+// limit into the other manifestWorks, and the merged manifestWorks are deleted.
+// It only takes effect with the PackingStrategyStableHash.
+func CompactionOption(threshold float64) WorkBuilderOption {
+	return func(builder *internalWorkBuilder) *internalWorkBuilder {
+		builder.compactionThreshold = threshold
+		return builder
+	}
+}
+
+// ManifestMovesOption sets a func to receive the manifests that are moved between the existing manifestWorks
+// by the build, a moved manifest is deleted and recreated on the managed cluster.
+func ManifestMovesOption(report func(moves []ManifestMove)) WorkBuilderOption {
+	return func(builder *internalWorkBuilder) *internalWorkBuilder {
+		builder.reportManifestMoves = report
+		return builder
+	}
+}
+
 func NewWorkBuilder() *WorkBuilder {
 	return &WorkBuilder{
 		manifestLimit: int(float64(DefaultManifestLimit) * DefaultManifestThreshold),
@@ -160,6 +191,30 @@ func (w *WorkBuilder) BuildAndApply(ctx context.Context,
 }
 
 func (f *internalWorkBuilder) buildManifestWorks(objects []runtime.Object) (appliedWorks, deletedWorks []*workapiv1.ManifestWork, err error) {
+	switch f.packingStrategy {
+	case "", PackingStrategyGreedy:
+		appliedWorks, deletedWorks, err = f.buildGreedyManifestWorks(objects)
+	case PackingStrategyStableHash:
+		appliedWorks, deletedWorks, err = f.buildStableHashManifestWorks(objects)
+	default:
+		return nil, nil, fmt.Errorf("unsupported packing strategy %q", f.packingStrategy)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f.reportManifestMoves != nil {
+		moves, err := f.manifestMoves(appliedWorks)
+		if err != nil {
+			return nil, nil, err
+		}
+		f.reportManifestMoves(moves)
+	}
+	return appliedWorks, deletedWorks, nil
+}
+
+// buildGreedyManifestWorks packs the manifests with the PackingStrategyGreedy.
+func (f *internalWorkBuilder) buildGreedyManifestWorks(objects []runtime.Object) (appliedWorks, deletedWorks []*workapiv1.ManifestWork, err error) {
 	var updatedWorks []manifestWorkBuffer
 
 	requiredMapper, err := generateRequiredManifestMapper(objects)
@@ -179,7 +234,7 @@ func (f *internalWorkBuilder) buildManifestWorks(objects []runtime.Object) (appl
 			}
 
 			// currently,we have 80% threshold for the size of manifests, update directly.
-			// TODO: need to consider if the size of updated manifests is more then the limit of manifestWork.
+			// the PackingStrategyStableHash splits the manifestWork if the updated manifests outgrow the limit.
 			if _, ok := requiredMapper[key]; ok {
 				requiredWork.Spec.Workload.Manifests = append(requiredWork.Spec.Workload.Manifests, requiredMapper[key])
 				delete(requiredMapper, key)
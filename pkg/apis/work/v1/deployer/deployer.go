@@ -0,0 +1,289 @@
+package deployer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	worklister "open-cluster-management.io/api/client/work/listers/work/v1"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+
+	clustersdkv1alpha1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1alpha1"
+	clustersdkv1beta1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1beta1"
+	"open-cluster-management.io/sdk-go/pkg/apis/work/v1/applier"
+	"open-cluster-management.io/sdk-go/pkg/apis/work/v1/builder"
+)
+
+const (
+	// DeployerLabelKey is the label of the manifestWorks that are deployed by a deployer, the value is the
+	// deployer name.
+	DeployerLabelKey = "work.open-cluster-management.io/deployer"
+
+	// SpecHashAnnotationKey is the annotation of the manifestWorks that records the hash of the objects that the
+	// manifestWorks are built with, the cluster is rolled out again once the hash is changed.
+	SpecHashAnnotationKey = "work.open-cluster-management.io/spec-hash"
+)
+
+// ObjectsFunc returns the objects to deploy on a cluster.
+type ObjectsFunc func(clusterName string) ([]runtime.Object, error)
+
+// Deployer deploys the objects to the clusters selected by a placement with a rollout strategy. The objects of a
+// cluster are built into the manifestWorks in the cluster namespace, the manifestWorks are named with the
+// deployer name and an index, and labeled with the DeployerLabelKey.
+//
+// On each sync, the deployer
+//  1. finds the clusters from the decisions of the placement.
+//  2. determines the rollout status of the clusters, a cluster is ToApply if it has no manifestWorks or its
+//     objects are changed, otherwise its status is returned by the status func.
+//  3. builds and applies the manifestWorks of the clusters to roll out, and deletes the manifestWorks of the
+//     clusters that are removed from the placement.
+type Deployer struct {
+	name           string
+	workBuilder    *builder.WorkBuilder
+	workApplier    *applier.WorkApplier
+	workLister     worklister.ManifestWorkLister
+	decisionGetter clustersdkv1beta1.PlacementDecisionGetter
+	objectsFunc    ObjectsFunc
+	statusFunc     clustersdkv1alpha1.ClusterRolloutStatusFunc[[]*workapiv1.ManifestWork]
+
+	annotations    map[string]string
+	builderOptions []builder.WorkBuilderOption
+}
+
+// DeployerOption configures a Deployer.
+type DeployerOption func(*Deployer)
+
+// WithWorkBuilder sets the WorkBuilder to build the manifestWorks, a WorkBuilder with the default manifests limit
+// is used by default.
+func WithWorkBuilder(workBuilder *builder.WorkBuilder) DeployerOption {
+	return func(d *Deployer) {
+		d.workBuilder = workBuilder
+	}
+}
+
+// WithWorkAnnotations sets the annotations of the manifestWorks, use it instead of the builder.ManifestAnnotations
+// option, which is overridden by the deployer.
+func WithWorkAnnotations(annotations map[string]string) DeployerOption {
+	return func(d *Deployer) {
+		d.annotations = annotations
+	}
+}
+
+// WithWorkBuilderOptions sets the options to build the manifestWorks, e.g. the delete option, the manifest configs
+// or the packing strategy.
+func WithWorkBuilderOptions(options ...builder.WorkBuilderOption) DeployerOption {
+	return func(d *Deployer) {
+		d.builderOptions = options
+	}
+}
+
+// NewDeployer returns a Deployer. The workLister lists the manifestWorks of all the cluster namespaces, and the
+// statusFunc returns the rollout status of a cluster from its manifestWorks.
+func NewDeployer(name string,
+	workApplier *applier.WorkApplier,
+	workLister worklister.ManifestWorkLister,
+	decisionGetter clustersdkv1beta1.PlacementDecisionGetter,
+	objectsFunc ObjectsFunc,
+	statusFunc clustersdkv1alpha1.ClusterRolloutStatusFunc[[]*workapiv1.ManifestWork],
+	opts ...DeployerOption) *Deployer {
+	d := &Deployer{
+		name:           name,
+		workBuilder:    builder.NewWorkBuilder(),
+		workApplier:    workApplier,
+		workLister:     workLister,
+		decisionGetter: decisionGetter,
+		objectsFunc:    objectsFunc,
+		statusFunc:     statusFunc,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Sync rolls out the objects to the clusters of the placement with the rollout strategy. It returns the rollout
+// result, the manifestWorks are applied to the ClustersToRollout and deleted from the ClustersRemoved, and the
+// sync should be requeued after the RecheckAfter if it is set.
+func (d *Deployer) Sync(ctx context.Context, placement *clusterv1beta1.Placement,
+	rolloutStrategy clusterv1alpha1.RolloutStrategy) (clustersdkv1alpha1.RolloutResult, error) {
+	existingWorks, err := d.existingWorks()
+	if err != nil {
+		return clustersdkv1alpha1.RolloutResult{}, err
+	}
+
+	tracker := clustersdkv1beta1.NewPlacementDecisionClustersTracker(placement, d.decisionGetter, nil)
+	if err := tracker.Refresh(); err != nil {
+		return clustersdkv1alpha1.RolloutResult{}, err
+	}
+	clusterGroups := tracker.ExistingClusterGroupsBesides()
+	clusterToGroupKey := clusterGroups.ClusterToGroupKey()
+
+	// the objects of the clusters in the placement, they are used to check whether a cluster is changed and to
+	// build the manifestWorks if the cluster is rolled out.
+	objects := map[string][]runtime.Object{}
+	specHashes := map[string]string{}
+	var errs []error
+	for _, clusterName := range sets.List(clusterGroups.GetClusters()) {
+		clusterObjects, err := d.objectsFunc(clusterName)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to get objects of cluster %s: %v", clusterName, err))
+			continue
+		}
+		specHash, err := hashObjects(clusterObjects)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to hash objects of cluster %s: %v", clusterName, err))
+			continue
+		}
+		objects[clusterName] = clusterObjects
+		specHashes[clusterName] = specHash
+	}
+
+	var existingStatus []clustersdkv1alpha1.ClusterRolloutStatus
+	for _, clusterName := range sets.List(sets.KeySet(existingWorks).Union(sets.KeySet(objects))) {
+		works := existingWorks[clusterName]
+		status := clustersdkv1alpha1.ClusterRolloutStatus{
+			ClusterName: clusterName,
+			GroupKey:    clusterToGroupKey[clusterName],
+			Status:      clustersdkv1alpha1.ToApply,
+		}
+		if specHash, ok := specHashes[clusterName]; !ok || (len(works) != 0 && upToDate(works, specHash)) {
+			status, err = d.statusFunc(clusterName, works)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to get rollout status of cluster %s: %v", clusterName, err))
+				continue
+			}
+			status.ClusterName = clusterName
+			status.GroupKey = clusterToGroupKey[clusterName]
+		}
+		existingStatus = append(existingStatus, status)
+	}
+
+	rolloutHandler, err := clustersdkv1alpha1.NewRolloutHandler(tracker, d.statusFunc)
+	if err != nil {
+		return clustersdkv1alpha1.RolloutResult{}, err
+	}
+	_, rolloutResult, err := rolloutHandler.GetRolloutCluster(rolloutStrategy, existingStatus)
+	if err != nil {
+		return rolloutResult, err
+	}
+
+	for _, status := range rolloutResult.ClustersToRollout {
+		clusterObjects, ok := objects[status.ClusterName]
+		if !ok {
+			continue
+		}
+		if err := d.apply(ctx, status.ClusterName, clusterObjects, specHashes[status.ClusterName],
+			existingWorks[status.ClusterName]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to apply works to cluster %s: %v", status.ClusterName, err))
+		}
+	}
+
+	for _, status := range rolloutResult.ClustersRemoved {
+		for _, work := range existingWorks[status.ClusterName] {
+			if err := d.workApplier.Delete(ctx, work.Namespace, work.Name); err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete work %s/%s: %v", work.Namespace, work.Name, err))
+			}
+		}
+	}
+
+	return rolloutResult, errors.NewAggregate(errs)
+}
+
+func (d *Deployer) apply(ctx context.Context, clusterName string, objects []runtime.Object, specHash string,
+	existingWorks []*workapiv1.ManifestWork) error {
+	annotations := map[string]string{}
+	for key, value := range d.annotations {
+		annotations[key] = value
+	}
+	annotations[SpecHashAnnotationKey] = specHash
+
+	works := make([]workapiv1.ManifestWork, 0, len(existingWorks))
+	for _, work := range existingWorks {
+		works = append(works, *work)
+	}
+
+	options := append([]builder.WorkBuilderOption{}, d.builderOptions...)
+	options = append(options,
+		builder.ExistingManifestWorksOption(works),
+		builder.ManifestAnnotations(annotations),
+	)
+	appliedWorks, deletedWorks, err := d.workBuilder.Build(objects, d.workObjectMeta(clusterName), options...)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, work := range appliedWorks {
+		if _, err := d.workApplier.Apply(ctx, work); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, work := range deletedWorks {
+		if err := d.workApplier.Delete(ctx, work.Namespace, work.Name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+func (d *Deployer) workObjectMeta(clusterName string) builder.GenerateManifestWorkObjectMeta {
+	return func(index int) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", d.name, index),
+			Namespace: clusterName,
+			Labels:    map[string]string{DeployerLabelKey: d.name},
+		}
+	}
+}
+
+// existingWorks returns the manifestWorks of the deployer grouped by the cluster name.
+func (d *Deployer) existingWorks() (map[string][]*workapiv1.ManifestWork, error) {
+	works, err := d.workLister.List(labels.SelectorFromSet(labels.Set{DeployerLabelKey: d.name}))
+	if err != nil {
+		return nil, err
+	}
+
+	clusterWorks := map[string][]*workapiv1.ManifestWork{}
+	for _, work := range works {
+		clusterWorks[work.Namespace] = append(clusterWorks[work.Namespace], work)
+	}
+	for _, works := range clusterWorks {
+		sort.Slice(works, func(i, j int) bool {
+			return works[i].Name < works[j].Name
+		})
+	}
+	return clusterWorks, nil
+}
+
+// upToDate returns true if all the manifestWorks are built with the objects of the spec hash.
+func upToDate(works []*workapiv1.ManifestWork, specHash string) bool {
+	for _, work := range works {
+		if work.Annotations[SpecHashAnnotationKey] != specHash {
+			return false
+		}
+	}
+	return true
+}
+
+func hashObjects(objects []runtime.Object) (string, error) {
+	h := fnv.New64a()
+	for _, object := range objects {
+		data, err := json.Marshal(object)
+		if err != nil {
+			return "", err
+		}
+		_, _ = h.Write(data)
+		_, _ = h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%016x", h.Sum64()), nil
+}
@@ -0,0 +1,239 @@
+package deployer
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	fakework "open-cluster-management.io/api/client/work/clientset/versioned/fake"
+	workinformers "open-cluster-management.io/api/client/work/informers/externalversions"
+	clusterv1alpha1 "open-cluster-management.io/api/cluster/v1alpha1"
+	clusterv1beta1 "open-cluster-management.io/api/cluster/v1beta1"
+	workapiv1 "open-cluster-management.io/api/work/v1"
+
+	clustersdkv1alpha1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1alpha1"
+	"open-cluster-management.io/sdk-go/pkg/apis/work/v1/applier"
+)
+
+type fakePlacementDecisionGetter struct {
+	decisions []*clusterv1beta1.PlacementDecision
+}
+
+func (f *fakePlacementDecisionGetter) List(selector labels.Selector, namespace string) ([]*clusterv1beta1.PlacementDecision, error) {
+	return f.decisions, nil
+}
+
+func newFakePlacementDecision(placementName string, groupIndex int, clusterNames ...string) *clusterv1beta1.PlacementDecision {
+	decisions := make([]clusterv1beta1.ClusterDecision, len(clusterNames))
+	for i, clusterName := range clusterNames {
+		decisions[i] = clusterv1beta1.ClusterDecision{ClusterName: clusterName}
+	}
+
+	return &clusterv1beta1.PlacementDecision{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				clusterv1beta1.PlacementLabel:          placementName,
+				clusterv1beta1.DecisionGroupIndexLabel: strconv.Itoa(groupIndex),
+			},
+		},
+		Status: clusterv1beta1.PlacementDecisionStatus{Decisions: decisions},
+	}
+}
+
+// testHarness runs a deployer against a fake work client, the work store of the lister is synced from the fake
+// client after each sync to simulate the informer.
+type testHarness struct {
+	workClient *fakework.Clientset
+	workStore  cache.Store
+	decisions  *fakePlacementDecisionGetter
+	deployer   *Deployer
+	// actions are the actions of the fake work client in the last sync
+	actions []clienttesting.Action
+
+	placement *clusterv1beta1.Placement
+	version   string
+	statuses  map[string]clustersdkv1alpha1.ClusterRolloutStatus
+}
+
+func newTestHarness(clusterNames ...string) *testHarness {
+	workClient := fakework.NewSimpleClientset()
+	workInformerFactory := workinformers.NewSharedInformerFactory(workClient, 10*time.Minute)
+	workInformer := workInformerFactory.Work().V1().ManifestWorks()
+
+	h := &testHarness{
+		workClient: workClient,
+		workStore:  workInformer.Informer().GetStore(),
+		decisions: &fakePlacementDecisionGetter{
+			decisions: []*clusterv1beta1.PlacementDecision{newFakePlacementDecision("test", 0, clusterNames...)},
+		},
+		placement: &clusterv1beta1.Placement{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}},
+		version:   "v1",
+		statuses:  map[string]clustersdkv1alpha1.ClusterRolloutStatus{},
+	}
+
+	objectsFunc := func(clusterName string) ([]runtime.Object, error) {
+		return []runtime.Object{
+			&corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+				Data:       map[string]string{"cluster": clusterName, "version": h.version},
+			},
+		}, nil
+	}
+	statusFunc := func(clusterName string, works []*workapiv1.ManifestWork) (clustersdkv1alpha1.ClusterRolloutStatus, error) {
+		if status, ok := h.statuses[clusterName]; ok {
+			return status, nil
+		}
+		return clustersdkv1alpha1.ClusterRolloutStatus{Status: clustersdkv1alpha1.Progressing}, nil
+	}
+
+	h.deployer = NewDeployer("test",
+		applier.NewWorkApplierWithTypedClient(workClient, workInformer.Lister()),
+		workInformer.Lister(), h.decisions, objectsFunc, statusFunc)
+	return h
+}
+
+func (h *testHarness) sync(t *testing.T, rolloutStrategy clusterv1alpha1.RolloutStrategy) clustersdkv1alpha1.RolloutResult {
+	h.workClient.ClearActions()
+	result, err := h.deployer.Sync(context.TODO(), h.placement, rolloutStrategy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h.actions = h.workClient.Actions()
+
+	works, err := h.workClient.WorkV1().ManifestWorks(metav1.NamespaceAll).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objs := []interface{}{}
+	for i := range works.Items {
+		objs = append(objs, &works.Items[i])
+	}
+	if err := h.workStore.Replace(objs, ""); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func (h *testHarness) succeed(clusterNames ...string) {
+	for _, clusterName := range clusterNames {
+		h.statuses[clusterName] = clustersdkv1alpha1.ClusterRolloutStatus{Status: clustersdkv1alpha1.Succeeded}
+	}
+}
+
+// changedClusters returns the namespaces of the works changed by the verb.
+func (h *testHarness) changedClusters(verb string) sets.Set[string] {
+	clusters := sets.New[string]()
+	for _, action := range h.actions {
+		if action.GetVerb() == verb {
+			clusters.Insert(action.GetNamespace())
+		}
+	}
+	return clusters
+}
+
+func rolloutClusters(statuses []clustersdkv1alpha1.ClusterRolloutStatus) sets.Set[string] {
+	clusters := sets.New[string]()
+	for _, status := range statuses {
+		clusters.Insert(status.ClusterName)
+	}
+	return clusters
+}
+
+func progressive(maxConcurrency int32, progressDeadline string) clusterv1alpha1.RolloutStrategy {
+	return clusterv1alpha1.RolloutStrategy{
+		Type: clusterv1alpha1.Progressive,
+		Progressive: &clusterv1alpha1.RolloutProgressive{
+			RolloutConfig:  clusterv1alpha1.RolloutConfig{ProgressDeadline: progressDeadline},
+			MaxConcurrency: intstr.FromInt32(maxConcurrency),
+		},
+	}
+}
+
+func TestDeployerSync(t *testing.T) {
+	h := newTestHarness("cluster1", "cluster2", "cluster3")
+
+	// the works are created on the first two clusters
+	result := h.sync(t, progressive(2, ""))
+	assert.Equal(t, sets.New("cluster1", "cluster2"), rolloutClusters(result.ClustersToRollout))
+	assert.Equal(t, sets.New("cluster1", "cluster2"), h.changedClusters("create"))
+
+	work, err := h.workClient.WorkV1().ManifestWorks("cluster1").Get(context.TODO(), "test-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "test", work.Labels[DeployerLabelKey])
+	assert.NotEmpty(t, work.Annotations[SpecHashAnnotationKey])
+
+	// the rollout continues to the last cluster once the first two clusters succeed
+	h.succeed("cluster1", "cluster2")
+	result = h.sync(t, progressive(2, ""))
+	assert.Equal(t, sets.New("cluster3"), rolloutClusters(result.ClustersToRollout))
+	assert.Equal(t, sets.New("cluster3"), h.changedClusters("create"))
+
+	// nothing is changed once all the clusters succeed
+	h.succeed("cluster3")
+	result = h.sync(t, progressive(2, ""))
+	assert.Empty(t, result.ClustersToRollout)
+	assert.Empty(t, h.actions)
+
+	// the changed objects are rolled out again from the first cluster
+	h.version = "v2"
+	result = h.sync(t, progressive(1, "10m"))
+	assert.Equal(t, sets.New("cluster1"), rolloutClusters(result.ClustersToRollout))
+	assert.Equal(t, sets.New("cluster1"), h.changedClusters("patch"))
+
+	// the rollout waits for the progressing cluster and rechecks it before the deadline
+	h.statuses["cluster1"] = clustersdkv1alpha1.ClusterRolloutStatus{
+		Status:             clustersdkv1alpha1.Progressing,
+		LastTransitionTime: &metav1.Time{Time: time.Now()},
+	}
+	result = h.sync(t, progressive(1, "10m"))
+	assert.Equal(t, sets.New("cluster1"), rolloutClusters(result.ClustersToRollout))
+	assert.Empty(t, h.actions)
+	if assert.NotNil(t, result.RecheckAfter) {
+		assert.LessOrEqual(t, *result.RecheckAfter, 10*time.Minute)
+	}
+
+	// the works of the removed cluster are deleted
+	h.decisions.decisions = []*clusterv1beta1.PlacementDecision{newFakePlacementDecision("test", 0, "cluster1", "cluster2")}
+	result = h.sync(t, progressive(1, "10m"))
+	assert.Equal(t, sets.New("cluster3"), rolloutClusters(result.ClustersRemoved))
+	assert.Equal(t, sets.New("cluster3"), h.changedClusters("delete"))
+}
+
+func TestHashObjects(t *testing.T) {
+	newConfigMap := func(value string) runtime.Object {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Data:       map[string]string{"key": value},
+		}
+	}
+
+	hash1, err := hashObjects([]runtime.Object{newConfigMap("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash2, err := hashObjects([]runtime.Object{newConfigMap("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash3, err := hashObjects([]runtime.Object{newConfigMap("b")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, hash1, hash2)
+	assert.NotEqual(t, hash1, hash3)
+}
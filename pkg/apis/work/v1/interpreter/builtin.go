@@ -0,0 +1,97 @@
+package interpreter
+
+// builtinRules decide the health of the common kinds from the well known status feedback values, the rules are
+// not matched if the status feedback values are not synced, and the health is decided by the conditions.
+//
+// A Deployment is healthy only if its rollout is complete, so besides the well known status feedback values, the
+// UpdatedReplicas, ObservedGeneration and Generation feedback values are required, the Deployment is progressing
+// until they are synced.
+var builtinRules = []HealthRule{
+	{
+		Name:   "DeploymentAvailable",
+		Group:  "apps",
+		Kind:   "Deployment",
+		Health: Healthy,
+		Expression: "has(feedback.ObservedGeneration) && has(feedback.Generation) && " +
+			"feedback.ObservedGeneration == feedback.Generation && " + replicasReady("AvailableReplicas") + " && " +
+			"(feedback.Replicas == 0 || (has(feedback.UpdatedReplicas) && feedback.UpdatedReplicas == feedback.Replicas))",
+	},
+	{
+		Name:       "DeploymentProgressing",
+		Group:      "apps",
+		Kind:       "Deployment",
+		Health:     Progressing,
+		Expression: "has(feedback.Replicas)",
+	},
+	{
+		Name:       "StatefulSetReady",
+		Group:      "apps",
+		Kind:       "StatefulSet",
+		Health:     Healthy,
+		Expression: replicasReady("ReadyReplicas"),
+	},
+	{
+		Name:       "StatefulSetProgressing",
+		Group:      "apps",
+		Kind:       "StatefulSet",
+		Health:     Progressing,
+		Expression: "has(feedback.Replicas)",
+	},
+	{
+		Name:   "DaemonSetReady",
+		Group:  "apps",
+		Kind:   "DaemonSet",
+		Health: Healthy,
+		Expression: "has(feedback.DesiredNumberScheduled) && (feedback.DesiredNumberScheduled == 0 || " +
+			"(has(feedback.NumberReady) && feedback.NumberReady >= feedback.DesiredNumberScheduled))",
+	},
+	{
+		Name:       "DaemonSetProgressing",
+		Group:      "apps",
+		Kind:       "DaemonSet",
+		Health:     Progressing,
+		Expression: "has(feedback.DesiredNumberScheduled)",
+	},
+	{
+		Name:       "JobComplete",
+		Group:      "batch",
+		Kind:       "Job",
+		Health:     Healthy,
+		Expression: `has(feedback.JobComplete) && feedback.JobComplete == "True"`,
+	},
+	{
+		Name:       "JobFailed",
+		Group:      "batch",
+		Kind:       "Job",
+		Health:     Degraded,
+		Expression: `has(feedback.JobFailed) && feedback.JobFailed == "True"`,
+	},
+	{
+		Name:       "JobRunning",
+		Group:      "batch",
+		Kind:       "Job",
+		Health:     Progressing,
+		Expression: `has(conditions.Available) && conditions.Available == "True"`,
+	},
+	{
+		Name:       "CustomResourceDefinitionEstablished",
+		Group:      "apiextensions.k8s.io",
+		Kind:       "CustomResourceDefinition",
+		Health:     Healthy,
+		Expression: `has(feedback.Established) && feedback.Established == "True"`,
+	},
+	{
+		Name:       "CustomResourceDefinitionNotEstablished",
+		Group:      "apiextensions.k8s.io",
+		Kind:       "CustomResourceDefinition",
+		Health:     Progressing,
+		Expression: "has(feedback.Established)",
+	},
+}
+
+// replicasReady returns the expression that checks the replicas are all ready, the ready replicas are not
+// reported if there is no ready replica.
+func replicasReady(readyReplicas string) string {
+	return "has(feedback.Replicas) && (feedback.Replicas == 0 || (has(feedback." + readyReplicas +
+		") && feedback." + readyReplicas + " >= feedback.Replicas))"
+}
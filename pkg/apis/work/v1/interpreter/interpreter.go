@@ -0,0 +1,287 @@
+package interpreter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cel/library"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+)
+
+// The CEL variables of the health rule expressions.
+const (
+	// ResourceVarName is the resource meta, it is a map with the keys group, version, kind, resource, name and
+	// namespace.
+	ResourceVarName = "resource"
+	// ConditionsVarName is the map of the resource condition types to their statuses, e.g.
+	// conditions.Available == "True".
+	ConditionsVarName = "conditions"
+	// FeedbackVarName is the map of the status feedback names to their values, the integer, string and boolean
+	// values are converted to CEL values, and the JSON raw values are parsed.
+	FeedbackVarName = "feedback"
+)
+
+// Health is the health verdict of a resource or a work.
+type Health string
+
+const (
+	// Healthy means the resource is applied and reaches its desired state.
+	Healthy Health = "Healthy"
+	// Progressing means the resource is applied and is moving to its desired state.
+	Progressing Health = "Progressing"
+	// Degraded means the resource cannot be applied or fails to reach its desired state.
+	Degraded Health = "Degraded"
+	// Unknown means the status of the resource is not reported yet.
+	Unknown Health = "Unknown"
+)
+
+// severity orders the health verdicts from the best to the worst for the aggregation.
+var severity = map[Health]int{
+	Healthy:     0,
+	Unknown:     1,
+	Progressing: 2,
+	Degraded:    3,
+}
+
+// HealthRule decides the health of the matched resources, the rule is matched if its expression evaluates to
+// true.
+type HealthRule struct {
+	// Name identifies the rule, it is the reason of the matched resource health.
+	Name string `json:"name"`
+	// Group and Kind select the resources that the rule applies to, the rule applies to all the resources if the
+	// kind is empty.
+	Group string `json:"group,omitempty"`
+	Kind  string `json:"kind,omitempty"`
+	// Health is the health of the resource once the rule is matched.
+	Health Health `json:"health"`
+	// Expression is a CEL expression which evaluates to a bool.
+	Expression string `json:"expression"`
+}
+
+// ResourceHealth is the health verdict of a resource in a work.
+type ResourceHealth struct {
+	ResourceMeta workv1.ManifestResourceMeta
+	Health       Health
+	// Reason is the name of the rule or the condition that decides the health.
+	Reason  string
+	Message string
+}
+
+// WorkHealth is the aggregated health verdict of the resources in a work, it is the worst health of the resources.
+type WorkHealth struct {
+	Health Health
+	// Reason is the reason of the first resource that has the worst health.
+	Reason string
+	// Message lists the resources that have the worst health.
+	Message   string
+	Resources []ResourceHealth
+	// LastTransitionTime is the latest transition time of the work conditions.
+	LastTransitionTime *metav1.Time
+}
+
+type compiledRule struct {
+	HealthRule
+	program cel.Program
+}
+
+// StatusInterpreter interprets the health of the resources from their conditions and status feedback values.
+//
+// The health of a resource is decided in order by:
+//  1. Unknown if the resource has no conditions, or Degraded if the resource is not applied.
+//  2. the user rules that apply to the resource, the first matched rule decides the health.
+//  3. the built-in rules of the Deployment, StatefulSet, DaemonSet, Job and CustomResourceDefinition, they are
+//     based on the well known status feedback values.
+//  4. the Degraded and Available conditions of the resource.
+type StatusInterpreter struct {
+	rules []compiledRule
+}
+
+// NewStatusInterpreter compiles the user rules together with the built-in rules.
+func NewStatusInterpreter(rules ...HealthRule) (*StatusInterpreter, error) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		cel.Variable(ResourceVarName, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(ConditionsVarName, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(FeedbackVarName, cel.MapType(cel.StringType, cel.DynType)),
+		library.JsonLib(),
+	}, common.BaseEnvOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	interpreter := &StatusInterpreter{}
+	for _, rule := range append(rules, builtinRules...) {
+		if len(rule.Name) == 0 {
+			return nil, fmt.Errorf("the name of health rule is required")
+		}
+		if _, ok := severity[rule.Health]; !ok {
+			return nil, fmt.Errorf("unsupported health %q of rule %s", rule.Health, rule.Name)
+		}
+
+		program, err := common.CompileProgram(env, rule.Expression, &library.CostEstimator{}, common.DefaultCostLimit, types.BoolType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %v", rule.Name, err)
+		}
+
+		interpreter.rules = append(interpreter.rules, compiledRule{HealthRule: rule, program: program})
+	}
+	return interpreter, nil
+}
+
+// InterpretWork returns the health of the resources in the status of a manifestwork.
+func (i *StatusInterpreter) InterpretWork(work *workv1.ManifestWork) WorkHealth {
+	return i.interpret(work.Status.Conditions, work.Status.ResourceStatus.Manifests)
+}
+
+// InterpretManifestBundleStatus returns the health of the resources in the status of a manifestbundle.
+func (i *StatusInterpreter) InterpretManifestBundleStatus(status *payload.ManifestBundleStatus) WorkHealth {
+	return i.interpret(status.Conditions, status.ResourceStatus)
+}
+
+// InterpretResource returns the health of a resource.
+func (i *StatusInterpreter) InterpretResource(manifest workv1.ManifestCondition) ResourceHealth {
+	health := ResourceHealth{ResourceMeta: manifest.ResourceMeta}
+
+	if len(manifest.Conditions) == 0 {
+		health.Health, health.Reason = Unknown, "NoStatus"
+		return health
+	}
+
+	if applied := meta.FindStatusCondition(manifest.Conditions, workv1.ManifestApplied); applied != nil &&
+		applied.Status == metav1.ConditionFalse {
+		health.Health, health.Reason, health.Message = Degraded, "NotApplied", applied.Message
+		return health
+	}
+
+	vars := map[string]interface{}{
+		ResourceVarName: map[string]string{
+			"group":     manifest.ResourceMeta.Group,
+			"version":   manifest.ResourceMeta.Version,
+			"kind":      manifest.ResourceMeta.Kind,
+			"resource":  manifest.ResourceMeta.Resource,
+			"name":      manifest.ResourceMeta.Name,
+			"namespace": manifest.ResourceMeta.Namespace,
+		},
+		ConditionsVarName: conditionStatuses(manifest.Conditions),
+		FeedbackVarName:   feedbackValues(manifest.StatusFeedbacks),
+	}
+	for _, rule := range i.rules {
+		if len(rule.Kind) != 0 && (rule.Group != manifest.ResourceMeta.Group || rule.Kind != manifest.ResourceMeta.Kind) {
+			continue
+		}
+
+		result, _, err := rule.program.Eval(vars)
+		if err != nil {
+			health.Health, health.Reason = Unknown, rule.Name
+			health.Message = fmt.Sprintf("failed to evaluate rule %s: %v", rule.Name, err)
+			return health
+		}
+		if matched, ok := result.Value().(bool); ok && matched {
+			health.Health, health.Reason = rule.Health, rule.Name
+			return health
+		}
+	}
+
+	if degraded := meta.FindStatusCondition(manifest.Conditions, workv1.ManifestDegraded); degraded != nil &&
+		degraded.Status == metav1.ConditionTrue {
+		health.Health, health.Reason, health.Message = Degraded, workv1.ManifestDegraded, degraded.Message
+		return health
+	}
+
+	available := meta.FindStatusCondition(manifest.Conditions, workv1.ManifestAvailable)
+	switch {
+	case available == nil:
+		health.Health, health.Reason = Unknown, "NoAvailableCondition"
+	case available.Status == metav1.ConditionTrue:
+		health.Health, health.Reason = Healthy, workv1.ManifestAvailable
+	default:
+		health.Health, health.Reason, health.Message = Progressing, "NotAvailable", available.Message
+	}
+	return health
+}
+
+func (i *StatusInterpreter) interpret(conditions []metav1.Condition, manifests []workv1.ManifestCondition) WorkHealth {
+	health := WorkHealth{LastTransitionTime: lastTransitionTime(conditions)}
+	if len(manifests) == 0 {
+		health.Health, health.Reason = Unknown, "NoResourceStatus"
+		return health
+	}
+
+	var worst []string
+	for _, manifest := range manifests {
+		resourceHealth := i.InterpretResource(manifest)
+		health.Resources = append(health.Resources, resourceHealth)
+
+		if len(worst) == 0 || severity[resourceHealth.Health] > severity[health.Health] {
+			health.Health, health.Reason = resourceHealth.Health, resourceHealth.Reason
+			worst = nil
+		}
+		if resourceHealth.Health == health.Health {
+			worst = append(worst, resourceKey(resourceHealth.ResourceMeta))
+		}
+	}
+
+	if health.Health != Healthy {
+		health.Message = fmt.Sprintf("%d of %d resources are %s: %s",
+			len(worst), len(manifests), health.Health, strings.Join(worst, ", "))
+	}
+	return health
+}
+
+func conditionStatuses(conditions []metav1.Condition) map[string]string {
+	statuses := make(map[string]string, len(conditions))
+	for _, condition := range conditions {
+		statuses[condition.Type] = string(condition.Status)
+	}
+	return statuses
+}
+
+func feedbackValues(feedback workv1.StatusFeedbackResult) map[string]interface{} {
+	values := make(map[string]interface{}, len(feedback.Values))
+	for _, value := range feedback.Values {
+		switch {
+		case value.Value.Integer != nil:
+			values[value.Name] = *value.Value.Integer
+		case value.Value.String != nil:
+			values[value.Name] = *value.Value.String
+		case value.Value.Boolean != nil:
+			values[value.Name] = *value.Value.Boolean
+		case value.Value.JsonRaw != nil:
+			var raw interface{}
+			if err := json.Unmarshal([]byte(*value.Value.JsonRaw), &raw); err != nil {
+				continue
+			}
+			values[value.Name] = raw
+		}
+	}
+	return values
+}
+
+func lastTransitionTime(conditions []metav1.Condition) *metav1.Time {
+	var last *metav1.Time
+	for i := range conditions {
+		if last == nil || conditions[i].LastTransitionTime.After(last.Time) {
+			last = &conditions[i].LastTransitionTime
+		}
+	}
+	return last
+}
+
+func resourceKey(resourceMeta workv1.ManifestResourceMeta) string {
+	kind := resourceMeta.Kind
+	if len(resourceMeta.Group) != 0 {
+		kind = kind + "." + resourceMeta.Group
+	}
+	if len(resourceMeta.Namespace) == 0 {
+		return fmt.Sprintf("%s %s", kind, resourceMeta.Name)
+	}
+	return fmt.Sprintf("%s %s/%s", kind, resourceMeta.Namespace, resourceMeta.Name)
+}
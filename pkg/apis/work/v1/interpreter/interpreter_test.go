@@ -0,0 +1,376 @@
+package interpreter
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	clustersdkv1alpha1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1alpha1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+)
+
+func newCondition(conditionType string, status metav1.ConditionStatus) metav1.Condition {
+	return metav1.Condition{Type: conditionType, Status: status}
+}
+
+func newIntegerFeedback(name string, value int64) workv1.FeedbackValue {
+	return workv1.FeedbackValue{Name: name, Value: workv1.FieldValue{Type: workv1.Integer, Integer: ptr.To(value)}}
+}
+
+func newStringFeedback(name string, value string) workv1.FeedbackValue {
+	return workv1.FeedbackValue{Name: name, Value: workv1.FieldValue{Type: workv1.String, String: ptr.To(value)}}
+}
+
+func newManifestCondition(group, kind, name string, feedback []workv1.FeedbackValue,
+	conditions ...metav1.Condition) workv1.ManifestCondition {
+	return workv1.ManifestCondition{
+		ResourceMeta:    workv1.ManifestResourceMeta{Group: group, Version: "v1", Kind: kind, Name: name, Namespace: "default"},
+		StatusFeedbacks: workv1.StatusFeedbackResult{Values: feedback},
+		Conditions:      conditions,
+	}
+}
+
+func TestInterpretResource(t *testing.T) {
+	interpreter, err := NewStatusInterpreter(HealthRule{
+		Name:       "ConfigMapSynced",
+		Kind:       "ConfigMap",
+		Health:     Healthy,
+		Expression: `has(feedback.synced) && feedback.synced.all(s, s == "yes")`,
+	}, HealthRule{
+		Name:       "PausedDeployment",
+		Group:      "apps",
+		Kind:       "Deployment",
+		Health:     Degraded,
+		Expression: `has(feedback.Paused) && feedback.Paused`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied := newCondition(workv1.ManifestApplied, metav1.ConditionTrue)
+	available := newCondition(workv1.ManifestAvailable, metav1.ConditionTrue)
+	cases := []struct {
+		name           string
+		manifest       workv1.ManifestCondition
+		expectedHealth Health
+		expectedReason string
+	}{
+		{
+			name:           "no conditions",
+			manifest:       newManifestCondition("apps", "Deployment", "test", nil),
+			expectedHealth: Unknown,
+			expectedReason: "NoStatus",
+		},
+		{
+			name: "not applied",
+			manifest: newManifestCondition("apps", "Deployment", "test", nil,
+				newCondition(workv1.ManifestApplied, metav1.ConditionFalse)),
+			expectedHealth: Degraded,
+			expectedReason: "NotApplied",
+		},
+		{
+			name: "deployment available",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 2), newIntegerFeedback("AvailableReplicas", 2),
+				newIntegerFeedback("UpdatedReplicas", 2), newIntegerFeedback("ObservedGeneration", 3),
+				newIntegerFeedback("Generation", 3),
+			}, applied, available),
+			expectedHealth: Healthy,
+			expectedReason: "DeploymentAvailable",
+		},
+		{
+			name: "deployment scaled to zero",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 0), newIntegerFeedback("ObservedGeneration", 3),
+				newIntegerFeedback("Generation", 3),
+			}, applied, available),
+			expectedHealth: Healthy,
+			expectedReason: "DeploymentAvailable",
+		},
+		{
+			name: "deployment old replicas available",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 2), newIntegerFeedback("AvailableReplicas", 2),
+				newIntegerFeedback("UpdatedReplicas", 1), newIntegerFeedback("ObservedGeneration", 3),
+				newIntegerFeedback("Generation", 3),
+			}, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "DeploymentProgressing",
+		},
+		{
+			name: "deployment generation not observed",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 2), newIntegerFeedback("AvailableReplicas", 2),
+				newIntegerFeedback("UpdatedReplicas", 2), newIntegerFeedback("ObservedGeneration", 2),
+				newIntegerFeedback("Generation", 3),
+			}, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "DeploymentProgressing",
+		},
+		{
+			name: "deployment without rollout feedback",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 2), newIntegerFeedback("AvailableReplicas", 2),
+			}, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "DeploymentProgressing",
+		},
+		{
+			name: "deployment progressing",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 2), newIntegerFeedback("AvailableReplicas", 1),
+			}, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "DeploymentProgressing",
+		},
+		{
+			name: "user rule takes precedence",
+			manifest: newManifestCondition("apps", "Deployment", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 0),
+				{Name: "Paused", Value: workv1.FieldValue{Type: workv1.Boolean, Boolean: ptr.To(true)}},
+			}, applied, available),
+			expectedHealth: Degraded,
+			expectedReason: "PausedDeployment",
+		},
+		{
+			name: "statefulset without ready replicas",
+			manifest: newManifestCondition("apps", "StatefulSet", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("Replicas", 1),
+			}, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "StatefulSetProgressing",
+		},
+		{
+			name: "daemonset ready",
+			manifest: newManifestCondition("apps", "DaemonSet", "test", []workv1.FeedbackValue{
+				newIntegerFeedback("DesiredNumberScheduled", 3), newIntegerFeedback("NumberReady", 3),
+			}, applied, available),
+			expectedHealth: Healthy,
+			expectedReason: "DaemonSetReady",
+		},
+		{
+			name: "job failed",
+			manifest: newManifestCondition("batch", "Job", "test", []workv1.FeedbackValue{
+				newStringFeedback("JobFailed", "True"),
+			}, applied, available),
+			expectedHealth: Degraded,
+			expectedReason: "JobFailed",
+		},
+		{
+			name:           "job running",
+			manifest:       newManifestCondition("batch", "Job", "test", nil, applied, available),
+			expectedHealth: Progressing,
+			expectedReason: "JobRunning",
+		},
+		{
+			name: "crd established",
+			manifest: newManifestCondition("apiextensions.k8s.io", "CustomResourceDefinition", "test", []workv1.FeedbackValue{
+				newStringFeedback("Established", "True"),
+			}, applied, available),
+			expectedHealth: Healthy,
+			expectedReason: "CustomResourceDefinitionEstablished",
+		},
+		{
+			name: "user rule with json feedback",
+			manifest: newManifestCondition("", "ConfigMap", "test", []workv1.FeedbackValue{
+				{Name: "synced", Value: workv1.FieldValue{Type: workv1.JsonRaw, JsonRaw: ptr.To(`["yes","yes"]`)}},
+			}, applied, newCondition(workv1.ManifestAvailable, metav1.ConditionFalse)),
+			expectedHealth: Healthy,
+			expectedReason: "ConfigMapSynced",
+		},
+		{
+			name: "degraded condition",
+			manifest: newManifestCondition("", "Secret", "test", nil,
+				applied, available, newCondition(workv1.ManifestDegraded, metav1.ConditionTrue)),
+			expectedHealth: Degraded,
+			expectedReason: workv1.ManifestDegraded,
+		},
+		{
+			name:           "available without feedback",
+			manifest:       newManifestCondition("apps", "Deployment", "test", nil, applied, available),
+			expectedHealth: Healthy,
+			expectedReason: workv1.ManifestAvailable,
+		},
+		{
+			name: "not available",
+			manifest: newManifestCondition("", "Secret", "test", nil,
+				applied, newCondition(workv1.ManifestAvailable, metav1.ConditionFalse)),
+			expectedHealth: Progressing,
+			expectedReason: "NotAvailable",
+		},
+		{
+			name:           "no available condition",
+			manifest:       newManifestCondition("", "Secret", "test", nil, applied),
+			expectedHealth: Unknown,
+			expectedReason: "NoAvailableCondition",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			health := interpreter.InterpretResource(c.manifest)
+			if health.Health != c.expectedHealth || health.Reason != c.expectedReason {
+				t.Errorf("expected %s/%s, but got %s/%s: %s",
+					c.expectedHealth, c.expectedReason, health.Health, health.Reason, health.Message)
+			}
+		})
+	}
+}
+
+func TestInterpretWork(t *testing.T) {
+	interpreter, err := NewStatusInterpreter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	applied := newCondition(workv1.ManifestApplied, metav1.ConditionTrue)
+	available := newCondition(workv1.ManifestAvailable, metav1.ConditionTrue)
+	notAvailable := newCondition(workv1.ManifestAvailable, metav1.ConditionFalse)
+	transitionTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	workApplied := metav1.Condition{
+		Type: workv1.WorkApplied, Status: metav1.ConditionTrue, ObservedGeneration: 1, LastTransitionTime: transitionTime,
+	}
+
+	newWork := func(manifests ...workv1.ManifestCondition) *workv1.ManifestWork {
+		work := &workv1.ManifestWork{}
+		work.Generation = 1
+		work.Status.Conditions = []metav1.Condition{workApplied}
+		work.Status.ResourceStatus.Manifests = manifests
+		return work
+	}
+
+	cases := []struct {
+		name            string
+		work            *workv1.ManifestWork
+		expectedHealth  Health
+		expectedMessage string
+		expectedRollout clustersdkv1alpha1.RolloutStatus
+	}{
+		{
+			name:            "no work",
+			expectedRollout: clustersdkv1alpha1.ToApply,
+		},
+		{
+			name:            "no resource status",
+			work:            newWork(),
+			expectedHealth:  Unknown,
+			expectedRollout: clustersdkv1alpha1.Progressing,
+		},
+		{
+			name: "healthy",
+			work: newWork(
+				newManifestCondition("", "Secret", "s1", nil, applied, available),
+				newManifestCondition("", "Secret", "s2", nil, applied, available),
+			),
+			expectedHealth:  Healthy,
+			expectedRollout: clustersdkv1alpha1.Succeeded,
+		},
+		{
+			name: "progressing",
+			work: newWork(
+				newManifestCondition("", "Secret", "s1", nil, applied, notAvailable),
+				newManifestCondition("", "Secret", "s2", nil),
+				newManifestCondition("", "Secret", "s3", nil, applied, notAvailable),
+			),
+			expectedHealth:  Progressing,
+			expectedMessage: "2 of 3 resources are Progressing: Secret default/s1, Secret default/s3",
+			expectedRollout: clustersdkv1alpha1.Progressing,
+		},
+		{
+			name: "degraded",
+			work: newWork(
+				newManifestCondition("", "Secret", "s1", nil, applied, notAvailable),
+				newManifestCondition("apps", "Deployment", "d1", nil, newCondition(workv1.ManifestApplied, metav1.ConditionFalse)),
+			),
+			expectedHealth:  Degraded,
+			expectedMessage: "1 of 2 resources are Degraded: Deployment.apps default/d1",
+			expectedRollout: clustersdkv1alpha1.Failed,
+		},
+		{
+			name: "generation is not applied",
+			work: func() *workv1.ManifestWork {
+				work := newWork(newManifestCondition("", "Secret", "s1", nil, applied, available))
+				work.Generation = 2
+				return work
+			}(),
+			expectedHealth:  Healthy,
+			expectedRollout: clustersdkv1alpha1.Progressing,
+		},
+	}
+
+	statusFunc := interpreter.ClusterRolloutStatusFunc()
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.work != nil {
+				health := interpreter.InterpretWork(c.work)
+				if health.Health != c.expectedHealth || health.Message != c.expectedMessage {
+					t.Errorf("expected %s %q, but got %s %q", c.expectedHealth, c.expectedMessage, health.Health, health.Message)
+				}
+				if !health.LastTransitionTime.Equal(&transitionTime) {
+					t.Errorf("expected last transition time %v, but got %v", transitionTime, health.LastTransitionTime)
+				}
+			}
+
+			rolloutStatus, err := statusFunc("cluster1", c.work)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rolloutStatus.ClusterName != "cluster1" || rolloutStatus.Status != c.expectedRollout {
+				t.Errorf("expected rollout status %d, but got %v", c.expectedRollout, rolloutStatus)
+			}
+		})
+	}
+}
+
+func TestInterpretManifestBundleStatus(t *testing.T) {
+	interpreter, err := NewStatusInterpreter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	health := interpreter.InterpretManifestBundleStatus(&payload.ManifestBundleStatus{
+		ResourceStatus: []workv1.ManifestCondition{
+			newManifestCondition("", "Secret", "s1", nil, newCondition(workv1.ManifestApplied, metav1.ConditionTrue),
+				newCondition(workv1.ManifestAvailable, metav1.ConditionTrue)),
+		},
+	})
+	if health.Health != Healthy || len(health.Resources) != 1 {
+		t.Errorf("unexpected health %v", health)
+	}
+}
+
+func TestNewStatusInterpreterErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		rule HealthRule
+	}{
+		{
+			name: "no name",
+			rule: HealthRule{Health: Healthy, Expression: "true"},
+		},
+		{
+			name: "unsupported health",
+			rule: HealthRule{Name: "test", Health: "Good", Expression: "true"},
+		},
+		{
+			name: "invalid expression",
+			rule: HealthRule{Name: "test", Health: Healthy, Expression: "feedback.("},
+		},
+		{
+			name: "not a bool expression",
+			rule: HealthRule{Name: "test", Health: Healthy, Expression: "resource.kind"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewStatusInterpreter(c.rule); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package interpreter
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	clustersdkv1alpha1 "open-cluster-management.io/sdk-go/pkg/apis/cluster/v1alpha1"
+)
+
+// ClusterRolloutStatusFunc returns a ClusterRolloutStatusFunc that decides the rollout status of a cluster from
+// the health of its manifestwork. The Healthy work is Succeeded, the Degraded work is Failed, and the others are
+// Progressing. The work is Progressing as well if its latest generation is not applied yet.
+func (i *StatusInterpreter) ClusterRolloutStatusFunc() clustersdkv1alpha1.ClusterRolloutStatusFunc[*workv1.ManifestWork] {
+	return func(clusterName string, work *workv1.ManifestWork) (clustersdkv1alpha1.ClusterRolloutStatus, error) {
+		status := clustersdkv1alpha1.ClusterRolloutStatus{ClusterName: clusterName}
+		if work == nil {
+			status.Status = clustersdkv1alpha1.ToApply
+			return status, nil
+		}
+
+		health := i.InterpretWork(work)
+		status.LastTransitionTime = health.LastTransitionTime
+
+		applied := meta.FindStatusCondition(work.Status.Conditions, workv1.WorkApplied)
+		if applied != nil && applied.ObservedGeneration != work.Generation {
+			status.Status = clustersdkv1alpha1.Progressing
+			return status, nil
+		}
+
+		switch health.Health {
+		case Healthy:
+			status.Status = clustersdkv1alpha1.Succeeded
+		case Degraded:
+			status.Status = clustersdkv1alpha1.Failed
+		default:
+			status.Status = clustersdkv1alpha1.Progressing
+		}
+		return status, nil
+	}
+}
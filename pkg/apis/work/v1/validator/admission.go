@@ -0,0 +1,150 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cel/library"
+)
+
+// ObjectVarName is the CEL variable of the manifest in the admission rule expressions.
+const ObjectVarName = "object"
+
+// GroupVersionKind matches the manifests by the group, version and kind, an empty version matches all the versions.
+type GroupVersionKind struct {
+	Group   string `json:"group"`
+	Version string `json:"version,omitempty"`
+	Kind    string `json:"kind"`
+}
+
+// AdmissionRule is a CEL rule over a manifest, the manifest is rejected if the expression evaluates to false.
+type AdmissionRule struct {
+	// Name identifies the rule in the errors.
+	Name string `json:"name"`
+	// Expression is a CEL expression which evaluates to a bool, the manifest is the object variable.
+	Expression string `json:"expression"`
+	// Message is the error message when the manifest is rejected, defaults to the expression.
+	Message string `json:"message,omitempty"`
+}
+
+// AdmissionRules are the rules that the manifests must satisfy.
+type AdmissionRules struct {
+	// DeniedGVKs are the GVKs that are not allowed in the manifests.
+	DeniedGVKs []GroupVersionKind `json:"deniedGVKs,omitempty"`
+	// DeniedNamespaces are the namespaces that the manifests are not allowed to be in.
+	DeniedNamespaces []string `json:"deniedNamespaces,omitempty"`
+	// RequiredLabels are the label keys that every manifest must have.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	// Rules are the CEL rules that every manifest must satisfy.
+	Rules []AdmissionRule `json:"rules,omitempty"`
+	// CostLimit is the runtime cost limit of each rule evaluation, defaults to 1000000.
+	CostLimit uint64 `json:"costLimit,omitempty"`
+}
+
+type compiledAdmissionRule struct {
+	AdmissionRule
+	program cel.Program
+}
+
+// ManifestAdmission checks the manifests against the admission rules.
+type ManifestAdmission struct {
+	deniedGVKs       []GroupVersionKind
+	deniedNamespaces sets.Set[string]
+	requiredLabels   []string
+	rules            []compiledAdmissionRule
+}
+
+// LoadAdmissionRules loads the admission rules from a YAML or JSON file.
+func LoadAdmissionRules(path string) (*AdmissionRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &AdmissionRules{}
+	if err := yaml.UnmarshalStrict(data, rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal admission rules file %s: %v", path, err)
+	}
+	return rules, nil
+}
+
+// NewManifestAdmission compiles the admission rules.
+func NewManifestAdmission(rules *AdmissionRules) (*ManifestAdmission, error) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		cel.Variable(ObjectVarName, cel.MapType(cel.StringType, cel.DynType)),
+		library.JsonLib(),
+	}, common.BaseEnvOpts...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	admission := &ManifestAdmission{
+		deniedGVKs:       rules.DeniedGVKs,
+		deniedNamespaces: sets.New(rules.DeniedNamespaces...),
+		requiredLabels:   rules.RequiredLabels,
+	}
+	for i, rule := range rules.Rules {
+		if len(rule.Name) == 0 {
+			return nil, fmt.Errorf("the name of rule %d is required", i)
+		}
+
+		program, err := common.CompileProgram(env, rule.Expression, &library.CostEstimator{}, rules.CostLimit, types.BoolType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %v", rule.Name, err)
+		}
+
+		admission.rules = append(admission.rules, compiledAdmissionRule{AdmissionRule: rule, program: program})
+	}
+	return admission, nil
+}
+
+// Admit checks the manifest against the admission rules and returns all the violations.
+func (a *ManifestAdmission) Admit(fldPath *field.Path, obj *unstructured.Unstructured) field.ErrorList {
+	var errs field.ErrorList
+
+	gvk := obj.GroupVersionKind()
+	for _, denied := range a.deniedGVKs {
+		if denied.Group == gvk.Group && denied.Kind == gvk.Kind && (len(denied.Version) == 0 || denied.Version == gvk.Version) {
+			errs = append(errs, field.Forbidden(fldPath.Child("kind"), fmt.Sprintf("%s is not allowed", gvk)))
+			break
+		}
+	}
+
+	if namespace := obj.GetNamespace(); a.deniedNamespaces.Has(namespace) {
+		errs = append(errs, field.Forbidden(fldPath.Child("metadata", "namespace"),
+			fmt.Sprintf("namespace %s is not allowed", namespace)))
+	}
+
+	labels := obj.GetLabels()
+	for _, key := range a.requiredLabels {
+		if _, ok := labels[key]; !ok {
+			errs = append(errs, field.Required(fldPath.Child("metadata", "labels").Key(key), "the label is required"))
+		}
+	}
+
+	for _, rule := range a.rules {
+		result, _, err := rule.program.Eval(map[string]interface{}{ObjectVarName: obj.Object})
+		if err != nil {
+			errs = append(errs, field.InternalError(fldPath, fmt.Errorf("failed to evaluate rule %s: %v", rule.Name, err)))
+			continue
+		}
+		if allowed, ok := result.Value().(bool); ok && allowed {
+			continue
+		}
+
+		message := rule.Message
+		if len(message) == 0 {
+			message = fmt.Sprintf("failed rule: %s", rule.Expression)
+		}
+		errs = append(errs, field.Forbidden(fldPath, fmt.Sprintf("%s: %s", rule.Name, message)))
+	}
+	return errs
+}
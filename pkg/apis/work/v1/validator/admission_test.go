@@ -0,0 +1,130 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testAdmissionRules = `
+deniedGVKs:
+- group: rbac.authorization.k8s.io
+  kind: ClusterRoleBinding
+- group: ""
+  version: v1
+  kind: Secret
+deniedNamespaces:
+- kube-system
+requiredLabels:
+- app
+rules:
+- name: replicas
+  expression: "!has(object.spec) || !has(object.spec.replicas) || object.spec.replicas <= 3"
+  message: replicas must not be more than 3
+- name: image-registry
+  expression: "!has(object.spec) || !has(object.spec.image) || object.spec.image.startsWith('quay.io/')"
+`
+
+func newTestObject(apiVersion, kind, namespace, name string, labels map[string]interface{},
+	spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace, "labels": labels},
+	}}
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	return obj
+}
+
+func TestManifestAdmission(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(file, []byte(testAdmissionRules), 0600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadAdmissionRules(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	admission, err := NewManifestAdmission(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]interface{}{"app": "test"}
+	cases := []struct {
+		name           string
+		obj            *unstructured.Unstructured
+		expectedFields []string
+	}{
+		{
+			name: "admitted",
+			obj:  newTestObject("apps/v1", "Deployment", "default", "test", labels, map[string]interface{}{"replicas": int64(3)}),
+		},
+		{
+			name:           "denied kind of all versions",
+			obj:            newTestObject("rbac.authorization.k8s.io/v1", "ClusterRoleBinding", "", "test", labels, nil),
+			expectedFields: []string{"manifests[0].kind"},
+		},
+		{
+			name:           "denied kind of a version",
+			obj:            newTestObject("v1", "Secret", "default", "test", labels, nil),
+			expectedFields: []string{"manifests[0].kind"},
+		},
+		{
+			name:           "denied namespace without required label",
+			obj:            newTestObject("v1", "ConfigMap", "kube-system", "test", nil, nil),
+			expectedFields: []string{"manifests[0].metadata.namespace", "manifests[0].metadata.labels[app]"},
+		},
+		{
+			name: "rules are not satisfied",
+			obj: newTestObject("example.com/v1", "Foo", "default", "test", labels,
+				map[string]interface{}{"replicas": int64(5), "image": "docker.io/test"}),
+			expectedFields: []string{"manifests[0]", "manifests[0]"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := admission.Admit(field.NewPath("manifests").Index(0), c.obj)
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, c.expectedFields, fields, "unexpected errors %v", errs)
+		})
+	}
+}
+
+func TestNewManifestAdmissionErrors(t *testing.T) {
+	cases := []struct {
+		name  string
+		rules *AdmissionRules
+	}{
+		{
+			name:  "no rule name",
+			rules: &AdmissionRules{Rules: []AdmissionRule{{Expression: "true"}}},
+		},
+		{
+			name:  "invalid expression",
+			rules: &AdmissionRules{Rules: []AdmissionRule{{Name: "test", Expression: "object.spec.("}}},
+		},
+		{
+			name:  "not a bool expression",
+			rules: &AdmissionRules{Rules: []AdmissionRule{{Name: "test", Expression: "object.kind"}}},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewManifestAdmission(c.rules); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/kube-openapi/pkg/spec3"
+	openapierrors "k8s.io/kube-openapi/pkg/validation/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+const (
+	gvkExtension                   = "x-kubernetes-group-version-kind"
+	preserveUnknownFieldsExtension = "x-kubernetes-preserve-unknown-fields"
+	embeddedResourceExtension      = "x-kubernetes-embedded-resource"
+	componentSchemaRefPrefix       = "#/components/schemas/"
+)
+
+// rootFields are always allowed in a resource even if they are not in the schema of a CRD.
+var rootFields = sets.New("apiVersion", "kind", "metadata")
+
+type resourceSchema struct {
+	schema    *spec.Schema
+	validator *validate.SchemaValidator
+}
+
+// SchemaValidator validates the manifests against the OpenAPI v3 schemas of their GVKs offline. The values are
+// validated against the schema, and the fields that are not defined in the schema are rejected unless the schema
+// preserves the unknown fields. The manifests whose GVKs have no schema are not validated.
+type SchemaValidator struct {
+	schemas map[schema.GroupVersionKind]*resourceSchema
+}
+
+// NewSchemaValidator loads the schemas from the files. A file contains CustomResourceDefinitions, or an OpenAPI
+// v3 document, e.g. the output of the /openapi/v3/apis/apps/v1 endpoint of a kube-apiserver. The files are in
+// YAML or JSON, and a YAML file may have multiple documents.
+func NewSchemaValidator(paths ...string) (*SchemaValidator, error) {
+	v := &SchemaValidator{schemas: map[schema.GroupVersionKind]*resourceSchema{}}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := v.load(data); err != nil {
+			return nil, fmt.Errorf("failed to load schemas from %s: %v", path, err)
+		}
+	}
+	return v, nil
+}
+
+func (v *SchemaValidator) load(data []byte) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		raw := json.RawMessage{}
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+
+		doc := struct {
+			Kind    string `json:"kind"`
+			OpenAPI string `json:"openapi"`
+		}{}
+		if err := json.Unmarshal(raw, &doc); err != nil {
+			return err
+		}
+
+		var err error
+		switch {
+		case doc.Kind == "CustomResourceDefinition":
+			err = v.loadCRD(raw)
+		case len(doc.OpenAPI) != 0:
+			err = v.loadOpenAPI(raw)
+		default:
+			err = fmt.Errorf("neither a CustomResourceDefinition nor an OpenAPI v3 document")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (v *SchemaValidator) loadCRD(data []byte) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := json.Unmarshal(data, crd); err != nil {
+		return err
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+
+		// the JSONSchemaProps is serialized as an OpenAPI v3 schema
+		schemaData, err := json.Marshal(version.Schema.OpenAPIV3Schema)
+		if err != nil {
+			return err
+		}
+		s := &spec.Schema{}
+		if err := json.Unmarshal(schemaData, s); err != nil {
+			return fmt.Errorf("invalid schema of %s version %s: %v", crd.Name, version.Name, err)
+		}
+		v.add(schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}, s)
+	}
+	return nil
+}
+
+func (v *SchemaValidator) loadOpenAPI(data []byte) error {
+	doc := &spec3.OpenAPI{}
+	if err := json.Unmarshal(data, doc); err != nil {
+		return err
+	}
+	if doc.Components == nil {
+		return nil
+	}
+
+	for _, s := range doc.Components.Schemas {
+		gvks, err := schemaGVKs(s)
+		if err != nil {
+			return err
+		}
+		if len(gvks) == 0 {
+			continue
+		}
+
+		resolved := inlineRefs(s, doc.Components.Schemas, sets.New[string]())
+		for _, gvk := range gvks {
+			v.add(gvk, resolved)
+		}
+	}
+	return nil
+}
+
+func (v *SchemaValidator) add(gvk schema.GroupVersionKind, s *spec.Schema) {
+	v.schemas[gvk] = &resourceSchema{
+		schema:    s,
+		validator: validate.NewSchemaValidator(s, nil, "", strfmt.Default),
+	}
+}
+
+// Validate validates the manifest against the schema of its GVK.
+func (v *SchemaValidator) Validate(fldPath *field.Path, obj *unstructured.Unstructured) field.ErrorList {
+	s, ok := v.schemas[obj.GroupVersionKind()]
+	if !ok {
+		return nil
+	}
+
+	// the null values are dropped when the manifest is decoded by the kube-apiserver
+	content := removeNulls(runtime.DeepCopyJSON(obj.Object))
+
+	errs := unknownFields(fldPath, s.schema, content, rootFields)
+	result := s.validator.Validate(content)
+	for _, err := range result.Errors {
+		errs = append(errs, toFieldError(fldPath, err))
+	}
+	return errs
+}
+
+// schemaGVKs returns the GVKs of a schema from the x-kubernetes-group-version-kind extension.
+func schemaGVKs(s *spec.Schema) ([]schema.GroupVersionKind, error) {
+	ext, ok := s.Extensions[gvkExtension]
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(ext)
+	if err != nil {
+		return nil, err
+	}
+	gvks := []schema.GroupVersionKind{}
+	if err := json.Unmarshal(data, &gvks); err != nil {
+		return nil, fmt.Errorf("invalid %s extension: %v", gvkExtension, err)
+	}
+	return gvks, nil
+}
+
+// inlineRefs returns a copy of the schema with the references to the component schemas inlined. The
+// unresolved and recursive references are replaced with an empty schema that accepts any value.
+func inlineRefs(s *spec.Schema, components map[string]*spec.Schema, visiting sets.Set[string]) *spec.Schema {
+	if s == nil {
+		return nil
+	}
+
+	if ref := s.Ref.String(); len(ref) != 0 {
+		name := strings.TrimPrefix(ref, componentSchemaRefPrefix)
+		target, ok := components[name]
+		if !ok || visiting.Has(name) {
+			return &spec.Schema{}
+		}
+		visiting.Insert(name)
+		defer visiting.Delete(name)
+		return inlineRefs(target, components, visiting)
+	}
+
+	// the referenced type is wrapped by allOf to carry the default value, unwrap it to validate the value
+	// against the referenced type directly.
+	if len(s.AllOf) == 1 && len(s.Type) == 0 && len(s.Properties) == 0 {
+		return inlineRefs(&s.AllOf[0], components, visiting)
+	}
+
+	inline := func(schemas []spec.Schema) []spec.Schema {
+		if schemas == nil {
+			return nil
+		}
+		out := make([]spec.Schema, len(schemas))
+		for i := range schemas {
+			out[i] = *inlineRefs(&schemas[i], components, visiting)
+		}
+		return out
+	}
+
+	out := *s
+	if s.Properties != nil {
+		out.Properties = make(map[string]spec.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			out.Properties[name] = *inlineRefs(&property, components, visiting)
+		}
+	}
+	if s.Items != nil {
+		out.Items = &spec.SchemaOrArray{
+			Schema:  inlineRefs(s.Items.Schema, components, visiting),
+			Schemas: inline(s.Items.Schemas),
+		}
+	}
+	if s.AdditionalProperties != nil {
+		out.AdditionalProperties = &spec.SchemaOrBool{
+			Allows: s.AdditionalProperties.Allows,
+			Schema: inlineRefs(s.AdditionalProperties.Schema, components, visiting),
+		}
+	}
+	out.AllOf = inline(s.AllOf)
+	out.AnyOf = inline(s.AnyOf)
+	out.OneOf = inline(s.OneOf)
+	out.Not = inlineRefs(s.Not, components, visiting)
+	return &out
+}
+
+// unknownFields returns the fields of the value that are not defined in the properties of the schema, the
+// allowed fields are accepted even if they are not defined.
+func unknownFields(fldPath *field.Path, s *spec.Schema, value interface{}, allowed sets.Set[string]) field.ErrorList {
+	if s == nil || isTrue(s.Extensions[preserveUnknownFieldsExtension]) {
+		return nil
+	}
+
+	var errs field.ErrorList
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+			for key, item := range v {
+				errs = append(errs, unknownFields(fldPath.Key(key), s.AdditionalProperties.Schema, item, nil)...)
+			}
+			return errs
+		}
+		if len(s.Properties) == 0 {
+			return nil
+		}
+
+		if isTrue(s.Extensions[embeddedResourceExtension]) {
+			allowed = rootFields
+		}
+		for _, key := range sets.List(sets.KeySet(v)) {
+			property, ok := s.Properties[key]
+			switch {
+			case ok:
+				errs = append(errs, unknownFields(fldPath.Child(key), &property, v[key], nil)...)
+			case !allowed.Has(key):
+				errs = append(errs, field.NotSupported(fldPath.Child(key), key, sets.List(sets.KeySet(s.Properties))))
+			}
+		}
+	case []interface{}:
+		if s.Items == nil || s.Items.Schema == nil {
+			return nil
+		}
+		for i, item := range v {
+			errs = append(errs, unknownFields(fldPath.Index(i), s.Items.Schema, item, nil)...)
+		}
+	}
+	return errs
+}
+
+// toFieldError converts the error of the OpenAPI schema validation to a field error.
+func toFieldError(fldPath *field.Path, err error) *field.Error {
+	validationErr, ok := err.(*openapierrors.Validation)
+	if !ok {
+		return field.Invalid(fldPath, "", err.Error())
+	}
+
+	errPath := fldPath
+	if len(validationErr.Name) != 0 && validationErr.Name != "." {
+		errPath = errPath.Child(strings.TrimPrefix(validationErr.Name, "."))
+	}
+
+	value := validationErr.Value
+	if value == nil {
+		value = ""
+	}
+	switch validationErr.Code() {
+	case openapierrors.RequiredFailCode:
+		return field.Required(errPath, "")
+	case openapierrors.InvalidTypeCode:
+		return field.TypeInvalid(errPath, value, validationErr.Error())
+	default:
+		return field.Invalid(errPath, value, validationErr.Error())
+	}
+}
+
+func removeNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = removeNulls(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = removeNulls(item)
+		}
+	}
+	return value
+}
+
+func isTrue(value interface{}) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
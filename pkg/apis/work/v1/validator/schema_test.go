@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testCRD = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: foos.example.com
+spec:
+  group: example.com
+  names:
+    kind: Foo
+    plural: foos
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: ["size"]
+            properties:
+              size:
+                type: integer
+                minimum: 1
+              mode:
+                type: string
+                enum: ["fast", "slow"]
+              extra:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+---
+`
+
+const testOpenAPI = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Kubernetes", "version": "v1.30.0"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "io.k8s.api.apps.v1.Deployment": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {"default": {}, "allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]},
+          "spec": {"default": {}, "allOf": [{"$ref": "#/components/schemas/io.k8s.api.apps.v1.DeploymentSpec"}]}
+        },
+        "x-kubernetes-group-version-kind": [{"group": "apps", "kind": "Deployment", "version": "v1"}]
+      },
+      "io.k8s.api.apps.v1.DeploymentSpec": {
+        "type": "object",
+        "properties": {
+          "replicas": {"type": "integer", "format": "int32"},
+          "paused": {"type": "boolean"}
+        }
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "namespace": {"type": "string"},
+          "labels": {"type": "object", "additionalProperties": {"type": "string", "default": ""}},
+          "creationTimestamp": {"type": "string", "format": "date-time"},
+          "ownerReferences": {"type": "array", "items": {"default": {}, "allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]}}
+        }
+      }
+    }
+  }
+}`
+
+func newTestSchemaValidator(t *testing.T) *SchemaValidator {
+	dir := t.TempDir()
+	crdFile := filepath.Join(dir, "crd.yaml")
+	openAPIFile := filepath.Join(dir, "apps.json")
+	if err := os.WriteFile(crdFile, []byte(testCRD), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(openAPIFile, []byte(testOpenAPI), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := NewSchemaValidator(crdFile, openAPIFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestSchemaValidator(t *testing.T) {
+	v := newTestSchemaValidator(t)
+
+	cases := []struct {
+		name           string
+		obj            map[string]interface{}
+		expectedFields []string
+	}{
+		{
+			name: "valid custom resource",
+			obj: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Foo",
+				"metadata":   map[string]interface{}{"name": "foo", "namespace": "default"},
+				"spec": map[string]interface{}{
+					"size":  int64(2),
+					"mode":  "fast",
+					"extra": map[string]interface{}{"any": "value"},
+				},
+			},
+		},
+		{
+			name: "invalid custom resource",
+			obj: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Foo",
+				"metadata":   map[string]interface{}{"name": "foo", "namespace": "default"},
+				"spec":       map[string]interface{}{"mode": "medium", "unknown": true},
+			},
+			expectedFields: []string{
+				"spec.workload.manifests[0].spec.unknown",
+				"spec.workload.manifests[0].spec.size",
+				"spec.workload.manifests[0].spec.mode",
+			},
+		},
+		{
+			name: "valid deployment",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":              "app",
+					"namespace":         "default",
+					"labels":            map[string]interface{}{"app": "test"},
+					"creationTimestamp": nil,
+				},
+				"spec": map[string]interface{}{"replicas": int64(1)},
+			},
+		},
+		{
+			name: "invalid deployment",
+			obj: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":   "app",
+					"labels": map[string]interface{}{"app": int64(1)},
+					"owner":  "test",
+				},
+				"spec": map[string]interface{}{"replicas": "1"},
+			},
+			expectedFields: []string{
+				"spec.workload.manifests[0].metadata.owner",
+				"spec.workload.manifests[0].metadata.labels.app",
+				"spec.workload.manifests[0].spec.replicas",
+			},
+		},
+		{
+			name: "no schema",
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata":   map[string]interface{}{"name": "test"},
+				"unknown":    "value",
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			errs := v.Validate(field.NewPath("spec", "workload", "manifests").Index(0), &unstructured.Unstructured{Object: c.obj})
+			fields := []string{}
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, c.expectedFields, fields, "unexpected errors %v", errs)
+		})
+	}
+}
+
+func TestNewSchemaValidatorErrors(t *testing.T) {
+	if _, err := NewSchemaValidator(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Errorf("expected error for the missing file")
+	}
+
+	file := filepath.Join(t.TempDir(), "configmap.yaml")
+	if err := os.WriteFile(file, []byte("apiVersion: v1\nkind: ConfigMap\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSchemaValidator(file); err == nil {
+		t.Errorf("expected error for the file that is not a schema")
+	}
+}
@@ -5,12 +5,15 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	workv1 "open-cluster-management.io/api/work/v1"
 )
 
 type Validator struct {
-	limit int
+	limit           int
+	schemaValidator *SchemaValidator
+	admission       *ManifestAdmission
 }
 
 var ManifestValidator = &Validator{limit: 500 * 1024} // the default manifest limit is 500k.
@@ -19,6 +22,16 @@ func (m *Validator) WithLimit(limit int) {
 	m.limit = limit
 }
 
+// WithSchemaValidator validates the manifests against the schemas of their GVKs.
+func (m *Validator) WithSchemaValidator(schemaValidator *SchemaValidator) {
+	m.schemaValidator = schemaValidator
+}
+
+// WithAdmission checks the manifests against the admission rules.
+func (m *Validator) WithAdmission(admission *ManifestAdmission) {
+	m.admission = admission
+}
+
 func (m *Validator) ValidateManifests(manifests []workv1.Manifest) error {
 	if len(manifests) == 0 {
 		return errors.NewBadRequest("Workload manifests should not be empty")
@@ -40,7 +53,41 @@ func (m *Validator) ValidateManifests(manifests []workv1.Manifest) error {
 		}
 	}
 
-	return nil
+	if m.schemaValidator == nil && m.admission == nil {
+		return nil
+	}
+	return m.ValidateManifestFields(field.NewPath("spec", "workload", "manifests"), manifests).ToAggregate()
+}
+
+// ValidateManifestFields validates each manifest, and against the schemas and the admission rules if they are
+// set. It returns all the errors with the field paths of the manifests.
+func (m *Validator) ValidateManifestFields(fldPath *field.Path, manifests []workv1.Manifest) field.ErrorList {
+	var errs field.ErrorList
+	for i, manifest := range manifests {
+		manifestPath := fldPath.Index(i)
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+			// the raw manifest may contain the sensitive data, e.g. a Secret, so it is summarized in the error
+			errs = append(errs, field.Invalid(manifestPath, fmt.Sprintf("<%d bytes>", len(manifest.Raw)), err.Error()))
+			continue
+		}
+		if obj.GetName() == "" {
+			errs = append(errs, field.Required(manifestPath.Child("metadata", "name"), "name must be set in manifest"))
+		}
+		if obj.GetGenerateName() != "" {
+			errs = append(errs, field.Forbidden(manifestPath.Child("metadata", "generateName"),
+				"generateName must not be set in manifest"))
+		}
+
+		if m.schemaValidator != nil {
+			errs = append(errs, m.schemaValidator.Validate(manifestPath, obj)...)
+		}
+		if m.admission != nil {
+			errs = append(errs, m.admission.Admit(manifestPath, obj)...)
+		}
+	}
+	return errs
 }
 
 func validateManifest(manifest []byte) error {
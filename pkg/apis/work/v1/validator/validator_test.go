@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	workv1 "open-cluster-management.io/api/work/v1"
 )
@@ -59,3 +61,41 @@ func Test_Validator(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidatorWithSchemaAndAdmission(t *testing.T) {
+	admission, err := NewManifestAdmission(&AdmissionRules{DeniedNamespaces: []string{"kube-system"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &Validator{limit: 500 * 1024}
+	v.WithSchemaValidator(newTestSchemaValidator(t))
+	v.WithAdmission(admission)
+
+	toManifest := func(obj *unstructured.Unstructured) workv1.Manifest {
+		raw, _ := obj.MarshalJSON()
+		return workv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+
+	valid := newTestObject("example.com/v1", "Foo", "default", "test", nil, map[string]interface{}{"size": int64(1)})
+	assert.NoError(t, v.ValidateManifests([]workv1.Manifest{toManifest(valid)}))
+
+	invalid := newTestObject("example.com/v1", "Foo", "kube-system", "test", nil, map[string]interface{}{"size": int64(0)})
+	errs := v.ValidateManifestFields(field.NewPath("spec", "workload", "manifests"),
+		[]workv1.Manifest{toManifest(valid), toManifest(invalid)})
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	assert.ElementsMatch(t, []string{
+		"spec.workload.manifests[1].spec.size",
+		"spec.workload.manifests[1].metadata.namespace",
+	}, fields)
+	assert.Error(t, v.ValidateManifests([]workv1.Manifest{toManifest(valid), toManifest(invalid)}))
+
+	// the raw manifest is not echoed in the errors
+	secret := []byte(`{"apiVersion":"v1","kind":"Secret","data":{"password":"c2VjcmV0"}`)
+	errs = v.ValidateManifestFields(field.NewPath("spec", "workload", "manifests"),
+		[]workv1.Manifest{{RawExtension: runtime.RawExtension{Raw: secret}}})
+	assert.Len(t, errs, 1)
+	assert.NotContains(t, errs.ToAggregate().Error(), "c2VjcmV0")
+}
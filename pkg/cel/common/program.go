@@ -0,0 +1,50 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/interpreter"
+)
+
+// DefaultCostLimit is the default runtime cost limit of an expression evaluation.
+const DefaultCostLimit uint64 = 1000000
+
+// CompileProgram compiles the expression in the environment and returns its program. The expression must evaluate
+// to one of the output types, the runtime cost of each evaluation is tracked with the cost estimator wrapped by the
+// BaseEnvCostEstimator, and it is limited by the cost limit, which defaults to the DefaultCostLimit if it is 0.
+func CompileProgram(env *cel.Env, expression string, estimator interpreter.ActualCostEstimator,
+	costLimit uint64, outputTypes ...*types.Type) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile the expression: %v", issues.Err())
+	}
+
+	matched := false
+	names := make([]string, 0, len(outputTypes))
+	for _, outputType := range outputTypes {
+		if ast.OutputType().IsExactType(outputType) {
+			matched = true
+			break
+		}
+		names = append(names, outputType.String())
+	}
+	if !matched {
+		return nil, fmt.Errorf("the expression should evaluate to %s, but got %s",
+			strings.Join(names, " or "), ast.OutputType())
+	}
+
+	if costLimit == 0 {
+		costLimit = DefaultCostLimit
+	}
+	program, err := env.Program(ast,
+		cel.CostTracking(&BaseEnvCostEstimator{CostEstimator: estimator}),
+		cel.CostLimit(costLimit),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the program: %v", err)
+	}
+	return program, nil
+}
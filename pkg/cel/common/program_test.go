@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileProgram(t *testing.T) {
+	env, err := cel.NewEnv(append([]cel.EnvOption{
+		cel.Variable("items", cel.ListType(cel.IntType)),
+	}, BaseEnvOpts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	items := make([]int64, 1000)
+	tests := []struct {
+		name        string
+		expression  string
+		costLimit   uint64
+		outputTypes []*types.Type
+		wantResult  any
+		wantErr     string
+	}{
+		{
+			name:        "bool expression",
+			expression:  "items.all(i, i == 0)",
+			outputTypes: []*types.Type{types.BoolType},
+			wantResult:  true,
+		},
+		{
+			name:        "one of the output types",
+			expression:  "size(items)",
+			outputTypes: []*types.Type{types.DoubleType, types.IntType},
+			wantResult:  int64(1000),
+		},
+		{
+			name:        "invalid expression",
+			expression:  "items.all(",
+			outputTypes: []*types.Type{types.BoolType},
+			wantErr:     "failed to compile the expression",
+		},
+		{
+			name:        "unexpected output type",
+			expression:  "size(items)",
+			outputTypes: []*types.Type{types.BoolType, types.DoubleType},
+			wantErr:     "the expression should evaluate to bool or double, but got int",
+		},
+		{
+			name:        "cost limit exceeded",
+			expression:  "items.all(i, i == 0)",
+			costLimit:   100,
+			outputTypes: []*types.Type{types.BoolType},
+			wantErr:     "actual cost limit exceeded",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := CompileProgram(env, tt.expression, nil, tt.costLimit, tt.outputTypes...)
+			if err == nil {
+				var result ref.Val
+				result, _, err = program.Eval(map[string]any{"items": items})
+				if err == nil {
+					assert.Equal(t, tt.wantResult, result.Value())
+				}
+			}
+			if len(tt.wantErr) != 0 {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
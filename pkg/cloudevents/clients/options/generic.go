@@ -10,6 +10,7 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/store"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/clients"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/builder"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
@@ -23,6 +24,8 @@ type GenericClientOptions[T generic.ResourceObject] struct {
 	clusterName  string
 	subscription bool
 	resync       bool
+	signer       options.EventSigner
+	verifier     options.EventVerifier
 }
 
 // NewGenericClientOptions create a GenericClientOptions
@@ -87,6 +90,19 @@ func (o *GenericClientOptions[T]) WithResyncEnabled(resync bool) *GenericClientO
 	return o
 }
 
+// WithEventSigner set the signer to sign the events that are sent by the client.
+func (o *GenericClientOptions[T]) WithEventSigner(signer options.EventSigner) *GenericClientOptions[T] {
+	o.signer = signer
+	return o
+}
+
+// WithEventVerifier set the verifier to verify the events that are received by the client, the events that are
+// unsigned or whose signatures do not match are rejected.
+func (o *GenericClientOptions[T]) WithEventVerifier(verifier options.EventVerifier) *GenericClientOptions[T] {
+	o.verifier = verifier
+	return o
+}
+
 func (o *GenericClientOptions[T]) ClusterName() string {
 	return o.clusterName
 }
@@ -114,14 +130,16 @@ func (o *GenericClientOptions[T]) AgentClient(ctx context.Context) (generic.Clou
 		o.watcherStore = store.NewAgentInformerWatcherStore[T]()
 	}
 
-	options, err := builder.BuildCloudEventsAgentOptions(o.config, o.clusterName, o.clientID, o.codec.EventDataType())
+	agentOptions, err := builder.BuildCloudEventsAgentOptions(o.config, o.clusterName, o.clientID, o.codec.EventDataType())
 	if err != nil {
 		return nil, err
 	}
+	agentOptions.EventSigner = o.signer
+	agentOptions.EventVerifier = o.verifier
 
 	cloudEventsClient, err := clients.NewCloudEventAgentClient(
 		ctx,
-		options,
+		agentOptions,
 		store.NewAgentWatcherStoreLister(o.watcherStore),
 		statushash.StatusHash,
 		o.codec,
@@ -178,14 +196,16 @@ func (o *GenericClientOptions[T]) SourceClient(ctx context.Context) (generic.Clo
 		return nil, fmt.Errorf("a watcher store is required")
 	}
 
-	options, err := builder.BuildCloudEventsSourceOptions(o.config, o.clientID, o.sourceID, o.codec.EventDataType())
+	sourceOptions, err := builder.BuildCloudEventsSourceOptions(o.config, o.clientID, o.sourceID, o.codec.EventDataType())
 	if err != nil {
 		return nil, err
 	}
+	sourceOptions.EventSigner = o.signer
+	sourceOptions.EventVerifier = o.verifier
 
 	cloudEventsClient, err := clients.NewCloudEventSourceClient(
 		ctx,
-		options,
+		sourceOptions,
 		store.NewSourceWatcherStoreLister(o.watcherStore),
 		statushash.StatusHash,
 		o.codec,
@@ -34,10 +34,26 @@ func init() {
 }
 
 // ManifestBundleCodec is a codec to encode/decode a ManifestWork/cloudevent with ManifestBundle for an agent.
-type ManifestBundleCodec struct{}
+type ManifestBundleCodec struct {
+	dataContentType string
+}
 
 func NewManifestBundleCodec() *ManifestBundleCodec {
-	return &ManifestBundleCodec{}
+	return &ManifestBundleCodec{dataContentType: cloudevents.ApplicationJSON}
+}
+
+// NewProtobufManifestBundleCodec returns a ManifestBundleCodec that encodes the event data with protobuf. The codec
+// decodes the received events by their data content type, so it works with the peers that still send JSON.
+func NewProtobufManifestBundleCodec() *ManifestBundleCodec {
+	return &ManifestBundleCodec{dataContentType: types.ApplicationProtobuf}
+}
+
+// DataContentType returns the content type of the event data that is encoded by this codec.
+func (c *ManifestBundleCodec) DataContentType() string {
+	if len(c.dataContentType) == 0 {
+		return cloudevents.ApplicationJSON
+	}
+	return c.dataContentType
 }
 
 // EventDataType always returns the event data type `io.open-cluster-management.works.v1alpha1.manifestbundles`.
@@ -88,7 +104,7 @@ func (c *ManifestBundleCodec) Encode(source string, eventType types.CloudEventsT
 		ResourceStatus: work.Status.ResourceStatus.Manifests,
 	}
 
-	if err := evt.SetData(cloudevents.ApplicationJSON, manifestBundleStatus); err != nil {
+	if err := payload.EncodeManifestBundleStatus(&evt, c.DataContentType(), manifestBundleStatus); err != nil {
 		return nil, fmt.Errorf("failed to encode manifestwork status to a cloudevent: %v", err)
 	}
 
@@ -180,8 +196,8 @@ func (c *ManifestBundleCodec) Decode(evt *cloudevents.Event) (*workv1.ManifestWo
 		return work, nil
 	}
 
-	manifests := &payload.ManifestBundle{}
-	if err := evt.DataAs(manifests); err != nil {
+	manifests, err := payload.DecodeManifestBundle(evt)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data %s, %v", string(evt.Data()), err)
 	}
 
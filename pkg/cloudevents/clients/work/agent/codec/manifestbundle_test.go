@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	workv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/common"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
@@ -722,3 +723,39 @@ func TestManifestBundleAgentLogTracing(t *testing.T) {
 		}
 	})
 }
+
+func TestProtobufManifestBundleCodec(t *testing.T) {
+	codec := NewProtobufManifestBundleCodec()
+	assert.Equal(t, types.ApplicationProtobuf, codec.DataContentType())
+
+	evt := cloudevents.NewEvent()
+	evt.SetSource("source1")
+	evt.SetType("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.test")
+	evt.SetExtension("resourceid", "test")
+	evt.SetExtension("resourceversion", "13")
+	evt.SetExtension("clustername", "cluster1")
+	err := payload.EncodeManifestBundle(&evt, types.ApplicationProtobuf, &payload.ManifestBundle{
+		Manifests: []workv1.Manifest{
+			{RawExtension: runtime.RawExtension{Raw: toConfigMap(t)}},
+		},
+	})
+	assert.NoError(t, err)
+
+	work, err := codec.Decode(&evt)
+	assert.NoError(t, err)
+	assert.Len(t, work.Spec.Workload.Manifests, 1)
+
+	work.Labels[common.CloudEventsOriginalSourceLabelKey] = "source1"
+	work.Status.Conditions = []metav1.Condition{{Type: workv1.WorkApplied, Status: metav1.ConditionTrue}}
+	statusEvt, err := codec.Encode("cluster1-work-agent", types.CloudEventsType{
+		CloudEventsDataType: payload.ManifestBundleEventDataType,
+		SubResource:         types.SubResourceStatus,
+		Action:              types.UpdateRequestAction,
+	}, work)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ApplicationProtobuf, statusEvt.DataContentType())
+
+	status, err := payload.DecodeManifestBundleStatus(statusEvt)
+	assert.NoError(t, err)
+	assert.Equal(t, work.Status.Conditions, status.Conditions)
+}
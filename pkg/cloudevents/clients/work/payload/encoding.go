@@ -0,0 +1,311 @@
+package payload
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload/protobuf/v1"
+	genericpayload "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/payload"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// EncodeManifestBundle sets the ManifestBundle to the data of a cloudevent with the given content type, the content
+// type can be `application/json` or `application/protobuf`.
+func EncodeManifestBundle(evt *cloudevents.Event, contentType string, bundle *ManifestBundle) error {
+	if contentType != types.ApplicationProtobuf {
+		return evt.SetData(contentType, bundle)
+	}
+
+	pbBundle, err := manifestBundleToProto(bundle)
+	if err != nil {
+		return err
+	}
+
+	return genericpayload.SetProtobufData(evt, pbBundle)
+}
+
+// DecodeManifestBundle gets the ManifestBundle from the data of a cloudevent, the data is decoded by the data content
+// type of the event.
+func DecodeManifestBundle(evt *cloudevents.Event) (*ManifestBundle, error) {
+	if evt.DataContentType() != types.ApplicationProtobuf {
+		bundle := &ManifestBundle{}
+		if err := evt.DataAs(bundle); err != nil {
+			return nil, err
+		}
+		return bundle, nil
+	}
+
+	pbBundle := &pbv1.ManifestBundle{}
+	if err := proto.Unmarshal(evt.Data(), pbBundle); err != nil {
+		return nil, err
+	}
+
+	return manifestBundleFromProto(pbBundle)
+}
+
+// EncodeManifestBundleStatus sets the ManifestBundleStatus to the data of a cloudevent with the given content type,
+// the content type can be `application/json` or `application/protobuf`.
+func EncodeManifestBundleStatus(evt *cloudevents.Event, contentType string, status *ManifestBundleStatus) error {
+	if contentType != types.ApplicationProtobuf {
+		return evt.SetData(contentType, status)
+	}
+
+	pbStatus := &pbv1.ManifestBundleStatus{
+		Conditions:     conditionsToProto(status.Conditions),
+		ResourceStatus: make([]*pbv1.ManifestCondition, len(status.ResourceStatus)),
+	}
+
+	if status.ManifestBundle != nil {
+		pbBundle, err := manifestBundleToProto(status.ManifestBundle)
+		if err != nil {
+			return err
+		}
+		pbStatus.ManifestBundle = pbBundle
+	}
+
+	for i, manifestCondition := range status.ResourceStatus {
+		pbStatus.ResourceStatus[i] = manifestConditionToProto(manifestCondition)
+	}
+
+	return genericpayload.SetProtobufData(evt, pbStatus)
+}
+
+// DecodeManifestBundleStatus gets the ManifestBundleStatus from the data of a cloudevent, the data is decoded by the
+// data content type of the event.
+func DecodeManifestBundleStatus(evt *cloudevents.Event) (*ManifestBundleStatus, error) {
+	if evt.DataContentType() != types.ApplicationProtobuf {
+		status := &ManifestBundleStatus{}
+		if err := evt.DataAs(status); err != nil {
+			return nil, err
+		}
+		return status, nil
+	}
+
+	pbStatus := &pbv1.ManifestBundleStatus{}
+	if err := proto.Unmarshal(evt.Data(), pbStatus); err != nil {
+		return nil, err
+	}
+
+	status := &ManifestBundleStatus{
+		Conditions: conditionsFromProto(pbStatus.Conditions),
+	}
+
+	if pbStatus.ManifestBundle != nil {
+		bundle, err := manifestBundleFromProto(pbStatus.ManifestBundle)
+		if err != nil {
+			return nil, err
+		}
+		status.ManifestBundle = bundle
+	}
+
+	for _, pbManifestCondition := range pbStatus.ResourceStatus {
+		status.ResourceStatus = append(status.ResourceStatus, manifestConditionFromProto(pbManifestCondition))
+	}
+
+	return status, nil
+}
+
+// manifestBundleToProto converts a ManifestBundle to its protobuf message. The manifests are kept as their raw
+// JSON, and the delete option, manifest configs and executer are encoded with JSON, so that they always follow the
+// ManifestWork API.
+func manifestBundleToProto(bundle *ManifestBundle) (*pbv1.ManifestBundle, error) {
+	pbBundle := &pbv1.ManifestBundle{Manifests: make([][]byte, len(bundle.Manifests))}
+	for i, manifest := range bundle.Manifests {
+		raw, err := manifest.MarshalJSON()
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal manifest %d, %v", i, err)
+		}
+		pbBundle.Manifests[i] = raw
+	}
+
+	var err error
+	if pbBundle.DeleteOption, err = marshalOptional(bundle.DeleteOption != nil, bundle.DeleteOption); err != nil {
+		return nil, err
+	}
+	if pbBundle.ManifestConfigs, err = marshalOptional(len(bundle.ManifestConfigs) != 0, bundle.ManifestConfigs); err != nil {
+		return nil, err
+	}
+	if pbBundle.Executer, err = marshalOptional(bundle.Executer != nil, bundle.Executer); err != nil {
+		return nil, err
+	}
+
+	return pbBundle, nil
+}
+
+func manifestBundleFromProto(pbBundle *pbv1.ManifestBundle) (*ManifestBundle, error) {
+	bundle := &ManifestBundle{Manifests: make([]workv1.Manifest, len(pbBundle.Manifests))}
+	for i, raw := range pbBundle.Manifests {
+		if bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+		bundle.Manifests[i] = workv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+
+	if len(pbBundle.DeleteOption) != 0 {
+		bundle.DeleteOption = &workv1.DeleteOption{}
+		if err := json.Unmarshal(pbBundle.DeleteOption, bundle.DeleteOption); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delete option, %v", err)
+		}
+	}
+
+	if len(pbBundle.ManifestConfigs) != 0 {
+		if err := json.Unmarshal(pbBundle.ManifestConfigs, &bundle.ManifestConfigs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal manifest configs, %v", err)
+		}
+	}
+
+	if len(pbBundle.Executer) != 0 {
+		bundle.Executer = &workv1.ManifestWorkExecutor{}
+		if err := json.Unmarshal(pbBundle.Executer, bundle.Executer); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal executer, %v", err)
+		}
+	}
+
+	return bundle, nil
+}
+
+func marshalOptional(set bool, v any) ([]byte, error) {
+	if !set {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	return data, nil
+}
+
+func manifestConditionToProto(manifestCondition workv1.ManifestCondition) *pbv1.ManifestCondition {
+	meta := manifestCondition.ResourceMeta
+	pbManifestCondition := &pbv1.ManifestCondition{
+		ResourceMeta: &pbv1.ManifestResourceMeta{
+			Ordinal:   meta.Ordinal,
+			Group:     meta.Group,
+			Version:   meta.Version,
+			Kind:      meta.Kind,
+			Resource:  meta.Resource,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+		},
+		Conditions: conditionsToProto(manifestCondition.Conditions),
+	}
+
+	for _, value := range manifestCondition.StatusFeedbacks.Values {
+		pbValue := &pbv1.FieldValue{Type: string(value.Value.Type)}
+		switch {
+		case value.Value.Integer != nil:
+			pbValue.Value = &pbv1.FieldValue_IntegerValue{IntegerValue: *value.Value.Integer}
+		case value.Value.String != nil:
+			pbValue.Value = &pbv1.FieldValue_StringValue{StringValue: *value.Value.String}
+		case value.Value.Boolean != nil:
+			pbValue.Value = &pbv1.FieldValue_BooleanValue{BooleanValue: *value.Value.Boolean}
+		case value.Value.JsonRaw != nil:
+			pbValue.Value = &pbv1.FieldValue_JsonRawValue{JsonRawValue: *value.Value.JsonRaw}
+		}
+
+		pbManifestCondition.StatusFeedbacks = append(pbManifestCondition.StatusFeedbacks, &pbv1.FeedbackValue{
+			Name:  value.Name,
+			Value: pbValue,
+		})
+	}
+
+	return pbManifestCondition
+}
+
+func manifestConditionFromProto(pbManifestCondition *pbv1.ManifestCondition) workv1.ManifestCondition {
+	manifestCondition := workv1.ManifestCondition{
+		Conditions: conditionsFromProto(pbManifestCondition.Conditions),
+	}
+
+	if meta := pbManifestCondition.ResourceMeta; meta != nil {
+		manifestCondition.ResourceMeta = workv1.ManifestResourceMeta{
+			Ordinal:   meta.Ordinal,
+			Group:     meta.Group,
+			Version:   meta.Version,
+			Kind:      meta.Kind,
+			Resource:  meta.Resource,
+			Name:      meta.Name,
+			Namespace: meta.Namespace,
+		}
+	}
+
+	for _, pbValue := range pbManifestCondition.StatusFeedbacks {
+		value := workv1.FeedbackValue{
+			Name:  pbValue.Name,
+			Value: workv1.FieldValue{Type: workv1.ValueType(pbValue.Value.GetType())},
+		}
+
+		switch v := pbValue.Value.GetValue().(type) {
+		case *pbv1.FieldValue_IntegerValue:
+			value.Value.Integer = &v.IntegerValue
+		case *pbv1.FieldValue_StringValue:
+			value.Value.String = &v.StringValue
+		case *pbv1.FieldValue_BooleanValue:
+			value.Value.Boolean = &v.BooleanValue
+		case *pbv1.FieldValue_JsonRawValue:
+			value.Value.JsonRaw = &v.JsonRawValue
+		}
+
+		manifestCondition.StatusFeedbacks.Values = append(manifestCondition.StatusFeedbacks.Values, value)
+	}
+
+	return manifestCondition
+}
+
+func conditionsToProto(conditions []metav1.Condition) []*pbv1.Condition {
+	if conditions == nil {
+		return nil
+	}
+
+	pbConditions := make([]*pbv1.Condition, len(conditions))
+	for i, cond := range conditions {
+		pbConditions[i] = &pbv1.Condition{
+			Type:               cond.Type,
+			Status:             string(cond.Status),
+			ObservedGeneration: cond.ObservedGeneration,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+		}
+		if !cond.LastTransitionTime.IsZero() {
+			pbConditions[i].LastTransitionTime = timestamppb.New(cond.LastTransitionTime.Time)
+		}
+	}
+	return pbConditions
+}
+
+func conditionsFromProto(pbConditions []*pbv1.Condition) []metav1.Condition {
+	if pbConditions == nil {
+		return nil
+	}
+
+	conditions := make([]metav1.Condition, len(pbConditions))
+	for i, pbCond := range pbConditions {
+		conditions[i] = metav1.Condition{
+			Type:               pbCond.Type,
+			Status:             metav1.ConditionStatus(pbCond.Status),
+			ObservedGeneration: pbCond.ObservedGeneration,
+			Reason:             pbCond.Reason,
+			Message:            pbCond.Message,
+		}
+		if pbCond.LastTransitionTime != nil {
+			conditions[i].LastTransitionTime = metav1.NewTime(pbCond.LastTransitionTime.AsTime().Local())
+		}
+	}
+	return conditions
+}
@@ -0,0 +1,103 @@
+package payload
+
+import (
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	workv1 "open-cluster-management.io/api/work/v1"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+func TestManifestBundleEncoding(t *testing.T) {
+	bundle := &ManifestBundle{
+		Manifests: []workv1.Manifest{
+			{RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"test","namespace":"test"}}`)}},
+		},
+		DeleteOption: &workv1.DeleteOption{PropagationPolicy: workv1.DeletePropagationPolicyTypeOrphan},
+		ManifestConfigs: []workv1.ManifestConfigOption{
+			{
+				ResourceIdentifier: workv1.ResourceIdentifier{Resource: "configmaps", Name: "test", Namespace: "test"},
+				FeedbackRules:      []workv1.FeedbackRule{{Type: workv1.WellKnownStatusType}},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		contentType string
+	}{
+		{name: "json", contentType: cloudevents.ApplicationJSON},
+		{name: "protobuf", contentType: types.ApplicationProtobuf},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := cloudevents.NewEvent()
+			require.NoError(t, EncodeManifestBundle(&evt, c.contentType, bundle))
+			require.Equal(t, c.contentType, evt.DataContentType())
+
+			decoded, err := DecodeManifestBundle(&evt)
+			require.NoError(t, err)
+			require.Equal(t, bundle.DeleteOption, decoded.DeleteOption)
+			require.Equal(t, bundle.ManifestConfigs, decoded.ManifestConfigs)
+			require.Nil(t, decoded.Executer)
+			require.Len(t, decoded.Manifests, 1)
+			require.JSONEq(t, string(bundle.Manifests[0].Raw), string(decoded.Manifests[0].Raw))
+		})
+	}
+}
+
+func TestManifestBundleStatusEncoding(t *testing.T) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	status := &ManifestBundleStatus{
+		Conditions: []metav1.Condition{
+			{Type: workv1.WorkApplied, Status: metav1.ConditionTrue, ObservedGeneration: 2, LastTransitionTime: now, Reason: "Applied"},
+		},
+		ResourceStatus: []workv1.ManifestCondition{
+			{
+				ResourceMeta: workv1.ManifestResourceMeta{Ordinal: 0, Version: "v1", Kind: "ConfigMap", Resource: "configmaps", Name: "test", Namespace: "test"},
+				StatusFeedbacks: workv1.StatusFeedbackResult{
+					Values: []workv1.FeedbackValue{
+						{Name: "replicas", Value: workv1.FieldValue{Type: workv1.Integer, Integer: ptr.To[int64](3)}},
+						{Name: "ready", Value: workv1.FieldValue{Type: workv1.Boolean, Boolean: ptr.To(true)}},
+						{Name: "phase", Value: workv1.FieldValue{Type: workv1.String, String: ptr.To("Running")}},
+						{Name: "raw", Value: workv1.FieldValue{Type: workv1.JsonRaw, JsonRaw: ptr.To(`{"a":"b"}`)}},
+					},
+				},
+				Conditions: []metav1.Condition{
+					{Type: workv1.ManifestAvailable, Status: metav1.ConditionTrue, LastTransitionTime: now, Reason: "ResourceAvailable"},
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name        string
+		contentType string
+	}{
+		{name: "json", contentType: cloudevents.ApplicationJSON},
+		{name: "protobuf", contentType: types.ApplicationProtobuf},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := cloudevents.NewEvent()
+			require.NoError(t, EncodeManifestBundleStatus(&evt, c.contentType, status))
+			require.Equal(t, c.contentType, evt.DataContentType())
+
+			decoded, err := DecodeManifestBundleStatus(&evt)
+			require.NoError(t, err)
+			require.Nil(t, decoded.ManifestBundle)
+			require.Equal(t, status.ResourceStatus, decoded.ResourceStatus)
+			require.Equal(t, status.Conditions, decoded.Conditions)
+		})
+	}
+}
@@ -20,10 +20,26 @@ import (
 )
 
 // ManifestBundleCodec is a codec to encode/decode a ManifestWork/cloudevent with ManifestBundle for a source.
-type ManifestBundleCodec struct{}
+type ManifestBundleCodec struct {
+	dataContentType string
+}
 
 func NewManifestBundleCodec() *ManifestBundleCodec {
-	return &ManifestBundleCodec{}
+	return &ManifestBundleCodec{dataContentType: cloudevents.ApplicationJSON}
+}
+
+// NewProtobufManifestBundleCodec returns a ManifestBundleCodec that encodes the event data with protobuf. The codec
+// decodes the received events by their data content type, so it works with the peers that still send JSON.
+func NewProtobufManifestBundleCodec() *ManifestBundleCodec {
+	return &ManifestBundleCodec{dataContentType: types.ApplicationProtobuf}
+}
+
+// DataContentType returns the content type of the event data that is encoded by this codec.
+func (c *ManifestBundleCodec) DataContentType() string {
+	if len(c.dataContentType) == 0 {
+		return cloudevents.ApplicationJSON
+	}
+	return c.dataContentType
 }
 
 // EventDataType always returns the event data type `io.open-cluster-management.works.v1alpha1.manifestbundles`.
@@ -66,7 +82,7 @@ func (c *ManifestBundleCodec) Encode(source string, eventType types.CloudEventsT
 		ManifestConfigs: work.Spec.ManifestConfigs,
 		Executer:        work.Spec.Executor,
 	}
-	if err := evt.SetData(cloudevents.ApplicationJSON, manifests); err != nil {
+	if err := payload.EncodeManifestBundle(&evt, c.DataContentType(), manifests); err != nil {
 		return nil, fmt.Errorf("failed to encode manifestwork status to a cloudevent: %v", err)
 	}
 
@@ -137,8 +153,8 @@ func (c *ManifestBundleCodec) Decode(evt *cloudevents.Event) (*workv1.ManifestWo
 		ObjectMeta: metaObj,
 	}
 
-	manifestStatus := &payload.ManifestBundleStatus{}
-	if err := evt.DataAs(manifestStatus); err != nil {
+	manifestStatus, err := payload.DecodeManifestBundleStatus(evt)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal event data %s, %v", string(evt.Data()), err)
 	}
 
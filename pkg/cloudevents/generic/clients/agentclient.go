@@ -45,7 +45,8 @@ func NewCloudEventAgentClient[T generic.ResourceObject](
 	statusHashGetter generic.StatusHashGetter[T],
 	codec generic.Codec[T],
 ) (generic.CloudEventsClient[T], error) {
-	baseClient := newBaseClient(agentOptions.AgentID, agentOptions.CloudEventsTransport, agentOptions.EventRateLimit)
+	baseClient := newBaseClient(agentOptions.AgentID, agentOptions.CloudEventsTransport, agentOptions.EventRateLimit,
+		agentOptions.EventSigner, agentOptions.EventVerifier)
 	if err := baseClient.connect(ctx); err != nil {
 		return nil, err
 	}
@@ -98,7 +99,7 @@ func (c *CloudEventAgentClient[T]) Resync(ctx context.Context, source string) er
 		WithOriginalSource(source).
 		WithClusterName(c.clusterName).
 		NewEvent()
-	if err := evt.SetData(cloudevents.ApplicationJSON, resources); err != nil {
+	if err := payload.EncodeSpecResyncRequest(&evt, dataContentType(c.codec), resources); err != nil {
 		return fmt.Errorf("failed to set data to cloud event: %v", err)
 	}
 
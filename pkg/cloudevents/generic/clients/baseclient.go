@@ -64,13 +64,21 @@ type baseClient struct {
 	subscribeChan          chan struct{}
 	connected              atomic.Bool
 	subscribed             atomic.Bool
+
+	// signer and verifier are optional, the signer signs the events before they are sent, and the verifier
+	// rejects the received events that are unsigned or whose signatures do not match.
+	signer   options.EventSigner
+	verifier options.EventVerifier
 }
 
-func newBaseClient(clientID string, transport options.CloudEventTransport, limit utils.EventRateLimit) *baseClient {
+func newBaseClient(clientID string, transport options.CloudEventTransport, limit utils.EventRateLimit,
+	signer options.EventSigner, verifier options.EventVerifier) *baseClient {
 	return &baseClient{
 		clientID:               clientID,
 		transport:              transport,
 		cloudEventsRateLimiter: utils.NewRateLimiter(limit),
+		signer:                 signer,
+		verifier:               verifier,
 		subscribedChan:         make(chan struct{}, 1),
 		subscribeChan:          make(chan struct{}, 1),
 		receiverChan:           make(chan int, 2), // Allow both stop and start signals to be buffered
@@ -83,6 +91,7 @@ func (c *baseClient) connect(ctx context.Context) error {
 	var err error
 	err = c.transport.Connect(ctx)
 	if err != nil {
+		c.rotateEndpoint(ctx)
 		return err
 	}
 	c.connected.Store(true)
@@ -104,6 +113,7 @@ func (c *baseClient) connect(ctx context.Context) error {
 				if err != nil {
 					// failed to reconnect, try again
 					runtime.HandleErrorWithContext(ctx, err, "the cloudevents client reconnect failed")
+					c.rotateEndpoint(ctx)
 					<-wait.RealTimer(DelayFn()).C()
 					continue
 				}
@@ -146,6 +156,7 @@ func (c *baseClient) connect(ctx context.Context) error {
 				if err := c.transport.Close(ctx); err != nil {
 					runtime.HandleErrorWithContext(ctx, err, "failed to close the cloudevents protocol")
 				}
+				c.rotateEndpoint(ctx)
 
 				<-wait.RealTimer(DelayFn()).C()
 			}
@@ -155,6 +166,14 @@ func (c *baseClient) connect(ctx context.Context) error {
 	return nil
 }
 
+// rotateEndpoint switches the transport to the next broker endpoint if the transport has multiple endpoints,
+// so the next connect fails over to another broker.
+func (c *baseClient) rotateEndpoint(ctx context.Context) {
+	if rotator, ok := c.transport.(options.EndpointRotator); ok {
+		rotator.RotateEndpoint(ctx)
+	}
+}
+
 func (c *baseClient) publish(ctx context.Context, evt cloudevents.Event) error {
 	logger := logging.SetLogTracingByCloudEvent(klog.FromContext(ctx), &evt)
 	now := time.Now()
@@ -176,6 +195,12 @@ func (c *baseClient) publish(ctx context.Context, evt cloudevents.Event) error {
 		return fmt.Errorf("the cloudevents client is not ready")
 	}
 
+	if c.signer != nil {
+		if err := c.signer.Sign(ctx, &evt); err != nil {
+			return fmt.Errorf("failed to sign the event: %w", err)
+		}
+	}
+
 	if logger.V(5).Enabled() {
 		evtData, _ := evt.MarshalJSON()
 		logger.V(5).Info("Sending event", "event", string(evtData))
@@ -303,6 +328,13 @@ func (c *baseClient) subscribe(ctx context.Context, receive receiveFn) {
 									receiveLogger.V(2).Info("Received event",
 										"eventType", evt.Type(), "extensions", evt.Extensions())
 								}
+								if c.verifier != nil {
+									if err := c.verifier.Verify(handlerCtx, evt); err != nil {
+										receiveLogger.Error(err, "reject the received event",
+											"eventType", evt.Type(), "source", evt.Source())
+										return
+									}
+								}
 								receive(handlerCtx, evt)
 							}); err != nil {
 								runtime.HandleErrorWithContext(ctx, err, "failed to receive cloudevents")
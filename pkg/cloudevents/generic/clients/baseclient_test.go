@@ -0,0 +1,98 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/fake"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/utils"
+)
+
+func TestConnectFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	originalDelayFn := DelayFn
+	// override DelayFn to avoid waiting for backoff
+	DelayFn = func() time.Duration { return 0 }
+	defer func() {
+		// reset DelayFn
+		DelayFn = originalDelayFn
+	}()
+
+	transport := newRotatingTransport("broker1", "broker2")
+	transport.setDown("broker1", true)
+
+	client := newBaseClient(testAgentName, transport, utils.EventRateLimit{}, nil, nil)
+
+	// the first broker is down, the client rotates to the second broker for the next connect
+	require.Error(t, client.connect(ctx))
+	require.NoError(t, client.connect(ctx))
+	require.Equal(t, []string{"broker2"}, transport.connectedEndpoints())
+
+	// the second broker is down, the client fails over to the first broker once it is disconnected
+	transport.setDown("broker1", false)
+	transport.setDown("broker2", true)
+	transport.ErrChan <- fmt.Errorf("test error")
+	require.Eventually(t, func() bool {
+		return len(transport.connectedEndpoints()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"broker2", "broker1"}, transport.connectedEndpoints())
+}
+
+// rotatingTransport is a fake transport that connects to one of multiple endpoints, the connection to an endpoint
+// that is down fails.
+type rotatingTransport struct {
+	*fake.EventChan
+
+	mu        sync.Mutex
+	endpoints []string
+	active    int
+	down      map[string]bool
+	connected []string
+}
+
+var _ options.EndpointRotator = &rotatingTransport{}
+
+func newRotatingTransport(endpoints ...string) *rotatingTransport {
+	return &rotatingTransport{
+		EventChan: fake.NewEventChan(),
+		endpoints: endpoints,
+		down:      map[string]bool{},
+	}
+}
+
+func (t *rotatingTransport) Connect(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	endpoint := t.endpoints[t.active]
+	if t.down[endpoint] {
+		return fmt.Errorf("the endpoint %s is down", endpoint)
+	}
+	t.connected = append(t.connected, endpoint)
+	return nil
+}
+
+func (t *rotatingTransport) RotateEndpoint(_ context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.active = (t.active + 1) % len(t.endpoints)
+}
+
+func (t *rotatingTransport) setDown(endpoint string, down bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.down[endpoint] = down
+}
+
+func (t *rotatingTransport) connectedEndpoints() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]string{}, t.connected...)
+}
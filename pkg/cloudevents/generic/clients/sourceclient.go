@@ -45,7 +45,8 @@ func NewCloudEventSourceClient[T generic.ResourceObject](
 	statusHashGetter generic.StatusHashGetter[T],
 	codec generic.Codec[T],
 ) (*CloudEventSourceClient[T], error) {
-	baseClient := newBaseClient(sourceOptions.SourceID, sourceOptions.CloudEventsTransport, sourceOptions.EventRateLimit)
+	baseClient := newBaseClient(sourceOptions.SourceID, sourceOptions.CloudEventsTransport, sourceOptions.EventRateLimit,
+		sourceOptions.EventSigner, sourceOptions.EventVerifier)
 	if err := baseClient.connect(ctx); err != nil {
 		return nil, err
 	}
@@ -94,7 +95,7 @@ func (c *CloudEventSourceClient[T]) Resync(ctx context.Context, clusterName stri
 	}
 
 	evt := types.NewEventBuilder(c.sourceID, eventType).WithClusterName(clusterName).NewEvent()
-	if err := evt.SetData(cloudevents.ApplicationJSON, hashes); err != nil {
+	if err := payload.EncodeStatusResyncRequest(&evt, dataContentType(c.codec), hashes); err != nil {
 		return fmt.Errorf("failed to set data to cloud event: %v", err)
 	}
 
@@ -312,3 +313,13 @@ func findResourceVersion(id string, versions []payload.ResourceVersion) int64 {
 
 	return 0
 }
+
+// dataContentType returns the content type that is used to encode the resync request data, it follows the content
+// type of the codec if the codec specifies it, otherwise, JSON is used.
+func dataContentType(codec any) string {
+	if getter, ok := codec.(generic.DataContentTypeGetter); ok && len(getter.DataContentType()) != 0 {
+		return getter.DataContentType()
+	}
+
+	return cloudevents.ApplicationJSON
+}
@@ -55,6 +55,14 @@ type Codec[T ResourceObject] interface {
 	Decode(event *cloudevents.Event) (T, error)
 }
 
+// DataContentTypeGetter is an optional interface for a Codec to specify the content type of its event data. The
+// clients encode their resync requests with the same content type, by default, the resync requests are encoded
+// with JSON. Only the work ManifestBundleCodecs are able to encode with protobuf, the cluster, CSR, lease and
+// addon codecs always encode their event data with JSON.
+type DataContentTypeGetter interface {
+	DataContentType() string
+}
+
 type CloudEventsClient[T ResourceObject] interface {
 	// Resync the resources of one source/agent by sending resync request.
 	// The second parameter is used to specify cluster name/source ID for a source/agent.
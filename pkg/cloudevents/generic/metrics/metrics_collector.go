@@ -24,6 +24,10 @@ const (
 	metricsClientIDLabel       = "client_id"
 	metricsWorkActionLabel     = "action"
 	metricsWorkCodeLabel       = "code"
+	metricsReasonLabel         = "reason"
+	metricsTokenSourceLabel    = "token_source"
+	metricsTransportLabel      = "transport"
+	metricsEndpointLabel       = "endpoint"
 )
 
 const NoneOriginalSource = "none"
@@ -75,6 +79,23 @@ var cloudeventsClientMetricsLabels = []string{
 	metricsClientIDLabel, // client_id
 }
 
+// eventVerificationMetricsLabels - Array of labels added to event verification metrics:
+var eventVerificationMetricsLabels = []string{
+	metricsSourceLabel, // source
+	metricsReasonLabel, // reason, eg, unsigned, unknown_key, invalid_signature
+}
+
+// tokenRefreshMetricsLabels - Array of labels added to token refresh metrics:
+var tokenRefreshMetricsLabels = []string{
+	metricsTokenSourceLabel, // token source, eg, file, exec
+}
+
+// activeEndpointMetricsLabels - Array of labels added to active endpoint metrics:
+var activeEndpointMetricsLabels = []string{
+	metricsTransportLabel, // transport, eg, mqtt, grpc
+	metricsEndpointLabel,  // endpoint, eg, broker1.example.com:8883
+}
+
 // workMetricsLabels - Array of labels added to manifestwork metrics:
 var workMetricsLabels = []string{
 	metricsWorkActionLabel, // action
@@ -89,6 +110,9 @@ const (
 	statusResyncDurationMetric = "status_resync_duration_seconds"
 	clientReconnectedCounter   = "client_reconnected_total"
 	workProcessedCounter       = "processed_total"
+	verificationFailedCounter  = "verification_failed_total"
+	tokenRefreshFailedCounter  = "token_refresh_failed_total"
+	activeEndpointGauge        = "active_endpoint"
 )
 
 // The cloudevents received by source counter metric is a counter with a base metric name of 'received_by_source_total'
@@ -237,6 +261,46 @@ var workProcessedCounterMetric = prometheus.NewCounterVec(
 	workMetricsLabels,
 )
 
+// The event verification failed counter metric is a counter with a base metric name of 'verification_failed_total'
+// and a help string of 'The total number of received CloudEvents that failed the signature verification.'
+// For example, 2 unsigned CloudEvents received from source1 would result in the following metrics:
+// cloudevents_verification_failed_total{source="source1",reason="unsigned"} 2
+var EventVerificationFailedCounterMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: cloudeventsMetricsSubsystem,
+		Name:      verificationFailedCounter,
+		Help:      "The total number of received CloudEvents that failed the signature verification.",
+	},
+	eventVerificationMetricsLabels,
+)
+
+// The token refresh failed counter metric is a counter with a base metric name of 'token_refresh_failed_total'
+// and a help string of 'The total number of failures to refresh the bearer token of the CloudEvents client.'
+// For example, 2 failures to re-read the token file would result in the following metrics:
+// cloudevents_token_refresh_failed_total{token_source="file"} 2
+var TokenRefreshFailedCounterMetric = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Subsystem: cloudeventsMetricsSubsystem,
+		Name:      tokenRefreshFailedCounter,
+		Help:      "The total number of failures to refresh the bearer token of the CloudEvents client.",
+	},
+	tokenRefreshMetricsLabels,
+)
+
+// The active endpoint metric is a gauge with a base metric name of 'active_endpoint' and a help string of
+// 'Whether the broker endpoint is the active endpoint of the CloudEvents client.'
+// For example, a gRPC client that fails over from broker1 to broker2 would result in the following metrics:
+// cloudevents_active_endpoint{transport="grpc",endpoint="broker1:8090"} 0
+// cloudevents_active_endpoint{transport="grpc",endpoint="broker2:8090"} 1
+var ActiveEndpointGaugeMetric = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Subsystem: cloudeventsMetricsSubsystem,
+		Name:      activeEndpointGauge,
+		Help:      "Whether the broker endpoint is the active endpoint of the CloudEvents client.",
+	},
+	activeEndpointMetricsLabels,
+)
+
 // Register the metrics
 func RegisterClientCloudEventsMetrics(register prometheus.Registerer) {
 	register.MustRegister(CloudeventsReceivedByClientCounterMetric)
@@ -253,6 +317,24 @@ func RegisterSourceCloudEventsMetrics(register prometheus.Registerer) {
 	register.MustRegister(ClientReconnectedCounterMetric)
 }
 
+// RegisterEventVerificationMetrics registers the event signature verification metrics, it is used by the sources and
+// agents that verify the received events.
+func RegisterEventVerificationMetrics(register prometheus.Registerer) {
+	register.MustRegister(EventVerificationFailedCounterMetric)
+}
+
+// RegisterTokenRefreshMetrics registers the bearer token refresh metrics, it is used by the clients that
+// authenticate with a rotating token.
+func RegisterTokenRefreshMetrics(register prometheus.Registerer) {
+	register.MustRegister(TokenRefreshFailedCounterMetric)
+}
+
+// RegisterActiveEndpointMetrics registers the active endpoint metrics, it is used by the clients that fail over
+// between multiple broker endpoints.
+func RegisterActiveEndpointMetrics(register prometheus.Registerer) {
+	register.MustRegister(ActiveEndpointGaugeMetric)
+}
+
 // ResetSourceCloudEventsMetrics resets all collectors from source
 func ResetSourceCloudEventsMetrics() {
 	CloudeventsReceivedBySourceCounterMetric.Reset()
@@ -357,3 +439,35 @@ func IncreaseWorkProcessedCounter(action, code string) {
 	}
 	workProcessedCounterMetric.With(labels).Inc()
 }
+
+// IncreaseEventVerificationFailureCounter increases the event verification failed counter metric:
+func IncreaseEventVerificationFailureCounter(source, reason string) {
+	labels := prometheus.Labels{
+		metricsSourceLabel: source,
+		metricsReasonLabel: reason,
+	}
+	EventVerificationFailedCounterMetric.With(labels).Inc()
+}
+
+// IncreaseTokenRefreshFailureCounter increases the token refresh failed counter metric:
+func IncreaseTokenRefreshFailureCounter(tokenSource string) {
+	labels := prometheus.Labels{
+		metricsTokenSourceLabel: tokenSource,
+	}
+	TokenRefreshFailedCounterMetric.With(labels).Inc()
+}
+
+// UpdateActiveEndpointMetric sets the active endpoint of the transport to 1 and the other endpoints to 0:
+func UpdateActiveEndpointMetric(transport, active string, endpoints []string) {
+	for _, endpoint := range endpoints {
+		labels := prometheus.Labels{
+			metricsTransportLabel: transport,
+			metricsEndpointLabel:  endpoint,
+		}
+		if endpoint == active {
+			ActiveEndpointGaugeMetric.With(labels).Set(1)
+			continue
+		}
+		ActiveEndpointGaugeMetric.With(labels).Set(0)
+	}
+}
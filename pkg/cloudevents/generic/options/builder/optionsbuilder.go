@@ -41,14 +41,14 @@ func (l *ConfigLoader) LoadConfig() (string, any, error) {
 			return "", nil, err
 		}
 
-		return mqttOptions.Dialer.BrokerHost, mqttOptions, nil
+		return mqttOptions.Dialer.ActiveBrokerHost(), mqttOptions, nil
 	case constants.ConfigTypeGRPC:
 		grpcOptions, err := grpc.BuildGRPCOptionsFromFlags(l.configPath)
 		if err != nil {
 			return "", nil, err
 		}
 
-		return grpcOptions.Dialer.URL, grpcOptions, nil
+		return grpcOptions.Dialer.ActiveURL(), grpcOptions, nil
 	case constants.ConfigTypePubSub:
 		pubsubOptions, err := pubsub.BuildPubSubOptionsFromFlags(l.configPath)
 		if err != nil {
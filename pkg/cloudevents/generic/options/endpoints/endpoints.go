@@ -0,0 +1,96 @@
+package endpoints
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/metrics"
+)
+
+// Policy is the policy to order the endpoints.
+type Policy string
+
+const (
+	// OrderedPolicy connects to the endpoints in the configured order.
+	OrderedPolicy Policy = "Ordered"
+	// RandomPolicy connects to the endpoints in a random order, the order is shuffled once, so the clients
+	// are spread across the endpoints.
+	RandomPolicy Policy = "Random"
+)
+
+// Validate validates the policy, an empty policy is the OrderedPolicy.
+func (p Policy) Validate() error {
+	switch p {
+	case "", OrderedPolicy, RandomPolicy:
+		return nil
+	default:
+		return fmt.Errorf("unsupported endpoint policy %q, it should be %s or %s", p, OrderedPolicy, RandomPolicy)
+	}
+}
+
+// Endpoints holds a list of broker endpoints, one of them is the active endpoint that the client connects to.
+//
+// The failover is sticky: the active endpoint is only switched to the next endpoint when the connection to it
+// fails, the client does not fail back to a preferred endpoint once the preferred endpoint recovers.
+type Endpoints struct {
+	transport string
+	addresses []string
+
+	mu     sync.Mutex
+	active int
+}
+
+// NewEndpoints returns the Endpoints of the given transport, e.g. mqtt or grpc, the first endpoint of the policy
+// order is active.
+func NewEndpoints(transport string, addresses []string, policy Policy) *Endpoints {
+	ordered := make([]string, len(addresses))
+	copy(ordered, addresses)
+	if policy == RandomPolicy {
+		rand.Shuffle(len(ordered), func(i, j int) {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		})
+	}
+
+	e := &Endpoints{transport: transport, addresses: ordered}
+	e.updateMetric()
+	return e
+}
+
+// Len returns the number of endpoints.
+func (e *Endpoints) Len() int {
+	return len(e.addresses)
+}
+
+// Active returns the active endpoint.
+func (e *Endpoints) Active() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.addresses) == 0 {
+		return ""
+	}
+	return e.addresses[e.active]
+}
+
+// Rotate switches the active endpoint to the next endpoint and returns it, the first endpoint follows the
+// last endpoint.
+func (e *Endpoints) Rotate() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.addresses) == 0 {
+		return ""
+	}
+
+	e.active = (e.active + 1) % len(e.addresses)
+	e.updateMetric()
+	return e.addresses[e.active]
+}
+
+func (e *Endpoints) updateMetric() {
+	if len(e.addresses) == 0 {
+		return
+	}
+	metrics.UpdateActiveEndpointMetric(e.transport, e.addresses[e.active], e.addresses)
+}
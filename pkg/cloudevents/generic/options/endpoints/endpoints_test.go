@@ -0,0 +1,80 @@
+package endpoints
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/metrics"
+)
+
+func TestValidate(t *testing.T) {
+	for _, policy := range []Policy{"", OrderedPolicy, RandomPolicy} {
+		if err := policy.Validate(); err != nil {
+			t.Errorf("unexpected error for policy %q: %v", policy, err)
+		}
+	}
+
+	if err := Policy("test").Validate(); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
+
+func TestOrderedEndpoints(t *testing.T) {
+	addresses := []string{"ordered1", "ordered2", "ordered3"}
+	e := NewEndpoints("test", addresses, OrderedPolicy)
+
+	assertActive(t, e, addresses, "ordered1")
+	if e.Rotate() != "ordered2" {
+		t.Errorf("expected ordered2 after rotation")
+	}
+	assertActive(t, e, addresses, "ordered2")
+
+	// the first endpoint follows the last endpoint
+	e.Rotate()
+	if e.Rotate() != "ordered1" {
+		t.Errorf("expected ordered1 after rotation")
+	}
+	assertActive(t, e, addresses, "ordered1")
+}
+
+func TestRandomEndpoints(t *testing.T) {
+	addresses := []string{"random1", "random2", "random3"}
+	e := NewEndpoints("test", addresses, RandomPolicy)
+
+	// each endpoint is connected once in a rotation round
+	visited := sets.New[string]()
+	for i := 0; i < len(addresses); i++ {
+		assertActive(t, e, addresses, e.Active())
+		visited.Insert(e.Active())
+		e.Rotate()
+	}
+	if !visited.Equal(sets.New(addresses...)) {
+		t.Errorf("expected all endpoints are rotated, but got %v", visited.UnsortedList())
+	}
+}
+
+func TestEmptyEndpoints(t *testing.T) {
+	e := NewEndpoints("test", nil, OrderedPolicy)
+	if e.Len() != 0 || e.Active() != "" || e.Rotate() != "" {
+		t.Errorf("expected no active endpoint")
+	}
+}
+
+func assertActive(t *testing.T, e *Endpoints, addresses []string, expected string) {
+	t.Helper()
+	if e.Active() != expected {
+		t.Errorf("expected active endpoint %s, but got %s", expected, e.Active())
+	}
+
+	for _, address := range addresses {
+		value := testutil.ToFloat64(metrics.ActiveEndpointGaugeMetric.WithLabelValues("test", address))
+		if address == expected && value != 1 {
+			t.Errorf("expected endpoint %s is active in the metric", address)
+		}
+		if address != expected && value != 0 {
+			t.Errorf("expected endpoint %s is inactive in the metric", address)
+		}
+	}
+}
@@ -100,3 +100,7 @@ func (o *grpcAgentTransport) Close(ctx context.Context) error {
 func (o *grpcAgentTransport) ErrorChan() <-chan error {
 	return o.errorChan
 }
+
+func (o *grpcAgentTransport) RotateEndpoint(ctx context.Context) {
+	o.Dialer.RotateEndpoint(ctx)
+}
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/cert"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/endpoints"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
 )
 
@@ -28,12 +29,23 @@ var _ cert.Connection = &GRPCDialer{}
 // GRPCDialer is a gRPC dialer that connects to a gRPC server
 // with the given URL, TLS configuration and keepalive options.
 type GRPCDialer struct {
-	URL              string
+	URL string
+	// URLs are the addresses of the gRPC servers to fail over, the URL is used if it is not set.
+	URLs []string
+	// EndpointPolicy is the order to connect the URLs, by default is Ordered.
+	EndpointPolicy   endpoints.Policy
 	KeepAliveOptions KeepAliveOptions
 	TLSConfig        *tls.Config
 	Token            string
-	mu               sync.Mutex       // Mutex to protect the connection.
-	conn             *grpc.ClientConn // Cached gRPC client connection.
+	// TokenSource provides the bearer token for each RPC, it takes precedence over the Token, so a rotated
+	// token is used without redialing.
+	TokenSource oauth2.TokenSource
+	mu          sync.Mutex       // Mutex to protect the connection.
+	conn        *grpc.ClientConn // Cached gRPC client connection.
+	connURL     string           // The server address of the cached connection.
+
+	endpointsOnce sync.Once
+	endpoints     *endpoints.Endpoints
 }
 
 // KeepAliveOptions holds the keepalive options for the gRPC client.
@@ -52,9 +64,19 @@ func (d *GRPCDialer) Dial() (*grpc.ClientConn, error) {
 	// lock the connection to ensure the connection is not created by multiple goroutines concurrently.
 	d.mu.Lock()
 	defer d.mu.Unlock()
-	if d.conn != nil && (d.conn.GetState() == connectivity.Connecting || d.conn.GetState() == connectivity.Ready) {
+	url := d.ActiveURL()
+	if d.conn != nil && d.connURL == url &&
+		(d.conn.GetState() == connectivity.Connecting || d.conn.GetState() == connectivity.Ready) {
 		return d.conn, nil
 	}
+	// The connection to the previous server is not used anymore once the endpoint is rotated, close it before
+	// connecting to the next server.
+	if d.conn != nil && d.connURL != url {
+		if err := d.conn.Close(); err != nil {
+			klog.Background().Error(err, "failed to close the grpc connection", "grpcURL", d.connURL)
+		}
+		d.conn = nil
+	}
 	// Prepare gRPC dial options.
 	dialOpts := []grpc.DialOption{}
 	if d.KeepAliveOptions.Enable {
@@ -64,42 +86,76 @@ func (d *GRPCDialer) Dial() (*grpc.ClientConn, error) {
 			PermitWithoutStream: d.KeepAliveOptions.PermitWithoutStream,
 		}))
 	}
+	tokenSource := d.TokenSource
+	if tokenSource == nil && len(d.Token) != 0 {
+		tokenSource = oauth2.StaticTokenSource(&oauth2.Token{
+			AccessToken: d.Token,
+		})
+	}
 	if d.TLSConfig != nil {
 		// Enable TLS
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(d.TLSConfig)))
-		if len(d.Token) != 0 {
-			perRPCCred := oauth.TokenSource{
-				TokenSource: oauth2.StaticTokenSource(&oauth2.Token{
-					AccessToken: d.Token,
-				})}
-			// Add per-RPC credentials to the dial options.
-			dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(perRPCCred))
+		if tokenSource != nil {
+			// Add per-RPC credentials to the dial options, the token is got from the token source for each RPC.
+			dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(oauth.TokenSource{TokenSource: tokenSource}))
 		}
 
 		// Establish a TLS connection to the gRPC server.
-		conn, err := grpc.NewClient(d.URL, dialOpts...)
+		conn, err := grpc.NewClient(url, dialOpts...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to grpc server %s, %v", d.URL, err)
+			return nil, fmt.Errorf("failed to connect to grpc server %s, %v", url, err)
 		}
 
 		// Cache the connection for future use.
 		d.conn = conn
+		d.connURL = url
 		return d.conn, nil
 	}
 
+	// The bearer token is only sent over a TLS connection.
+	if tokenSource != nil {
+		return nil, fmt.Errorf("failed to connect to grpc server %s, setting token requires TLS", url)
+	}
+
 	// Insecure connection option; should not be used in production.
 	dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	conn, err := grpc.NewClient(d.URL, dialOpts...)
+	conn, err := grpc.NewClient(url, dialOpts...)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to grpc server %s, %v", d.URL, err)
+		return nil, fmt.Errorf("failed to connect to grpc server %s, %v", url, err)
 	}
 
 	// Cache the connection for future use.
 	d.conn = conn
+	d.connURL = url
 	return d.conn, nil
 }
 
+// ActiveURL returns the address of the gRPC server that the dialer connects to.
+func (d *GRPCDialer) ActiveURL() string {
+	return d.getEndpoints().Active()
+}
+
+// RotateEndpoint switches to the next gRPC server, the next dial connects to it. The gRPC connection is
+// established lazily, so the failover is driven by the client when the connection to the active server fails.
+func (d *GRPCDialer) RotateEndpoint(ctx context.Context) {
+	servers := d.getEndpoints()
+	if servers.Len() > 1 {
+		klog.FromContext(ctx).Info("rotate the grpc server", "grpcURL", servers.Rotate())
+	}
+}
+
+func (d *GRPCDialer) getEndpoints() *endpoints.Endpoints {
+	d.endpointsOnce.Do(func() {
+		urls := d.URLs
+		if len(urls) == 0 {
+			urls = []string{d.URL}
+		}
+		d.endpoints = endpoints.NewEndpoints("grpc", urls, d.EndpointPolicy)
+	})
+	return d.endpoints
+}
+
 // Close closes the gRPC client connection.
 func (d *GRPCDialer) Close() error {
 	d.mu.Lock()
@@ -124,10 +180,19 @@ type GRPCConfig struct {
 	// URL is the address of the gRPC server (host:port).
 	URL string `json:"url" yaml:"url"`
 
-	// TokenFile is the file path to a token file for authentication.
+	// URLs are the addresses of the gRPC servers (host:port) to fail over, if it is set, the URL is ignored.
+	URLs []string `json:"urls,omitempty" yaml:"urls,omitempty"`
+
+	// EndpointPolicy is the order to connect the URLs, Ordered or Random, by default is Ordered.
+	EndpointPolicy endpoints.Policy `json:"endpointPolicy,omitempty" yaml:"endpointPolicy,omitempty"`
+
+	// TokenFile is the file path to a token file for authentication, the file is re-read once it is changed.
 	TokenFile string `json:"tokenFile,omitempty" yaml:"tokenFile,omitempty"`
 	// Token is the token for authentication
 	Token string `json:"token" yaml:"token"`
+	// Exec specifies a credential plugin to provide the token for authentication, it is ignored if the
+	// Token or TokenFile is set.
+	Exec *ExecConfig `json:"exec,omitempty" yaml:"exec,omitempty"`
 
 	// keepalive options
 	KeepAliveConfig KeepAliveConfig `json:"keepAliveConfig,omitempty" yaml:"keepAliveConfig,omitempty"`
@@ -180,30 +245,45 @@ func BuildGRPCOptionsFromFlags(configPath string) (*GRPCOptions, error) {
 		return nil, err
 	}
 
-	if config.URL == "" {
+	if config.URL == "" && len(config.URLs) == 0 {
 		return nil, fmt.Errorf("url is required")
 	}
 
+	if err := config.EndpointPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
 
 	token := config.Token
-	if config.Token == "" && config.TokenFile != "" {
-		tokenBytes, err := os.ReadFile(config.TokenFile)
+	var tokenSource oauth2.TokenSource
+	switch {
+	case config.Token != "":
+	case config.TokenFile != "":
+		tokenSource, err = NewFileTokenSource(config.TokenFile)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read token file %s, %v", config.TokenFile, err)
+			return nil, err
+		}
+	case config.Exec != nil:
+		tokenSource, err = NewExecTokenSource(config.Exec)
+		if err != nil {
+			return nil, err
 		}
-		token = string(tokenBytes)
 	}
-	if token != "" && len(config.CAData) == 0 {
+	hasToken := token != "" || tokenSource != nil
+	if hasToken && len(config.CAData) == 0 {
 		return nil, fmt.Errorf("setting token requires authority certificates")
 	}
 
 	options := &GRPCOptions{
 		Dialer: &GRPCDialer{
-			URL:   config.URL,
-			Token: token,
+			URL:            config.URL,
+			URLs:           config.URLs,
+			EndpointPolicy: config.EndpointPolicy,
+			Token:          token,
+			TokenSource:    tokenSource,
 		},
 	}
 
@@ -229,7 +309,7 @@ func BuildGRPCOptionsFromFlags(configPath string) (*GRPCOptions, error) {
 	// If token or client certs are provided, set up TLS configuration for the gRPC connection,
 	// the certificates will be reloaded periodically.
 	// Note: setting token requires authority certificates
-	if token != "" || config.HasCerts() {
+	if hasToken || config.HasCerts() {
 		options.Dialer.TLSConfig, err = cert.AutoLoadTLSConfig(
 			config.CertConfig,
 			func() (*cert.CertConfig, error) {
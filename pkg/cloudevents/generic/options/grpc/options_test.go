@@ -1,13 +1,21 @@
 package grpc
 
 import (
+	"context"
+	"net"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/utils/ptr"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/endpoints"
 	clienttesting "open-cluster-management.io/sdk-go/pkg/testing"
 )
 
@@ -33,6 +41,38 @@ func TestBuildGRPCOptionsFromFlags(t *testing.T) {
 			config:           "{\"url\":\"test\",\"token\":\"test\"}",
 			expectedErrorMsg: "setting token requires authority certificates",
 		},
+		{
+			name:             "exec config without caFile",
+			config:           "{\"url\":\"test\",\"exec\":{\"command\":\"test\"}}",
+			expectedErrorMsg: "setting token requires authority certificates",
+		},
+		{
+			name:   "token file does not exist",
+			config: "{\"url\":\"test\",\"tokenFile\":\"/none/token\"}",
+			expectedErrorMsg: "failed to read token file /none/token, " +
+				"stat /none/token: no such file or directory",
+		},
+		{
+			name:             "unsupported endpoint policy",
+			config:           "{\"urls\":[\"test1\",\"test2\"],\"endpointPolicy\":\"test\"}",
+			expectedErrorMsg: "unsupported endpoint policy \"test\", it should be Ordered or Random",
+		},
+		{
+			name:   "multiple urls",
+			config: "{\"urls\":[\"test1\",\"test2\"],\"endpointPolicy\":\"Random\"}",
+			expectedOptions: &GRPCOptions{
+				Dialer: &GRPCDialer{
+					URLs:           []string{"test1", "test2"},
+					EndpointPolicy: endpoints.RandomPolicy,
+					KeepAliveOptions: KeepAliveOptions{
+						Enable:              false,
+						Time:                30 * time.Second,
+						Timeout:             10 * time.Second,
+						PermitWithoutStream: false,
+					},
+				},
+			},
+		},
 		{
 			name:   "customized options",
 			config: "{\"url\":\"test\"}",
@@ -117,3 +157,65 @@ func TestBuildGRPCOptionsFromFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestGRPCDialerFailover(t *testing.T) {
+	// the first server is down
+	down := newHealthServer(t)
+	down.Stop()
+	up := newHealthServer(t)
+	defer up.Stop()
+
+	dialer := &GRPCDialer{URLs: []string{down.url, up.url}}
+	defer dialer.Close()
+
+	if err := checkHealth(dialer); err == nil {
+		t.Errorf("expected the health check against the first server fails")
+	}
+	staleConn := dialer.conn
+
+	// the dialer connects to the second server after the rotation and sticks on it
+	dialer.RotateEndpoint(context.Background())
+	if dialer.ActiveURL() != up.url {
+		t.Errorf("expected the second server is active, but got %s", dialer.ActiveURL())
+	}
+	if err := checkHealth(dialer); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if staleConn.GetState() != connectivity.Shutdown {
+		t.Errorf("expected the connection to the first server is closed, but got %s", staleConn.GetState())
+	}
+	if err := checkHealth(dialer); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+type healthServer struct {
+	*grpc.Server
+	url string
+}
+
+func newHealthServer(t *testing.T) *healthServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	return &healthServer{Server: server, url: ln.Addr().String()}
+}
+
+func checkHealth(dialer *GRPCDialer) error {
+	conn, err := dialer.Dial()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
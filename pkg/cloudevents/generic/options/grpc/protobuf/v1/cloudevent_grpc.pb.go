@@ -25,8 +25,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	CloudEventService_Publish_FullMethodName   = "/io.cloudevents.v1.CloudEventService/Publish"
-	CloudEventService_Subscribe_FullMethodName = "/io.cloudevents.v1.CloudEventService/Subscribe"
+	CloudEventService_Publish_FullMethodName       = "/io.cloudevents.v1.CloudEventService/Publish"
+	CloudEventService_Subscribe_FullMethodName     = "/io.cloudevents.v1.CloudEventService/Subscribe"
+	CloudEventService_PublishStream_FullMethodName = "/io.cloudevents.v1.CloudEventService/PublishStream"
 )
 
 // CloudEventServiceClient is the client API for CloudEventService service.
@@ -35,6 +36,9 @@ const (
 type CloudEventServiceClient interface {
 	Publish(ctx context.Context, in *PublishRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Subscribe(ctx context.Context, in *SubscriptionRequest, opts ...grpc.CallOption) (CloudEventService_SubscribeClient, error)
+	// PublishStream publishes CloudEvents over a long-lived stream, each published
+	// CloudEvent is acknowledged by a PublishStreamResponse in order.
+	PublishStream(ctx context.Context, opts ...grpc.CallOption) (CloudEventService_PublishStreamClient, error)
 }
 
 type cloudEventServiceClient struct {
@@ -86,12 +90,46 @@ func (x *cloudEventServiceSubscribeClient) Recv() (*CloudEvent, error) {
 	return m, nil
 }
 
+func (c *cloudEventServiceClient) PublishStream(ctx context.Context, opts ...grpc.CallOption) (CloudEventService_PublishStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CloudEventService_ServiceDesc.Streams[1], CloudEventService_PublishStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cloudEventServicePublishStreamClient{stream}
+	return x, nil
+}
+
+type CloudEventService_PublishStreamClient interface {
+	Send(*PublishStreamRequest) error
+	Recv() (*PublishStreamResponse, error)
+	grpc.ClientStream
+}
+
+type cloudEventServicePublishStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *cloudEventServicePublishStreamClient) Send(m *PublishStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *cloudEventServicePublishStreamClient) Recv() (*PublishStreamResponse, error) {
+	m := new(PublishStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CloudEventServiceServer is the server API for CloudEventService service.
 // All implementations must embed UnimplementedCloudEventServiceServer
 // for forward compatibility
 type CloudEventServiceServer interface {
 	Publish(context.Context, *PublishRequest) (*empty.Empty, error)
 	Subscribe(*SubscriptionRequest, CloudEventService_SubscribeServer) error
+	// PublishStream publishes CloudEvents over a long-lived stream, each published
+	// CloudEvent is acknowledged by a PublishStreamResponse in order.
+	PublishStream(CloudEventService_PublishStreamServer) error
 	mustEmbedUnimplementedCloudEventServiceServer()
 }
 
@@ -105,6 +143,9 @@ func (UnimplementedCloudEventServiceServer) Publish(context.Context, *PublishReq
 func (UnimplementedCloudEventServiceServer) Subscribe(*SubscriptionRequest, CloudEventService_SubscribeServer) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedCloudEventServiceServer) PublishStream(CloudEventService_PublishStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method PublishStream not implemented")
+}
 func (UnimplementedCloudEventServiceServer) mustEmbedUnimplementedCloudEventServiceServer() {}
 
 // UnsafeCloudEventServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -157,6 +198,32 @@ func (x *cloudEventServiceSubscribeServer) Send(m *CloudEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _CloudEventService_PublishStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CloudEventServiceServer).PublishStream(&cloudEventServicePublishStreamServer{stream})
+}
+
+type CloudEventService_PublishStreamServer interface {
+	Send(*PublishStreamResponse) error
+	Recv() (*PublishStreamRequest, error)
+	grpc.ServerStream
+}
+
+type cloudEventServicePublishStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *cloudEventServicePublishStreamServer) Send(m *PublishStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *cloudEventServicePublishStreamServer) Recv() (*PublishStreamRequest, error) {
+	m := new(PublishStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // CloudEventService_ServiceDesc is the grpc.ServiceDesc for CloudEventService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -175,6 +242,12 @@ var CloudEventService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _CloudEventService_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PublishStream",
+			Handler:       _CloudEventService_PublishStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "cloudevent.proto",
 }
@@ -18,9 +18,9 @@ import (
 const (
 	prefix      = "ce-"
 	contenttype = "contenttype"
-	// dataSchema  = "dataschema"
-	subject   = "subject"
-	timestamp = "time"
+	dataSchema  = "dataschema"
+	subject     = "subject"
+	timestamp   = "time"
 )
 
 var specs = spec.WithPrefix(prefix)
@@ -140,6 +140,10 @@ func (m *Message) ReadBinary(ctx context.Context, encoder binding.BinaryWriter)
 		return encoder.SetData(bytes.NewBuffer(m.internal.GetBinaryData()))
 	}
 
+	if m.internal.GetProtoData() != nil {
+		return encoder.SetData(bytes.NewBuffer(m.internal.GetProtoData().GetValue()))
+	}
+
 	return nil
 }
 
@@ -13,6 +13,10 @@ type SubscribeOption struct {
 	Source      string
 	ClusterName string
 	DataType    string // data type for the client, eg. "io.open-cluster-management.works.v1alpha1.manifestbundles"
+	// ResourceName and LabelSelector are optional, they narrow the subscription to the resource with the name or
+	// the resources whose labels match the selector.
+	ResourceName  string
+	LabelSelector string
 }
 
 // WithSubscribeOption sets the Subscribe configuration for the client.
@@ -116,9 +116,11 @@ func (p *Protocol) OpenInbound(ctx context.Context) error {
 
 	logger := klog.FromContext(ctx)
 	subClient, err := p.client.Subscribe(ctx, &pbv1.SubscriptionRequest{
-		Source:      p.subscribeOption.Source,
-		ClusterName: p.subscribeOption.ClusterName,
-		DataType:    p.subscribeOption.DataType,
+		Source:        p.subscribeOption.Source,
+		ClusterName:   p.subscribeOption.ClusterName,
+		DataType:      p.subscribeOption.DataType,
+		ResourceName:  p.subscribeOption.ResourceName,
+		LabelSelector: p.subscribeOption.LabelSelector,
 	})
 	if err != nil {
 		return err
@@ -10,9 +10,11 @@ import (
 	"github.com/cloudevents/sdk-go/v2/binding/format"
 	"github.com/cloudevents/sdk-go/v2/binding/spec"
 	"github.com/cloudevents/sdk-go/v2/types"
+	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
 // WritePBMessage fills the provided pubMessage with the message m.
@@ -81,6 +83,18 @@ func (b *pbEventWriter) SetData(reader io.Reader) error {
 		}
 	}
 
+	// the protobuf data is carried by the proto_data with its type URL, the type URL is specified by the
+	// dataschema attribute of the event.
+	if b.Attributes[contenttype].GetCeString() == cetypes.ApplicationProtobuf {
+		b.Data = &pbv1.CloudEvent_ProtoData{
+			ProtoData: &anypb.Any{
+				TypeUrl: typeURLFrom(b.Attributes[prefix+dataSchema]),
+				Value:   buf.Bytes(),
+			},
+		}
+		return nil
+	}
+
 	b.Data = &pbv1.CloudEvent_BinaryData{
 		BinaryData: buf.Bytes(),
 	}
@@ -173,6 +187,18 @@ func (b *pbEventWriter) SetExtension(name string, value interface{}) error {
 	return nil
 }
 
+// typeURLFrom returns the type URL of the protobuf data from the dataschema attribute.
+func typeURLFrom(attr *pbv1.CloudEventAttributeValue) string {
+	switch {
+	case attr.GetCeUri() != "":
+		return attr.GetCeUri()
+	case attr.GetCeUriRef() != "":
+		return attr.GetCeUriRef()
+	default:
+		return attr.GetCeString()
+	}
+}
+
 func attributeFor(v interface{}) (*pbv1.CloudEventAttributeValue, error) {
 	vv, err := types.Validate(v)
 	if err != nil {
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
 func TestEncodeMessage(t *testing.T) {
@@ -60,3 +61,26 @@ func TestEncodeMessage(t *testing.T) {
 		}
 	})
 }
+
+func TestEncodeProtobufDataMessage(t *testing.T) {
+	ctx := context.Background()
+
+	eventIn := event.New()
+	eventIn.SetID("test")
+	eventIn.SetSource("test-source")
+	eventIn.SetType("test-type")
+	eventIn.SetDataSchema("type.googleapis.com/io.open_cluster_management.test")
+	require.NoError(t, eventIn.SetData(cetypes.ApplicationProtobuf, []byte{0x0a, 0x03, 0x61, 0x62, 0x63}))
+
+	pbEvt := &pbv1.CloudEvent{}
+	require.NoError(t, WritePBMessage(ctx, (*binding.EventMessage)(&eventIn), pbEvt))
+
+	// the protobuf data should be carried by the proto_data
+	require.NotNil(t, pbEvt.GetProtoData())
+	require.Nil(t, pbEvt.GetBinaryData())
+	require.Equal(t, "type.googleapis.com/io.open_cluster_management.test", pbEvt.GetProtoData().GetTypeUrl())
+
+	eventOut, err := binding.ToEvent(ctx, NewMessage(pbEvt))
+	require.NoError(t, err)
+	test.AssertEventEquals(t, eventIn, *eventOut)
+}
@@ -95,3 +95,7 @@ func (o *gRPCSourceTransport) Close(ctx context.Context) error {
 func (o *gRPCSourceTransport) ErrorChan() <-chan error {
 	return o.errorChan
 }
+
+func (o *gRPCSourceTransport) RotateEndpoint(ctx context.Context) {
+	o.Dialer.RotateEndpoint(ctx)
+}
@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthenticationv1 "k8s.io/client-go/pkg/apis/clientauthentication/v1"
+	"k8s.io/klog/v2"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/metrics"
+)
+
+const (
+	fileTokenSource = "file"
+	execTokenSource = "exec"
+
+	// execInfoEnv is the environment variable that passes the ExecCredential to the credential plugin.
+	execInfoEnv = "KUBERNETES_EXEC_INFO"
+
+	defaultExecAPIVersion = "client.authentication.k8s.io/v1"
+
+	// tokenExpirySkew is the duration before the token expiration that the token is refreshed.
+	tokenExpirySkew = 10 * time.Second
+)
+
+// ExecConfig specifies a command to provide the bearer token, it follows the kubeconfig exec credential plugin
+// contract: the command outputs an ExecCredential to stdout, and the token is refreshed by running the command
+// again once it expires.
+type ExecConfig struct {
+	// Command to execute.
+	Command string `json:"command" yaml:"command"`
+	// Args to pass to the command when executing it.
+	Args []string `json:"args,omitempty" yaml:"args,omitempty"`
+	// Env defines additional environment variables to expose to the process.
+	Env []ExecEnvVar `json:"env,omitempty" yaml:"env,omitempty"`
+	// APIVersion is the version of the ExecCredential, defaults to client.authentication.k8s.io/v1.
+	APIVersion string `json:"apiVersion,omitempty" yaml:"apiVersion,omitempty"`
+}
+
+// ExecEnvVar is used for setting environment variables when executing the credential plugin.
+type ExecEnvVar struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// fileTokenSourceImpl reads the token from a file, the file is re-read when it is changed, so the rotated token,
+// e.g. a projected service account token, is used by the subsequent RPCs without redialing.
+type fileTokenSourceImpl struct {
+	path string
+
+	mu      sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileTokenSource returns a token source that reads the token from the given file. The file is checked on
+// each RPC and re-read once it is changed, if the file cannot be read, the last read token is used.
+func NewFileTokenSource(path string) (oauth2.TokenSource, error) {
+	ts := &fileTokenSourceImpl{path: path}
+	if _, err := ts.Token(); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+func (ts *fileTokenSourceImpl) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	info, err := os.Stat(ts.path)
+	if err == nil && info.ModTime().Equal(ts.modTime) && info.Size() == ts.size && len(ts.token) != 0 {
+		return &oauth2.Token{AccessToken: ts.token}, nil
+	}
+
+	var data []byte
+	if err == nil {
+		data, err = os.ReadFile(ts.path)
+	}
+	if err == nil && len(bytes.TrimSpace(data)) == 0 {
+		err = fmt.Errorf("the token file is empty")
+	}
+	if err != nil {
+		metrics.IncreaseTokenRefreshFailureCounter(fileTokenSource)
+		if len(ts.token) != 0 {
+			klog.Errorf("failed to read token file %s, use the last read token: %v", ts.path, err)
+			return &oauth2.Token{AccessToken: ts.token}, nil
+		}
+		return nil, fmt.Errorf("failed to read token file %s, %v", ts.path, err)
+	}
+
+	ts.token = strings.TrimSpace(string(data))
+	ts.modTime = info.ModTime()
+	ts.size = info.Size()
+	return &oauth2.Token{AccessToken: ts.token}, nil
+}
+
+// execTokenSourceImpl runs a credential plugin to get the token, the token is cached until it expires.
+type execTokenSourceImpl struct {
+	config *ExecConfig
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// NewExecTokenSource returns a token source that gets the token from the given credential plugin. The plugin
+// runs again when the token is about to expire, a token without expiration is used until the process exits.
+func NewExecTokenSource(config *ExecConfig) (oauth2.TokenSource, error) {
+	if config == nil || len(config.Command) == 0 {
+		return nil, fmt.Errorf("the exec command is required")
+	}
+	return &execTokenSourceImpl{config: config}, nil
+}
+
+func (ts *execTokenSourceImpl) Token() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != nil && (ts.token.Expiry.IsZero() || time.Now().Add(tokenExpirySkew).Before(ts.token.Expiry)) {
+		return ts.token, nil
+	}
+
+	token, err := ts.runCommand()
+	if err != nil {
+		metrics.IncreaseTokenRefreshFailureCounter(execTokenSource)
+		// use the last token until it actually expires
+		if ts.token != nil && time.Now().Before(ts.token.Expiry) {
+			klog.Errorf("failed to refresh token with exec command %s, use the last token: %v", ts.config.Command, err)
+			return ts.token, nil
+		}
+		return nil, err
+	}
+
+	ts.token = token
+	return ts.token, nil
+}
+
+func (ts *execTokenSourceImpl) runCommand() (*oauth2.Token, error) {
+	apiVersion := ts.config.APIVersion
+	if len(apiVersion) == 0 {
+		apiVersion = defaultExecAPIVersion
+	}
+
+	execInfo, err := json.Marshal(&clientauthenticationv1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{APIVersion: apiVersion, Kind: "ExecCredential"},
+		Spec:     clientauthenticationv1.ExecCredentialSpec{Interactive: false},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	env := os.Environ()
+	for _, e := range ts.config.Env {
+		env = append(env, fmt.Sprintf("%s=%s", e.Name, e.Value))
+	}
+	env = append(env, fmt.Sprintf("%s=%s", execInfoEnv, execInfo))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, ts.config.Command, ts.config.Args...) //#nosec G204 -- the command is configured by the client
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run exec command %s: %v, %s", ts.config.Command, err, stderr.String())
+	}
+
+	// the v1beta1 and v1 ExecCredential have the same status fields
+	cred := &clientauthenticationv1.ExecCredential{}
+	if err := json.Unmarshal(stdout.Bytes(), cred); err != nil {
+		return nil, fmt.Errorf("failed to decode the output of exec command %s: %v", ts.config.Command, err)
+	}
+	if cred.APIVersion != apiVersion {
+		return nil, fmt.Errorf("exec command %s returned %q, but expected %q", ts.config.Command, cred.APIVersion, apiVersion)
+	}
+	if cred.Status == nil || len(cred.Status.Token) == 0 {
+		return nil, fmt.Errorf("exec command %s did not return a token", ts.config.Command)
+	}
+
+	token := &oauth2.Token{AccessToken: cred.Status.Token}
+	if cred.Status.ExpirationTimestamp != nil {
+		token.Expiry = cred.Status.ExpirationTimestamp.Time
+	}
+	return token, nil
+}
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/oauth2"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/metrics"
+)
+
+func TestFileTokenSource(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if _, err := NewFileTokenSource(tokenFile); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+
+	if err := os.WriteFile(tokenFile, []byte("token1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := NewFileTokenSource(tokenFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertToken(t, ts, "token1")
+
+	// the rotated token is read once the file is changed
+	if err := os.WriteFile(tokenFile, []byte("token2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(tokenFile, future, future); err != nil {
+		t.Fatal(err)
+	}
+	assertToken(t, ts, "token2")
+
+	// the last read token is used if the file cannot be read
+	failures := testutil.ToFloat64(metrics.TokenRefreshFailedCounterMetric.WithLabelValues(fileTokenSource))
+	if err := os.Remove(tokenFile); err != nil {
+		t.Fatal(err)
+	}
+	assertToken(t, ts, "token2")
+	if testutil.ToFloat64(metrics.TokenRefreshFailedCounterMetric.WithLabelValues(fileTokenSource)) != failures+1 {
+		t.Errorf("expected the token refresh failure is counted")
+	}
+}
+
+func TestExecTokenSource(t *testing.T) {
+	dir := t.TempDir()
+	countFile := filepath.Join(dir, "count")
+	plugin := filepath.Join(dir, "plugin.sh")
+
+	// the plugin returns the token with a sequence number, so that the plugin runs can be checked
+	writePlugin := func(expiration time.Time) {
+		content := fmt.Sprintf("#!/bin/sh\n"+
+			"echo x >> %s\n"+
+			"test -n \"$KUBERNETES_EXEC_INFO\" || exit 1\n"+
+			"echo '{\"apiVersion\":\"client.authentication.k8s.io/v1\",\"kind\":\"ExecCredential\",'"+
+			"'\"status\":{\"token\":\"'$TOKEN_PREFIX-$(wc -l < %s | tr -d ' ')'\",\"expirationTimestamp\":\"%s\"}}'\n",
+			countFile, countFile, expiration.UTC().Format(time.RFC3339))
+		if err := os.WriteFile(plugin, []byte(content), 0700); err != nil { //#nosec G306
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := NewExecTokenSource(&ExecConfig{}); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+
+	config := &ExecConfig{Command: plugin, Env: []ExecEnvVar{{Name: "TOKEN_PREFIX", Value: "test"}}}
+
+	// the token is cached until it expires
+	writePlugin(time.Now().Add(time.Hour))
+	ts, err := NewExecTokenSource(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertToken(t, ts, "test-1")
+	assertToken(t, ts, "test-1")
+
+	// the plugin runs again once the token is about to expire
+	writePlugin(time.Now().Add(5 * time.Second))
+	ts, err = NewExecTokenSource(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertToken(t, ts, "test-2")
+	assertToken(t, ts, "test-3")
+
+	// the refresh failure is counted
+	failures := testutil.ToFloat64(metrics.TokenRefreshFailedCounterMetric.WithLabelValues(execTokenSource))
+	ts, err = NewExecTokenSource(&ExecConfig{Command: filepath.Join(dir, "none")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ts.Token(); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+	if testutil.ToFloat64(metrics.TokenRefreshFailedCounterMetric.WithLabelValues(execTokenSource)) != failures+1 {
+		t.Errorf("expected the token refresh failure is counted")
+	}
+}
+
+func assertToken(t *testing.T, ts oauth2.TokenSource, expected string) {
+	t.Helper()
+	token, err := ts.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != expected {
+		t.Errorf("expected token %q, but got %q", expected, token.AccessToken)
+	}
+}
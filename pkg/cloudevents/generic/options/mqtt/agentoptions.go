@@ -116,6 +116,10 @@ func (o *mqttAgentTransport) ErrorChan() <-chan error {
 	return o.errorChan
 }
 
+func (o *mqttAgentTransport) RotateEndpoint(ctx context.Context) {
+	o.Dialer.RotateEndpoint(ctx)
+}
+
 func AgentPubTopic(ctx context.Context, o *MQTTOptions, clusterName string, evtCtx cloudevents.EventContext) (string, error) {
 	logger := klog.FromContext(ctx)
 
@@ -8,6 +8,7 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/errors"
 
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/cert"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/endpoints"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
@@ -34,31 +36,79 @@ const (
 type MQTTDialer struct {
 	TLSConfig  *tls.Config
 	BrokerHost string
-	Timeout    time.Duration
-
-	conn net.Conn
+	// BrokerHosts are the hosts of the MQTT brokers to fail over, the BrokerHost is used if it is not set.
+	BrokerHosts []string
+	// EndpointPolicy is the order to connect the BrokerHosts, by default is Ordered.
+	EndpointPolicy endpoints.Policy
+	Timeout        time.Duration
+
+	conn          net.Conn
+	endpointsOnce sync.Once
+	endpoints     *endpoints.Endpoints
 }
 
+// Dial connects to the active broker, if it fails, the dialer fails over to the next brokers in turn until a
+// broker is connected or all of the brokers are failed.
 func (d *MQTTDialer) Dial() (net.Conn, error) {
+	brokers := d.getEndpoints()
+
+	var errs []error
+	for i := 0; i < brokers.Len(); i++ {
+		conn, err := d.dial(brokers.Active())
+		if err == nil {
+			// ensure parallel writes are thread-Safe
+			d.conn = packets.NewThreadSafeConn(conn)
+			return d.conn, nil
+		}
+
+		errs = append(errs, err)
+		if i < brokers.Len()-1 {
+			klog.Infof("failed to connect to MQTT broker, fail over to the next broker: %v", err)
+			brokers.Rotate()
+		}
+	}
+
+	return nil, errors.NewAggregate(errs)
+}
+
+func (d *MQTTDialer) dial(brokerHost string) (net.Conn, error) {
 	if d.TLSConfig != nil {
-		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: d.Timeout}, "tcp", d.BrokerHost, d.TLSConfig)
+		conn, err := tls.DialWithDialer(&net.Dialer{Timeout: d.Timeout}, "tcp", brokerHost, d.TLSConfig)
 		if err != nil {
-			return nil, fmt.Errorf("failed to connect to MQTT broker %s, %v", d.BrokerHost, err)
+			return nil, fmt.Errorf("failed to connect to MQTT broker %s, %v", brokerHost, err)
 		}
-
-		// ensure parallel writes are thread-Safe
-		d.conn = packets.NewThreadSafeConn(conn)
-		return d.conn, nil
+		return conn, nil
 	}
 
-	conn, err := net.DialTimeout("tcp", d.BrokerHost, d.Timeout)
+	conn, err := net.DialTimeout("tcp", brokerHost, d.Timeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MQTT broker %s, %v", d.BrokerHost, err)
+		return nil, fmt.Errorf("failed to connect to MQTT broker %s, %v", brokerHost, err)
 	}
+	return conn, nil
+}
 
-	// ensure parallel writes are thread-Safe
-	d.conn = packets.NewThreadSafeConn(conn)
-	return d.conn, nil
+// ActiveBrokerHost returns the host of the broker that the dialer connects to.
+func (d *MQTTDialer) ActiveBrokerHost() string {
+	return d.getEndpoints().Active()
+}
+
+// RotateEndpoint switches to the next broker, the next dial connects to it.
+func (d *MQTTDialer) RotateEndpoint(ctx context.Context) {
+	brokers := d.getEndpoints()
+	if brokers.Len() > 1 {
+		klog.FromContext(ctx).Info("rotate the MQTT broker", "brokerHost", brokers.Rotate())
+	}
+}
+
+func (d *MQTTDialer) getEndpoints() *endpoints.Endpoints {
+	d.endpointsOnce.Do(func() {
+		hosts := d.BrokerHosts
+		if len(hosts) == 0 {
+			hosts = []string{d.BrokerHost}
+		}
+		d.endpoints = endpoints.NewEndpoints("mqtt", hosts, d.EndpointPolicy)
+	})
+	return d.endpoints
 }
 
 func (d *MQTTDialer) Close() error {
@@ -87,6 +137,13 @@ type MQTTConfig struct {
 	// BrokerHost is the host of the MQTT broker (hostname:port).
 	BrokerHost string `json:"brokerHost" yaml:"brokerHost"`
 
+	// BrokerHosts are the hosts of the MQTT brokers (hostname:port) to fail over, if it is set, the BrokerHost
+	// is ignored.
+	BrokerHosts []string `json:"brokerHosts,omitempty" yaml:"brokerHosts,omitempty"`
+
+	// EndpointPolicy is the order to connect the BrokerHosts, Ordered or Random, by default is Ordered.
+	EndpointPolicy endpoints.Policy `json:"endpointPolicy,omitempty" yaml:"endpointPolicy,omitempty"`
+
 	// Username is the username for basic authentication to connect the MQTT broker.
 	Username string `json:"username,omitempty" yaml:"username,omitempty"`
 	// Password is the password for basic authentication to connect the MQTT broker.
@@ -132,10 +189,14 @@ func BuildMQTTOptionsFromFlags(configPath string) (*MQTTOptions, error) {
 		return nil, err
 	}
 
-	if config.BrokerHost == "" {
+	if config.BrokerHost == "" && len(config.BrokerHosts) == 0 {
 		return nil, fmt.Errorf("brokerHost is required")
 	}
 
+	if err := config.EndpointPolicy.Validate(); err != nil {
+		return nil, err
+	}
+
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
@@ -171,8 +232,10 @@ func BuildMQTTOptionsFromFlags(configPath string) (*MQTTOptions, error) {
 	}
 
 	options.Dialer = &MQTTDialer{
-		BrokerHost: config.BrokerHost,
-		Timeout:    dialTimeout,
+		BrokerHost:     config.BrokerHost,
+		BrokerHosts:    config.BrokerHosts,
+		EndpointPolicy: config.EndpointPolicy,
+		Timeout:        dialTimeout,
 	}
 
 	if config.HasCerts() {
@@ -9,7 +9,8 @@ import (
 	"testing"
 	"time"
 
-	"k8s.io/apimachinery/pkg/api/equality"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	clienttesting "open-cluster-management.io/sdk-go/pkg/testing"
@@ -65,6 +66,28 @@ func TestBuildMQTTOptionsFromFlags(t *testing.T) {
 			config:           "{\"brokerHost\":\"test\"}",
 			expectedErrorMsg: "the topics must be set",
 		},
+		{
+			name:             "unsupported endpoint policy",
+			config:           "{\"brokerHosts\":[\"test1\",\"test2\"],\"endpointPolicy\":\"test\"}",
+			expectedErrorMsg: "unsupported endpoint policy \"test\", it should be Ordered or Random",
+		},
+		{
+			name:   "multiple broker hosts",
+			config: strings.Replace(testYamlConfig, "brokerHost: test", "brokerHosts: [test1, test2]", 1),
+			expectedOptions: &MQTTOptions{
+				KeepAlive: 60,
+				PubQoS:    1,
+				SubQoS:    1,
+				Topics: types.Topics{
+					SourceEvents: "sources/hub1/clusters/+/sourceevents",
+					AgentEvents:  "sources/hub1/clusters/+/agentevents",
+				},
+				Dialer: &MQTTDialer{
+					BrokerHosts: []string{"test1", "test2"},
+					Timeout:     60 * time.Second,
+				},
+			},
+		},
 		{
 			name:   "default options",
 			config: testConfig,
@@ -133,7 +156,7 @@ func TestBuildMQTTOptionsFromFlags(t *testing.T) {
 				}
 			}
 
-			if !equality.Semantic.DeepEqual(options, c.expectedOptions) {
+			if !cmp.Equal(options, c.expectedOptions, cmpopts.IgnoreUnexported(MQTTDialer{})) {
 				t.Errorf("unexpected options %v", options)
 			}
 		})
@@ -348,6 +371,41 @@ func TestConnectionTimeout(t *testing.T) {
 	}
 }
 
+func TestDialFailover(t *testing.T) {
+	// the first broker is down
+	down := newLocalListener(t)
+	downHost := down.Addr().String()
+	down.Close()
+
+	ln := newLocalListener(t)
+	defer ln.Close()
+
+	dialer := &MQTTDialer{
+		BrokerHosts: []string{downHost, ln.Addr().String()},
+		Timeout:     time.Second,
+	}
+	if dialer.ActiveBrokerHost() != downHost {
+		t.Errorf("expected the first broker is active, but got %s", dialer.ActiveBrokerHost())
+	}
+
+	conn, err := dialer.Dial()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// the dialer fails over to the second broker and sticks on it
+	if dialer.ActiveBrokerHost() != ln.Addr().String() {
+		t.Errorf("expected the second broker is active, but got %s", dialer.ActiveBrokerHost())
+	}
+
+	// the dialer fails if all of the brokers are down
+	ln.Close()
+	if _, err := dialer.Dial(); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
+
 func newLocalListener(t *testing.T) net.Listener {
 	ln, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
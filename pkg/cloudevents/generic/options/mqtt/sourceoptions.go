@@ -115,6 +115,10 @@ func (o *mqttSourceTransport) ErrorChan() <-chan error {
 	return o.errorChan
 }
 
+func (o *mqttSourceTransport) RotateEndpoint(ctx context.Context) {
+	o.Dialer.RotateEndpoint(ctx)
+}
+
 func SourcePubTopic(ctx context.Context, o *MQTTOptions, sourceID string, evtCtx cloudevents.EventContext) (string, error) {
 	ceType := evtCtx.GetType()
 	eventType, err := types.ParseCloudEventsType(ceType)
@@ -60,6 +60,25 @@ type CloudEventTransport interface {
 	ErrorChan() <-chan error
 }
 
+// EndpointRotator is implemented by the transports that connect to one of multiple broker endpoints.
+type EndpointRotator interface {
+	// RotateEndpoint switches the transport to the next endpoint, it is called by the source/agent client
+	// when the connection to the active endpoint fails, the next Connect uses the new endpoint.
+	RotateEndpoint(ctx context.Context)
+}
+
+// EventSigner signs a cloudevent before it is sent.
+type EventSigner interface {
+	// Sign adds the signature of the event to the event.
+	Sign(ctx context.Context, evt *cloudevents.Event) error
+}
+
+// EventVerifier verifies the signature of a received cloudevent.
+type EventVerifier interface {
+	// Verify returns an error if the event is unsigned or its signature does not match.
+	Verify(ctx context.Context, evt cloudevents.Event) error
+}
+
 // CloudEventsSourceOptions provides the required options to build a source CloudEventsClient
 type CloudEventsSourceOptions struct {
 	// CloudEventsTransport sends/receives cloudevents based on different event protocol.
@@ -72,6 +91,12 @@ type CloudEventsSourceOptions struct {
 
 	// EventRateLimit limits the event sending rate.
 	EventRateLimit utils.EventRateLimit
+
+	// EventSigner signs the events before they are sent, it is optional.
+	EventSigner EventSigner
+
+	// EventVerifier verifies the received events, the events that fail the verification are rejected, it is optional.
+	EventVerifier EventVerifier
 }
 
 // CloudEventsAgentOptions provides the required options to build an agent CloudEventsClient
@@ -88,4 +113,10 @@ type CloudEventsAgentOptions struct {
 
 	// EventRateLimit limits the event sending rate.
 	EventRateLimit utils.EventRateLimit
+
+	// EventSigner signs the events before they are sent, it is optional.
+	EventSigner EventSigner
+
+	// EventVerifier verifies the received events, the events that fail the verification are rejected, it is optional.
+	EventVerifier EventVerifier
 }
@@ -7,11 +7,28 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
+// SubscriptionOption narrows the resources that an agent subscribes.
+type SubscriptionOption func(*pbv1.SubscriptionRequest)
+
+// WithResourceName subscribes the resource with the given name only.
+func WithResourceName(name string) SubscriptionOption {
+	return func(req *pbv1.SubscriptionRequest) {
+		req.ResourceName = name
+	}
+}
+
+// WithLabelSelector subscribes the resources whose labels match the given selector only, e.g. app=addon.
+func WithLabelSelector(selector string) SubscriptionOption {
+	return func(req *pbv1.SubscriptionRequest) {
+		req.LabelSelector = selector
+	}
+}
+
 func NewAgentOptions(grpcOptions *grpc.GRPCOptions,
-	clusterName, agentID string, dataType types.CloudEventsDataType) *options.CloudEventsAgentOptions {
+	clusterName, agentID string, dataType types.CloudEventsDataType, opts ...SubscriptionOption) *options.CloudEventsAgentOptions {
 	return &options.CloudEventsAgentOptions{
 		CloudEventsTransport: newTransport(grpcOptions, func() *pbv1.SubscriptionRequest {
-			return &pbv1.SubscriptionRequest{
+			req := &pbv1.SubscriptionRequest{
 				// TODO: Update this code to determine the subscription source for the agent client.
 				// Currently, the grpc agent client is not utilized, and the 'Source' field serves
 				// as a placeholder with all the sources.
@@ -19,6 +36,10 @@ func NewAgentOptions(grpcOptions *grpc.GRPCOptions,
 				ClusterName: clusterName,
 				DataType:    dataType.String(),
 			}
+			for _, opt := range opts {
+				opt(req)
+			}
+			return req
 		}),
 		AgentID:     agentID,
 		ClusterName: clusterName,
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/klog/v2"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+// publishStream publishes the events over a PublishStream RPC. The events can be published concurrently,
+// each publish waits for the acknowledgement of its event, which is correlated by the request sequence.
+type publishStream struct {
+	stream pbv1.CloudEventService_PublishStreamClient
+	cancel context.CancelFunc
+
+	// sendMu serializes the stream sends, the grpc send is not concurrency safe.
+	sendMu sync.Mutex
+
+	mu       sync.Mutex
+	sequence uint64
+	pending  map[uint64]chan error
+	// err is the error that terminates the stream, once it is set, the stream cannot be used anymore.
+	err  error
+	done chan struct{}
+}
+
+func newPublishStream(ctx context.Context, client pbv1.CloudEventServiceClient) (*publishStream, error) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	stream, err := client.PublishStream(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	ps := &publishStream{
+		stream:  stream,
+		cancel:  cancel,
+		pending: make(map[uint64]chan error),
+		done:    make(chan struct{}),
+	}
+
+	go ps.receiveAcks(klog.FromContext(ctx))
+	return ps, nil
+}
+
+// publish sends the event to the stream and waits for its acknowledgement. The returned error is the same
+// gRPC status error that the Publish RPC returns for the event.
+func (ps *publishStream) publish(ctx context.Context, evt *pbv1.CloudEvent) error {
+	ps.mu.Lock()
+	if ps.err != nil {
+		ps.mu.Unlock()
+		return ps.err
+	}
+	ps.sequence++
+	sequence := ps.sequence
+	ackCh := make(chan error, 1)
+	ps.pending[sequence] = ackCh
+	ps.mu.Unlock()
+
+	// a failed send aborts the stream, the stream status is returned by the stream receive, so the
+	// send error is ignored and the event is failed with the stream status once the stream is terminated.
+	ps.sendMu.Lock()
+	_ = ps.stream.Send(&pbv1.PublishStreamRequest{Sequence: sequence, Event: evt})
+	ps.sendMu.Unlock()
+
+	select {
+	case err := <-ackCh:
+		return err
+	case <-ctx.Done():
+		ps.mu.Lock()
+		delete(ps.pending, sequence)
+		ps.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// receiveAcks receives the acknowledgements from the stream until the stream is terminated.
+func (ps *publishStream) receiveAcks(logger klog.Logger) {
+	for {
+		resp, err := ps.stream.Recv()
+		if err == io.EOF {
+			ps.terminate(status.Error(codes.Unavailable, "publish stream is closed by the server"))
+			return
+		}
+		if err != nil {
+			ps.terminate(err)
+			return
+		}
+
+		ps.mu.Lock()
+		ackCh, ok := ps.pending[resp.Sequence]
+		delete(ps.pending, resp.Sequence)
+		ps.mu.Unlock()
+
+		if !ok {
+			logger.V(4).Info("ignore the acknowledgement of an unknown event", "sequence", resp.Sequence)
+			continue
+		}
+
+		if codes.Code(resp.Code) == codes.OK {
+			ackCh <- nil
+			continue
+		}
+		ackCh <- status.Error(codes.Code(resp.Code), resp.Message)
+	}
+}
+
+// terminate terminates the stream with the given error, the waiting publishes are failed with the
+// stream status error.
+func (ps *publishStream) terminate(err error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	if ps.err != nil {
+		return
+	}
+
+	ps.err = err
+	for sequence, ackCh := range ps.pending {
+		ackCh <- err
+		delete(ps.pending, sequence)
+	}
+	close(ps.done)
+	ps.cancel()
+}
+
+// terminated returns true if the stream is terminated.
+func (ps *publishStream) terminated() bool {
+	select {
+	case <-ps.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// close closes the stream, the waiting publishes are failed with a canceled error.
+func (ps *publishStream) close() {
+	ps.sendMu.Lock()
+	_ = ps.stream.CloseSend()
+	ps.sendMu.Unlock()
+
+	ps.terminate(status.Error(codes.Canceled, "publish stream is closed"))
+}
@@ -33,6 +33,11 @@ type grpcTransport struct {
 	client    pbv1.CloudEventServiceClient
 	subClient pbv1.CloudEventService_SubscribeClient
 
+	// pubStream is the stream to publish events, it is opened on the first send.
+	pubStream *publishStream
+	// publishStreamUnsupported is true if the server does not support the PublishStream RPC.
+	publishStreamUnsupported bool
+
 	subID      string
 	subscribed bool
 
@@ -63,22 +68,29 @@ func (t *grpcTransport) Connect(ctx context.Context) error {
 
 	t.client = pbv1.NewCloudEventServiceClient(conn)
 
+	// the server may be changed after reconnecting, reset the publish stream
+	t.closePublishStream()
+	t.publishStreamUnsupported = false
+
 	// Initialize closeChan to support reconnect cycles
 	t.closeChan = make(chan struct{})
 
 	// Start a goroutine to monitor the gRPC connection state changes
 	go t.monitorConnectionState(ctx, conn)
 
-	logger.Info("grpc is connected", "grpcURL", t.opts.Dialer.URL)
+	logger.Info("grpc is connected", "grpcURL", t.opts.Dialer.ActiveURL())
 
 	return nil
 }
 
+// Send publishes the event with the PublishStream RPC, if the server does not support the PublishStream
+// RPC, the event is published with the unary Publish RPC.
 func (t *grpcTransport) Send(ctx context.Context, evt cloudevents.Event) error {
 	t.mu.Lock()
-	defer t.mu.Unlock()
+	client := t.client
+	t.mu.Unlock()
 
-	if t.client == nil {
+	if client == nil {
 		return fmt.Errorf("transport not connected")
 	}
 
@@ -87,13 +99,61 @@ func (t *grpcTransport) Send(ctx context.Context, evt cloudevents.Event) error {
 		return err
 	}
 
-	if _, err := t.client.Publish(ctx, &pbv1.PublishRequest{Event: pbEvt}); err != nil {
+	pubStream, err := t.getPublishStream(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	if pubStream != nil {
+		err := pubStream.publish(ctx, pbEvt)
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+
+		klog.FromContext(ctx).Info("the server does not support the publish stream, fall back to unary publish")
+		t.mu.Lock()
+		t.publishStreamUnsupported = true
+		t.mu.Unlock()
+	}
+
+	if _, err := client.Publish(ctx, &pbv1.PublishRequest{Event: pbEvt}); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// getPublishStream returns the current publish stream, a new stream is opened if the current stream is
+// terminated. It returns nil if the server does not support the PublishStream RPC.
+func (t *grpcTransport) getPublishStream(ctx context.Context, client pbv1.CloudEventServiceClient) (*publishStream, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.publishStreamUnsupported {
+		return nil, nil
+	}
+
+	if t.pubStream != nil && !t.pubStream.terminated() {
+		return t.pubStream, nil
+	}
+
+	pubStream, err := newPublishStream(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	t.pubStream = pubStream
+	return t.pubStream, nil
+}
+
+// closePublishStream closes the current publish stream, the caller must hold the transport lock.
+func (t *grpcTransport) closePublishStream() {
+	if t.pubStream != nil {
+		t.pubStream.close()
+		t.pubStream = nil
+	}
+}
+
 func (t *grpcTransport) Subscribe(ctx context.Context) error {
 	logger := klog.FromContext(ctx)
 	t.mu.Lock()
@@ -192,6 +252,10 @@ func (t *grpcTransport) ErrorChan() <-chan error {
 	return t.errorChan
 }
 
+func (t *grpcTransport) RotateEndpoint(ctx context.Context) {
+	t.opts.Dialer.RotateEndpoint(ctx)
+}
+
 func (t *grpcTransport) Close(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
@@ -208,6 +272,7 @@ func (t *grpcTransport) Close(ctx context.Context) error {
 		}
 	}
 
+	t.closePublishStream()
 	t.subscribed = false
 	return t.opts.Dialer.Close()
 }
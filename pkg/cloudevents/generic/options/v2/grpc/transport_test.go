@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"sync"
@@ -13,8 +14,10 @@ import (
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -34,6 +37,14 @@ type mockCloudEventService struct {
 	eventsSent        atomic.Int32
 	publishFunc       func(ctx context.Context, req *pbv1.PublishRequest) (*emptypb.Empty, error)
 	subscribeFunc     func(req *pbv1.SubscriptionRequest, stream pbv1.CloudEventService_SubscribeServer) error
+	publishStreamFunc func(stream pbv1.CloudEventService_PublishStreamServer) error
+}
+
+func (m *mockCloudEventService) PublishStream(stream pbv1.CloudEventService_PublishStreamServer) error {
+	if m.publishStreamFunc != nil {
+		return m.publishStreamFunc(stream)
+	}
+	return m.UnimplementedCloudEventServiceServer.PublishStream(stream)
 }
 
 func (m *mockCloudEventService) Publish(ctx context.Context, req *pbv1.PublishRequest) (*emptypb.Empty, error) {
@@ -353,6 +364,130 @@ func TestGrpcTransport_Send_Error(t *testing.T) {
 	}
 }
 
+func TestGrpcTransport_Send_PublishStream(t *testing.T) {
+	var unaryCalled atomic.Bool
+	var streamEvents sync.Map
+
+	service := &mockCloudEventService{
+		publishFunc: func(ctx context.Context, req *pbv1.PublishRequest) (*emptypb.Empty, error) {
+			unaryCalled.Store(true)
+			return &emptypb.Empty{}, nil
+		},
+		publishStreamFunc: func(stream pbv1.CloudEventService_PublishStreamServer) error {
+			for {
+				req, err := stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+
+				resp := &pbv1.PublishStreamResponse{Sequence: req.Sequence}
+				if req.Event.Id == "invalid" {
+					resp.Code = int32(codes.FailedPrecondition)
+					resp.Message = "invalid event"
+				}
+				streamEvents.Store(req.Event.Id, true)
+				if err := stream.Send(resp); err != nil {
+					return err
+				}
+			}
+		},
+	}
+
+	conn, cleanup := setupMockServer(t, service)
+	defer cleanup()
+
+	transport := &grpcTransport{
+		client:    pbv1.NewCloudEventServiceClient(conn),
+		errorChan: make(chan error, 1),
+		closeChan: make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			evt := cloudevents.NewEvent()
+			evt.SetID(fmt.Sprintf("test-id-%d", i))
+			evt.SetType("test-type")
+			evt.SetSource("test-source")
+			if err := transport.Send(context.Background(), evt); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 10; i++ {
+		if _, ok := streamEvents.Load(fmt.Sprintf("test-id-%d", i)); !ok {
+			t.Errorf("event test-id-%d is not published with the stream", i)
+		}
+	}
+
+	evt := cloudevents.NewEvent()
+	evt.SetID("invalid")
+	evt.SetType("test-type")
+	evt.SetSource("test-source")
+	err := transport.Send(context.Background(), evt)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition error, got %v", err)
+	}
+
+	if unaryCalled.Load() {
+		t.Error("unexpected unary publish")
+	}
+
+	transport.mu.Lock()
+	pubStream := transport.pubStream
+	transport.closePublishStream()
+	transport.mu.Unlock()
+
+	if !pubStream.terminated() {
+		t.Error("expected the publish stream is terminated")
+	}
+}
+
+func TestGrpcTransport_Send_PublishStreamFallback(t *testing.T) {
+	var unaryCalls atomic.Int32
+
+	service := &mockCloudEventService{
+		publishFunc: func(ctx context.Context, req *pbv1.PublishRequest) (*emptypb.Empty, error) {
+			unaryCalls.Add(1)
+			return &emptypb.Empty{}, nil
+		},
+	}
+
+	conn, cleanup := setupMockServer(t, service)
+	defer cleanup()
+
+	transport := &grpcTransport{
+		client:    pbv1.NewCloudEventServiceClient(conn),
+		errorChan: make(chan error, 1),
+		closeChan: make(chan struct{}),
+	}
+
+	for i := 0; i < 2; i++ {
+		evt := cloudevents.NewEvent()
+		evt.SetID("test-id")
+		evt.SetType("test-type")
+		evt.SetSource("test-source")
+		if err := transport.Send(context.Background(), evt); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	if unaryCalls.Load() != 2 {
+		t.Errorf("expected 2 unary publishes, got %d", unaryCalls.Load())
+	}
+
+	if !transport.publishStreamUnsupported {
+		t.Error("expected the publish stream is unsupported")
+	}
+}
+
 func TestGrpcTransport_Subscribe_Success(t *testing.T) {
 	subscribeCalled := false
 	var receivedRequest *pbv1.SubscriptionRequest
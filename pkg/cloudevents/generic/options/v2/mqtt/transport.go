@@ -84,7 +84,7 @@ func (t *mqttTransport) Connect(ctx context.Context) error {
 	// TODO consider to make the channel size configurable
 	t.msgChan = make(chan *paho.Publish, 100)
 
-	logger.Info("mqtt is connected", "brokerHost", t.opts.Dialer.BrokerHost)
+	logger.Info("mqtt is connected", "brokerHost", t.opts.Dialer.ActiveBrokerHost())
 
 	return nil
 }
@@ -196,6 +196,10 @@ func (t *mqttTransport) ErrorChan() <-chan error {
 	return t.errorChan
 }
 
+func (t *mqttTransport) RotateEndpoint(ctx context.Context) {
+	t.opts.Dialer.RotateEndpoint(ctx)
+}
+
 func (t *mqttTransport) Close(ctx context.Context) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
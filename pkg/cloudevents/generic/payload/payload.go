@@ -5,8 +5,16 @@ import (
 	"fmt"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"google.golang.org/protobuf/proto"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/payload/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
+// protobufTypeURLPrefix is the prefix of the type URL of a protobuf message, the type URL is set to the `dataschema`
+// attribute of a cloudevent when its data is encoded with protobuf.
+const protobufTypeURLPrefix = "type.googleapis.com/"
+
 type ResourceVersion struct {
 	ResourceID      string `json:"resourceID"`
 	ResourceVersion int64  `json:"resourceVersion"`
@@ -29,9 +37,61 @@ type ResourceStatusHashList struct {
 	Hashes []ResourceStatusHash `json:"statusHashes"`
 }
 
+// EncodeSpecResyncRequest sets the resource versions to the data of a spec resync request with the given content
+// type, the content type can be `application/json` or `application/protobuf`.
+func EncodeSpecResyncRequest(evt *cloudevents.Event, contentType string, versions *ResourceVersionList) error {
+	if contentType != types.ApplicationProtobuf {
+		return evt.SetData(contentType, versions)
+	}
+
+	pbVersions := &pbv1.ResourceVersionList{ResourceVersions: make([]*pbv1.ResourceVersion, len(versions.Versions))}
+	for i, version := range versions.Versions {
+		pbVersions.ResourceVersions[i] = &pbv1.ResourceVersion{
+			ResourceId:      version.ResourceID,
+			ResourceVersion: version.ResourceVersion,
+		}
+	}
+
+	return SetProtobufData(evt, pbVersions)
+}
+
+// EncodeStatusResyncRequest sets the resource status hashes to the data of a status resync request with the given
+// content type, the content type can be `application/json` or `application/protobuf`.
+func EncodeStatusResyncRequest(evt *cloudevents.Event, contentType string, hashes *ResourceStatusHashList) error {
+	if contentType != types.ApplicationProtobuf {
+		return evt.SetData(contentType, hashes)
+	}
+
+	pbHashes := &pbv1.ResourceStatusHashList{StatusHashes: make([]*pbv1.ResourceStatusHash, len(hashes.Hashes))}
+	for i, hash := range hashes.Hashes {
+		pbHashes.StatusHashes[i] = &pbv1.ResourceStatusHash{
+			ResourceId: hash.ResourceID,
+			StatusHash: hash.StatusHash,
+		}
+	}
+
+	return SetProtobufData(evt, pbHashes)
+}
+
 func DecodeSpecResyncRequest(evt cloudevents.Event) (*ResourceVersionList, error) {
 	versions := &ResourceVersionList{}
 	data := evt.Data()
+
+	if evt.DataContentType() == types.ApplicationProtobuf {
+		pbVersions := &pbv1.ResourceVersionList{}
+		if err := proto.Unmarshal(data, pbVersions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal spec resync request protobuf payload, %v", err)
+		}
+
+		for _, version := range pbVersions.ResourceVersions {
+			versions.Versions = append(versions.Versions, ResourceVersion{
+				ResourceID:      version.ResourceId,
+				ResourceVersion: version.ResourceVersion,
+			})
+		}
+		return versions, nil
+	}
+
 	if err := json.Unmarshal(data, versions); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal spec resync request payload %s, %v", string(data), err)
 	}
@@ -41,8 +101,36 @@ func DecodeSpecResyncRequest(evt cloudevents.Event) (*ResourceVersionList, error
 func DecodeStatusResyncRequest(evt cloudevents.Event) (*ResourceStatusHashList, error) {
 	hashes := &ResourceStatusHashList{}
 	data := evt.Data()
+
+	if evt.DataContentType() == types.ApplicationProtobuf {
+		pbHashes := &pbv1.ResourceStatusHashList{}
+		if err := proto.Unmarshal(data, pbHashes); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal status resync request protobuf payload, %v", err)
+		}
+
+		for _, hash := range pbHashes.StatusHashes {
+			hashes.Hashes = append(hashes.Hashes, ResourceStatusHash{
+				ResourceID: hash.ResourceId,
+				StatusHash: hash.StatusHash,
+			})
+		}
+		return hashes, nil
+	}
+
 	if err := json.Unmarshal(data, hashes); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal status resync request payload %s, %v", string(data), err)
 	}
 	return hashes, nil
 }
+
+// SetProtobufData marshals the protobuf message to the data of a cloudevent, the data content type of the event is
+// set to `application/protobuf` and the dataschema of the event is set to the type URL of the message.
+func SetProtobufData(evt *cloudevents.Event, msg proto.Message) error {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	evt.SetDataSchema(protobufTypeURLPrefix + string(msg.ProtoReflect().Descriptor().FullName()))
+	return evt.SetData(types.ApplicationProtobuf, data)
+}
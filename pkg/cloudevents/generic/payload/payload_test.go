@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 )
 
 func TestDecodeSpecResyncRequest(t *testing.T) {
@@ -37,3 +39,41 @@ func TestDecodeStatusResyncRequest(t *testing.T) {
 		t.Errorf("unexpected versions %v", hashes)
 	}
 }
+
+func TestResyncRequestProtobufRoundTrip(t *testing.T) {
+	versionsEvt := cloudevents.NewEvent()
+	if err := EncodeSpecResyncRequest(&versionsEvt, types.ApplicationProtobuf, &ResourceVersionList{
+		Versions: []ResourceVersion{{ResourceID: "123", ResourceVersion: 3}},
+	}); err != nil {
+		t.Fatalf("failed to encode spec resync request %v", err)
+	}
+
+	if versionsEvt.DataContentType() != types.ApplicationProtobuf {
+		t.Errorf("unexpected data content type %s", versionsEvt.DataContentType())
+	}
+
+	versions, err := DecodeSpecResyncRequest(versionsEvt)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if len(versions.Versions) != 1 || versions.Versions[0].ResourceID != "123" || versions.Versions[0].ResourceVersion != 3 {
+		t.Errorf("unexpected versions %v", versions)
+	}
+
+	hashesEvt := cloudevents.NewEvent()
+	if err := EncodeStatusResyncRequest(&hashesEvt, types.ApplicationProtobuf, &ResourceStatusHashList{
+		Hashes: []ResourceStatusHash{{ResourceID: "123", StatusHash: "1a2b"}},
+	}); err != nil {
+		t.Fatalf("failed to encode status resync request %v", err)
+	}
+
+	hashes, err := DecodeStatusResyncRequest(hashesEvt)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if len(hashes.Hashes) != 1 || hashes.Hashes[0].ResourceID != "123" || hashes.Hashes[0].StatusHash != "1a2b" {
+		t.Errorf("unexpected hashes %v", hashes)
+	}
+}
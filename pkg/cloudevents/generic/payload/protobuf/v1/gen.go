@@ -0,0 +1,3 @@
+package v1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative payload.proto
@@ -0,0 +1,383 @@
+// After making changes to the *.proto files, always run the following
+// command in current directory to update the generated code:
+// go generate
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.12.4
+// source: payload.proto
+
+package v1
+
+import (
+	reflect "reflect"
+	sync "sync"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ResourceVersion represents the resource version of a resource maintained by an agent.
+type ResourceVersion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The resource ID.
+	ResourceId string `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// The resource version.
+	ResourceVersion int64 `protobuf:"varint,2,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+}
+
+func (x *ResourceVersion) Reset() {
+	*x = ResourceVersion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceVersion) ProtoMessage() {}
+
+func (x *ResourceVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceVersion.ProtoReflect.Descriptor instead.
+func (*ResourceVersion) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ResourceVersion) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ResourceVersion) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+// ResourceStatusHash represents the status hash of a resource maintained by a source.
+type ResourceStatusHash struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The resource ID.
+	ResourceId string `protobuf:"bytes,1,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// The hash of the resource status.
+	StatusHash string `protobuf:"bytes,2,opt,name=status_hash,json=statusHash,proto3" json:"status_hash,omitempty"`
+}
+
+func (x *ResourceStatusHash) Reset() {
+	*x = ResourceStatusHash{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceStatusHash) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceStatusHash) ProtoMessage() {}
+
+func (x *ResourceStatusHash) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceStatusHash.ProtoReflect.Descriptor instead.
+func (*ResourceStatusHash) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResourceStatusHash) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ResourceStatusHash) GetStatusHash() string {
+	if x != nil {
+		return x.StatusHash
+	}
+	return ""
+}
+
+// ResourceVersionList is the payload of a spec resync request.
+type ResourceVersionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResourceVersions []*ResourceVersion `protobuf:"bytes,1,rep,name=resource_versions,json=resourceVersions,proto3" json:"resource_versions,omitempty"`
+}
+
+func (x *ResourceVersionList) Reset() {
+	*x = ResourceVersionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceVersionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceVersionList) ProtoMessage() {}
+
+func (x *ResourceVersionList) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceVersionList.ProtoReflect.Descriptor instead.
+func (*ResourceVersionList) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResourceVersionList) GetResourceVersions() []*ResourceVersion {
+	if x != nil {
+		return x.ResourceVersions
+	}
+	return nil
+}
+
+// ResourceStatusHashList is the payload of a status resync request.
+type ResourceStatusHashList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StatusHashes []*ResourceStatusHash `protobuf:"bytes,1,rep,name=status_hashes,json=statusHashes,proto3" json:"status_hashes,omitempty"`
+}
+
+func (x *ResourceStatusHashList) Reset() {
+	*x = ResourceStatusHashList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_payload_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceStatusHashList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceStatusHashList) ProtoMessage() {}
+
+func (x *ResourceStatusHashList) ProtoReflect() protoreflect.Message {
+	mi := &file_payload_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceStatusHashList.ProtoReflect.Descriptor instead.
+func (*ResourceStatusHashList) Descriptor() ([]byte, []int) {
+	return file_payload_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ResourceStatusHashList) GetStatusHashes() []*ResourceStatusHash {
+	if x != nil {
+		return x.StatusHashes
+	}
+	return nil
+}
+
+var File_payload_proto protoreflect.FileDescriptor
+
+var file_payload_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x25, 0x69, 0x6f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72,
+	0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x22, 0x5d, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x56, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x61, 0x73, 0x68, 0x22, 0x7a, 0x0a,
+	0x13, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x63, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x36, 0x2e, 0x69, 0x6f, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65,
+	0x72, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x78, 0x0a, 0x16, 0x52, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x61, 0x73, 0x68, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x5e, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x69, 0x6f, 0x2e,
+	0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6e,
+	0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x48, 0x61, 0x73, 0x68, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x61, 0x73,
+	0x68, 0x65, 0x73, 0x42, 0x4f, 0x5a, 0x4d, 0x6f, 0x70, 0x65, 0x6e, 0x2d, 0x63, 0x6c, 0x75, 0x73,
+	0x74, 0x65, 0x72, 0x2d, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x69,
+	0x6f, 0x2f, 0x73, 0x64, 0x6b, 0x2d, 0x67, 0x6f, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x69, 0x63,
+	0x2f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_payload_proto_rawDescOnce sync.Once
+	file_payload_proto_rawDescData = file_payload_proto_rawDesc
+)
+
+func file_payload_proto_rawDescGZIP() []byte {
+	file_payload_proto_rawDescOnce.Do(func() {
+		file_payload_proto_rawDescData = protoimpl.X.CompressGZIP(file_payload_proto_rawDescData)
+	})
+	return file_payload_proto_rawDescData
+}
+
+var file_payload_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_payload_proto_goTypes = []interface{}{
+	(*ResourceVersion)(nil),        // 0: io.open_cluster_management.payload.v1.ResourceVersion
+	(*ResourceStatusHash)(nil),     // 1: io.open_cluster_management.payload.v1.ResourceStatusHash
+	(*ResourceVersionList)(nil),    // 2: io.open_cluster_management.payload.v1.ResourceVersionList
+	(*ResourceStatusHashList)(nil), // 3: io.open_cluster_management.payload.v1.ResourceStatusHashList
+}
+var file_payload_proto_depIdxs = []int32{
+	0, // 0: io.open_cluster_management.payload.v1.ResourceVersionList.resource_versions:type_name -> io.open_cluster_management.payload.v1.ResourceVersion
+	1, // 1: io.open_cluster_management.payload.v1.ResourceStatusHashList.status_hashes:type_name -> io.open_cluster_management.payload.v1.ResourceStatusHash
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_payload_proto_init() }
+func file_payload_proto_init() {
+	if File_payload_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_payload_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceVersion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceStatusHash); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceVersionList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_payload_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceStatusHashList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_payload_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_payload_proto_goTypes,
+		DependencyIndexes: file_payload_proto_depIdxs,
+		MessageInfos:      file_payload_proto_msgTypes,
+	}.Build()
+	File_payload_proto = out.File
+	file_payload_proto_rawDesc = nil
+	file_payload_proto_goTypes = nil
+	file_payload_proto_depIdxs = nil
+}
@@ -0,0 +1,70 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"fmt"
+
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"open-cluster-management.io/sdk-go/pkg/jose"
+)
+
+// KeyResolver resolves the public key that is used to verify an event signature.
+type KeyResolver interface {
+	// ResolveKey returns the public key of the given key ID.
+	ResolveKey(ctx context.Context, keyID string) (crypto.PublicKey, error)
+}
+
+// StaticKeyResolver resolves the public keys from a static key ID to public key map.
+type StaticKeyResolver map[string]crypto.PublicKey
+
+func (r StaticKeyResolver) ResolveKey(_ context.Context, keyID string) (crypto.PublicKey, error) {
+	key, ok := r[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %q is not found", keyID)
+	}
+	return key, nil
+}
+
+// SecretKeyResolver resolves the public keys from a Kubernetes Secret, each data item of the secret is a public key,
+// the item key is the key ID and the item value is a PEM encoded public key or certificate.
+type SecretKeyResolver struct {
+	lister    corev1listers.SecretLister
+	namespace string
+	name      string
+}
+
+// NewSecretKeyResolver returns a SecretKeyResolver that reads the given secret from the secret lister.
+func NewSecretKeyResolver(lister corev1listers.SecretLister, namespace, name string) *SecretKeyResolver {
+	return &SecretKeyResolver{lister: lister, namespace: namespace, name: name}
+}
+
+func (r *SecretKeyResolver) ResolveKey(_ context.Context, keyID string) (crypto.PublicKey, error) {
+	secret, err := r.lister.Secrets(r.namespace).Get(r.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the key secret %s/%s: %v", r.namespace, r.name, err)
+	}
+
+	data, ok := secret.Data[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %q is not found in the secret %s/%s", keyID, r.namespace, r.name)
+	}
+
+	return jose.ParsePublicKeyPEM(data)
+}
+
+// JWKSFileKeyResolver resolves the public keys from a JSON Web Key Set file by the key `kid`, the file is reloaded
+// when it is changed.
+type JWKSFileKeyResolver struct {
+	keySet *jose.KeySetFile
+}
+
+// NewJWKSFileKeyResolver returns a JWKSFileKeyResolver for the given JWKS file.
+func NewJWKSFileKeyResolver(path string) *JWKSFileKeyResolver {
+	return &JWKSFileKeyResolver{keySet: jose.NewKeySetFile(path)}
+}
+
+func (r *JWKSFileKeyResolver) ResolveKey(_ context.Context, keyID string) (crypto.PublicKey, error) {
+	return r.keySet.Lookup(keyID)
+}
@@ -0,0 +1,195 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/metrics"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+	"open-cluster-management.io/sdk-go/pkg/jose"
+)
+
+// signatureType is the `typ` of the signature JWS header.
+const signatureType = "cloudevent+jws"
+
+// The reasons of the event verification failures.
+const (
+	ReasonUnsigned          = "unsigned"
+	ReasonMalformed         = "malformed"
+	ReasonUnknownKey        = "unknown_key"
+	ReasonKeySourceMismatch = "key_source_mismatch"
+	ReasonInvalidSignature  = "invalid_signature"
+)
+
+// VerificationError is returned when a received event fails the verification.
+type VerificationError struct {
+	Reason string
+	Err    error
+}
+
+func (e *VerificationError) Error() string {
+	return fmt.Sprintf("event verification failed (%s): %v", e.Reason, e.Err)
+}
+
+func (e *VerificationError) Unwrap() error {
+	return e.Err
+}
+
+// signedContent is the payload of the detached signature JWS. It is rebuilt from the event by the receiver, so
+// its JSON encoding must be deterministic. The signature covers the event id, source, type, time and data, and all
+// the extensions except the signature itself.
+type signedContent struct {
+	ID         string            `json:"id"`
+	Source     string            `json:"source"`
+	Type       string            `json:"type"`
+	Time       string            `json:"time,omitempty"`
+	DataDigest string            `json:"data"`
+	Extensions map[string]string `json:"extensions"`
+}
+
+func signingPayload(evt cloudevents.Event) ([]byte, error) {
+	digest := sha256.Sum256(evt.Data())
+	content := signedContent{
+		ID:         evt.ID(),
+		Source:     evt.Source(),
+		Type:       evt.Type(),
+		DataDigest: base64.RawURLEncoding.EncodeToString(digest[:]),
+		Extensions: map[string]string{},
+	}
+	if !evt.Time().IsZero() {
+		content.Time = cloudeventstypes.FormatTime(evt.Time())
+	}
+
+	for name, value := range evt.Extensions() {
+		if name == types.ExtensionSignature {
+			continue
+		}
+
+		// format the value to its canonical string, so that the signature does not depend on how a transport
+		// carries the extension value.
+		formatted, err := cloudeventstypes.Format(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format extension %s: %v", name, err)
+		}
+		content.Extensions[name] = formatted
+	}
+
+	return json.Marshal(content)
+}
+
+// Signer signs the cloudevents with a private key, the signature is set to the `signature` extension of the event.
+type Signer struct {
+	keyID     string
+	algorithm string
+	key       crypto.Signer
+}
+
+var _ options.EventSigner = &Signer{}
+
+// NewSigner returns a Signer with the given key ID and private key. The key ID must be the source of the events
+// that are signed by this signer, or be prefixed with the source followed by a `/`, e.g. `source1/2024-01`.
+func NewSigner(keyID string, key crypto.Signer) (*Signer, error) {
+	if len(keyID) == 0 {
+		return nil, fmt.Errorf("the key id is required")
+	}
+
+	algorithm, err := jose.AlgorithmForKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Signer{keyID: keyID, algorithm: algorithm, key: key}, nil
+}
+
+// Sign signs the event data, its attributes and extensions, and sets the detached JWS to the event. The event must
+// not be changed once it is signed.
+func (s *Signer) Sign(_ context.Context, evt *cloudevents.Event) error {
+	payload, err := signingPayload(*evt)
+	if err != nil {
+		return err
+	}
+
+	signature, err := jose.SignDetached(jose.Header{
+		Algorithm: s.algorithm,
+		KeyID:     s.keyID,
+		Type:      signatureType,
+	}, payload, s.key)
+	if err != nil {
+		return fmt.Errorf("failed to sign the event: %v", err)
+	}
+
+	evt.SetExtension(types.ExtensionSignature, signature)
+	return nil
+}
+
+// Verifier verifies the signatures of the received cloudevents, the public keys are resolved by a KeyResolver.
+type Verifier struct {
+	resolver KeyResolver
+}
+
+var _ options.EventVerifier = &Verifier{}
+
+// NewVerifier returns a Verifier with the given key resolver.
+func NewVerifier(resolver KeyResolver) *Verifier {
+	return &Verifier{resolver: resolver}
+}
+
+// Verify verifies the event signature, it returns a VerificationError if the event is unsigned, the signing key
+// is unknown or not bound to the event source, or the signature does not match the event.
+func (v *Verifier) Verify(ctx context.Context, evt cloudevents.Event) error {
+	if err := v.verify(ctx, evt); err != nil {
+		metrics.IncreaseEventVerificationFailureCounter(evt.Source(), err.Reason)
+		return err
+	}
+
+	return nil
+}
+
+func (v *Verifier) verify(ctx context.Context, evt cloudevents.Event) *VerificationError {
+	value, ok := evt.Extensions()[types.ExtensionSignature]
+	if !ok {
+		return &VerificationError{Reason: ReasonUnsigned, Err: fmt.Errorf("the event %s has no signature", evt.ID())}
+	}
+
+	signature, err := cloudeventstypes.ToString(value)
+	if err != nil {
+		return &VerificationError{Reason: ReasonMalformed, Err: err}
+	}
+
+	payload, err := signingPayload(evt)
+	if err != nil {
+		return &VerificationError{Reason: ReasonMalformed, Err: err}
+	}
+
+	header, _, signingInput, sig, err := jose.ParseCompact(signature, payload)
+	if err != nil {
+		return &VerificationError{Reason: ReasonMalformed, Err: err}
+	}
+
+	if header.KeyID != evt.Source() && !strings.HasPrefix(header.KeyID, evt.Source()+"/") {
+		return &VerificationError{
+			Reason: ReasonKeySourceMismatch,
+			Err:    fmt.Errorf("the key %q cannot sign the events of the source %q", header.KeyID, evt.Source()),
+		}
+	}
+
+	key, err := v.resolver.ResolveKey(ctx, header.KeyID)
+	if err != nil {
+		return &VerificationError{Reason: ReasonUnknownKey, Err: err}
+	}
+
+	if err := jose.VerifySignature(header.Algorithm, key, signingInput, sig); err != nil {
+		return &VerificationError{Reason: ReasonInvalidSignature, Err: err}
+	}
+
+	return nil
+}
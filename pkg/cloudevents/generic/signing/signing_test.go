@@ -0,0 +1,264 @@
+package signing
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+func newTestEvent() *cloudevents.Event {
+	evt := cloudevents.NewEvent()
+	evt.SetID("test-id")
+	evt.SetSource("source1")
+	evt.SetType("io.open-cluster-management.works.v1alpha1.manifests.spec.create_request")
+	evt.SetExtension(types.ExtensionResourceID, "test-resource")
+	evt.SetExtension(types.ExtensionResourceVersion, int64(1))
+	evt.SetExtension(types.ExtensionClusterName, "cluster1")
+	evt.SetExtension(types.ExtensionDeletionTimestamp, time.Now())
+	evt.SetExtension(types.ExtensionWorkMeta, `{"name":"work1","labels":{"app":"work1"}}`)
+	evt.SetTime(time.Now())
+	if err := evt.SetData(cloudevents.ApplicationJSON, map[string]string{"test": "data"}); err != nil {
+		panic(err)
+	}
+	return &evt
+}
+
+func TestSignAndVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := StaticKeyResolver{
+		"source1/key1": key.Public(),
+		"source2":      key.Public(),
+	}
+
+	cases := []struct {
+		name           string
+		keyID          string
+		signKey        *ecdsa.PrivateKey
+		unsigned       bool
+		mutate         func(evt *cloudevents.Event)
+		expectedReason string
+	}{
+		{
+			name:  "verified",
+			keyID: "source1/key1",
+		},
+		{
+			name:           "unsigned",
+			unsigned:       true,
+			expectedReason: ReasonUnsigned,
+		},
+		{
+			name:           "malformed signature",
+			keyID:          "source1/key1",
+			mutate:         func(evt *cloudevents.Event) { evt.SetExtension(types.ExtensionSignature, "invalid") },
+			expectedReason: ReasonMalformed,
+		},
+		{
+			name:           "key is not bound to the source",
+			keyID:          "source2",
+			expectedReason: ReasonKeySourceMismatch,
+		},
+		{
+			name:           "unknown key",
+			keyID:          "source1/key2",
+			expectedReason: ReasonUnknownKey,
+		},
+		{
+			name:           "signed by another key",
+			keyID:          "source1/key1",
+			signKey:        otherKey,
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:  "data is changed",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				if err := evt.SetData(cloudevents.ApplicationJSON, map[string]string{"test": "changed"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:  "forged event reuses a valid signature with its payload attached",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				payload, err := signingPayload(*evt)
+				if err != nil {
+					t.Fatal(err)
+				}
+				parts := strings.Split(evt.Extensions()[types.ExtensionSignature].(string), ".")
+				evt.SetExtension(types.ExtensionSignature,
+					parts[0]+"."+base64.RawURLEncoding.EncodeToString(payload)+"."+parts[2])
+				if err := evt.SetData(cloudevents.ApplicationJSON, map[string]string{"test": "forged"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			expectedReason: ReasonMalformed,
+		},
+		{
+			name:  "signed extension is changed",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				evt.SetExtension(types.ExtensionClusterName, "cluster2")
+			},
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:  "metadata extension is changed",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				evt.SetExtension(types.ExtensionWorkMeta, `{"name":"work2","labels":{"app":"work1"}}`)
+			},
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:  "extension is added",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				evt.SetExtension(types.ExtensionStatusHash, "hash")
+			},
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:           "id is changed",
+			keyID:          "source1/key1",
+			mutate:         func(evt *cloudevents.Event) { evt.SetID("other-id") },
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:           "time is changed",
+			keyID:          "source1/key1",
+			mutate:         func(evt *cloudevents.Event) { evt.SetTime(evt.Time().Add(time.Second)) },
+			expectedReason: ReasonInvalidSignature,
+		},
+		{
+			name:  "verified after the protobuf transport",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				pbEvt := &pbv1.CloudEvent{}
+				if err := protocol.WritePBMessage(context.Background(), binding.ToMessage(evt), pbEvt); err != nil {
+					t.Fatal(err)
+				}
+				received, err := binding.ToEvent(context.Background(), protocol.NewMessage(pbEvt))
+				if err != nil {
+					t.Fatal(err)
+				}
+				*evt = *received
+			},
+		},
+		{
+			name:  "signed extension is removed",
+			keyID: "source1/key1",
+			mutate: func(evt *cloudevents.Event) {
+				evt.SetExtension(types.ExtensionResourceVersion, nil)
+			},
+			expectedReason: ReasonInvalidSignature,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evt := newTestEvent()
+			if !c.unsigned {
+				signKey := key
+				if c.signKey != nil {
+					signKey = c.signKey
+				}
+				signer, err := NewSigner(c.keyID, signKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := signer.Sign(context.Background(), evt); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if c.mutate != nil {
+				c.mutate(evt)
+			}
+
+			err := NewVerifier(resolver).Verify(context.Background(), *evt)
+			if len(c.expectedReason) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+
+			var verificationErr *VerificationError
+			if !errors.As(err, &verificationErr) {
+				t.Fatalf("expected verification error, but got %v", err)
+			}
+			if verificationErr.Reason != c.expectedReason {
+				t.Errorf("expected reason %s, but got %s (%v)", c.expectedReason, verificationErr.Reason, err)
+			}
+		})
+	}
+}
+
+func TestSecretKeyResolver(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "keys"},
+		Data: map[string][]byte{
+			"source1": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewSigner("source1", key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evt := newTestEvent()
+	if err := signer.Sign(context.Background(), evt); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := NewVerifier(NewSecretKeyResolver(corev1listers.NewSecretLister(indexer), "test", "keys"))
+	if err := verifier.Verify(context.Background(), *evt); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	verifier = NewVerifier(NewSecretKeyResolver(corev1listers.NewSecretLister(indexer), "test", "none"))
+	if err := verifier.Verify(context.Background(), *evt); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
@@ -12,6 +12,9 @@ import (
 // HeartbeatCloudEventsType indicates the type of heartbeat cloud events.
 const HeartbeatCloudEventsType = "io.open-cluster-management.cloudevents.heartbeat"
 
+// ApplicationProtobuf is the content type of the cloud event data that is encoded with protobuf.
+const ApplicationProtobuf = "application/protobuf"
+
 const (
 	// ClusterAll is the default argument to specify on a context when you want to list or filter resources across all
 	// managed clusters.
@@ -81,6 +84,10 @@ const (
 
 	// ExtensionWorkMeta is an extension attribute for work meta data.
 	ExtensionWorkMeta = "metadata"
+
+	// ExtensionSignature is the cloud event extension key of the event signature, the signature is a detached JWS
+	// over the event data and the critical attributes of the event.
+	ExtensionSignature = "signature"
 )
 
 const (
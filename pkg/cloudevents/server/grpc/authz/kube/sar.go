@@ -2,8 +2,12 @@ package sar
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
 
@@ -12,15 +16,22 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/serviceaccount"
 	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
 
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"k8s.io/klog/v2"
 
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
 	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
 
 	authv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	rbacinformers "k8s.io/client-go/informers/rbac/v1"
 	"k8s.io/client-go/kubernetes"
+	kubecache "k8s.io/client-go/tools/cache"
 
 	workv1 "open-cluster-management.io/api/work/v1"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/cluster"
@@ -30,10 +41,98 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/metrics"
 )
 
+// SARCacheOptions configures the cache of the authorization decisions.
+type SARCacheOptions struct {
+	// MaxSize is the maximum number of the cached decisions, the least recently used decision is evicted
+	// once the cache is full.
+	MaxSize int
+	// AllowTTL is the duration to cache an allowed decision.
+	AllowTTL time.Duration
+	// DenyTTL is the duration to cache a denied decision, the denied decisions are not cached if it is zero.
+	DenyTTL time.Duration
+}
+
+// NewSARCacheOptions returns the default SARCacheOptions.
+func NewSARCacheOptions() SARCacheOptions {
+	return SARCacheOptions{
+		MaxSize:  10000,
+		AllowTTL: 5 * time.Minute,
+		DenyTTL:  30 * time.Second,
+	}
+}
+
+// SAROption configures the SARAuthorizer.
+type SAROption func(*SARAuthorizer)
+
+// WithDecisionCache caches the SubjectAccessReview decisions, so the kube-apiserver is not requested for
+// each published event.
+func WithDecisionCache(opts SARCacheOptions) SAROption {
+	return func(s *SARAuthorizer) {
+		s.cacheOpts = opts
+		s.cache = cache.NewLRUExpireCache(opts.MaxSize)
+	}
+}
+
+// WithRBACInformers invalidates the cached decisions once the RBAC is changed, the cached decisions of a
+// namespace are invalidated by its RoleBinding changes and all of the cached decisions are invalidated by
+// the ClusterRoleBinding changes. The changes of the Role or ClusterRole rules are not watched, the decisions
+// are refreshed after their TTLs. The informers should be started by the caller.
+func WithRBACInformers(roleBindings rbacinformers.RoleBindingInformer,
+	clusterRoleBindings rbacinformers.ClusterRoleBindingInformer) SAROption {
+	return func(s *SARAuthorizer) {
+		if roleBindings != nil {
+			_, err := roleBindings.Informer().AddEventHandler(s.invalidateHandler(func(obj any) {
+				if rb, ok := obj.(*rbacv1.RoleBinding); ok {
+					s.invalidate(rb.Namespace)
+					return
+				}
+				// the namespace of a deleted object in a tombstone is unknown, invalidate all of the decisions
+				s.invalidate("")
+			}))
+			utilruntime.Must(err)
+		}
+		if clusterRoleBindings != nil {
+			_, err := clusterRoleBindings.Informer().AddEventHandler(s.invalidateHandler(func(obj any) {
+				s.invalidate("")
+			}))
+			utilruntime.Must(err)
+		}
+	}
+}
+
 type SARAuthorizer struct {
 	kubeClient kubernetes.Interface
+
+	cacheOpts SARCacheOptions
+	cache     *cache.LRUExpireCache
+	// inflight deduplicates the concurrent reviews of the same decision.
+	inflight singleflight.Group
+	// generation is increased once the cached decisions are invalidated, so a decision that is reviewed
+	// before the invalidation is not cached.
+	generation atomic.Uint64
+}
+
+// decisionKey identifies a cached decision, it is built from the subject and resource attributes of
+// the SubjectAccessReview.
+type decisionKey struct {
+	user        string
+	groups      string
+	namespace   string
+	group       string
+	resource    string
+	verb        string
+	subresource string
+	name        string
+	selector    string
+}
+
+// decision is a cached SubjectAccessReview decision.
+type decision struct {
+	allowed bool
+	reason  string
 }
 
 // validate SARAuthorizer implement StreamAuthorizer and UnaryAuthorizer
@@ -61,13 +160,56 @@ func (c *wrappedAuthorizedStream) RecvMsg(m any) error {
 	msg.ClusterName = c.authorizedReq.ClusterName
 	msg.Source = c.authorizedReq.Source
 	msg.DataType = c.authorizedReq.DataType
+	msg.ResourceName = c.authorizedReq.ResourceName
+	msg.LabelSelector = c.authorizedReq.LabelSelector
 	return nil
 }
 
-func NewSARAuthorizer(kubeClient kubernetes.Interface) *SARAuthorizer {
-	return &SARAuthorizer{
+// publishAuthorizedStream authorizes each event of a publish stream. The denied event is acknowledged
+// with a PermissionDenied response directly, the same as the Publish request is denied, and it is not
+// passed to the stream handler.
+type publishAuthorizedStream struct {
+	grpc.ServerStream
+	authorizer *SARAuthorizer
+}
+
+// RecvMsg receives the next authorized publish stream request.
+func (p *publishAuthorizedStream) RecvMsg(m any) error {
+	msg, ok := m.(*pbv1.PublishStreamRequest)
+	if !ok {
+		return fmt.Errorf("unsupported request type %T", m)
+	}
+
+	for {
+		if err := p.ServerStream.RecvMsg(msg); err != nil {
+			return err
+		}
+
+		decision, err := p.authorizer.authorizeEvent(p.Context(), msg.Event)
+		if decision == authz.DecisionAllow {
+			return nil
+		}
+
+		// the stream handler sends the responses in the goroutine that receives the requests, so it is
+		// safe to send the response here.
+		if err := p.ServerStream.SendMsg(&pbv1.PublishStreamResponse{
+			Sequence: msg.Sequence,
+			Code:     int32(codes.PermissionDenied),
+			Message:  fmt.Sprintf("access denied: %v", err),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+func NewSARAuthorizer(kubeClient kubernetes.Interface, opts ...SAROption) *SARAuthorizer {
+	s := &SARAuthorizer{
 		kubeClient: kubeClient,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *SARAuthorizer) AuthorizeRequest(ctx context.Context, req any) (authz.Decision, error) {
@@ -76,37 +218,56 @@ func (s *SARAuthorizer) AuthorizeRequest(ctx context.Context, req any) (authz.De
 		return authz.DecisionDeny, fmt.Errorf("unsupported request type %T", req)
 	}
 
-	eventsType, err := types.ParseCloudEventsType(pReq.Event.Type)
+	return s.authorizeEvent(ctx, pReq.Event)
+}
+
+func (s *SARAuthorizer) authorizeEvent(ctx context.Context, pbEvt *pbv1.CloudEvent) (authz.Decision, error) {
+	if pbEvt == nil {
+		return authz.DecisionDeny, fmt.Errorf("missing event in request")
+	}
+
+	eventsType, err := types.ParseCloudEventsType(pbEvt.Type)
 	if err != nil {
 		return authz.DecisionDeny, err
 	}
 
 	// the event of grpc publish request is the original cloudevent data, we need a `ce-` prefix
 	// to get the event attribute
-	clusterAttr, ok := pReq.Event.Attributes[fmt.Sprintf("ce-%s", types.ExtensionClusterName)]
+	clusterAttr, ok := pbEvt.Attributes[fmt.Sprintf("ce-%s", types.ExtensionClusterName)]
 	if !ok {
-		return authz.DecisionDeny, fmt.Errorf("missing ce-clustername in event attributes, %v", pReq.Event.Attributes)
-	}
-
-	if pReq.Event == nil {
-		return authz.DecisionDeny, fmt.Errorf("missing event in request")
+		return authz.DecisionDeny, fmt.Errorf("missing ce-clustername in event attributes, %v", pbEvt.Attributes)
 	}
 
 	var partial metav1.PartialObjectMetadata
 
-	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pReq.Event))
+	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pbEvt))
 	if err != nil {
 		return authz.DecisionDeny, fmt.Errorf("failed to convert protobuf to cloudevent: %v", err)
 	}
-	if err := evt.DataAs(&partial); err != nil {
+	if evt.DataContentType() == types.ApplicationProtobuf {
+		// the protobuf event data does not carry the object metadata, the metadata is carried by the
+		// metadata extension instead, so the event is not authorized without the resource name.
+		metaAttr, ok := pbEvt.Attributes[fmt.Sprintf("ce-%s", types.ExtensionWorkMeta)]
+		if !ok {
+			return authz.DecisionDeny, fmt.Errorf("missing ce-%s in the protobuf event attributes", types.ExtensionWorkMeta)
+		}
+		if err := json.Unmarshal([]byte(metaAttr.GetCeString()), &partial.ObjectMeta); err != nil {
+			return authz.DecisionDeny, fmt.Errorf("failed to decode ce-%s of the event: %v", types.ExtensionWorkMeta, err)
+		}
+	} else if err := evt.DataAs(&partial); err != nil {
 		return authz.DecisionDeny, err
 	}
 
-	decision, err := s.authorize(ctx, clusterAttr.GetCeString(), *eventsType, partial.ObjectMeta)
+	decision, err := s.authorize(ctx, clusterAttr.GetCeString(), *eventsType, partial.ObjectMeta, "")
 	return decision, err
 }
 
 func (s *SARAuthorizer) AuthorizeStream(ctx context.Context, ss grpc.ServerStream, info *grpc.StreamServerInfo) (authz.Decision, grpc.ServerStream, error) {
+	if info.FullMethod == pbv1.CloudEventService_PublishStream_FullMethodName {
+		// the events of the publish stream are authorized one by one when they are received
+		return authz.DecisionAllow, &publishAuthorizedStream{ServerStream: ss, authorizer: s}, nil
+	}
+
 	if info.FullMethod != pbv1.CloudEventService_Subscribe_FullMethodName {
 		klog.V(4).Infof("unsupported service full method %s for SARAuthorizer", info.FullMethod)
 		return authz.DecisionNoOpinion, nil, nil
@@ -132,9 +293,10 @@ func (s *SARAuthorizer) AuthorizeStream(ctx context.Context, ss grpc.ServerStrea
 		Action:              types.WatchRequestAction,
 	}
 
-	// for now, we subscribe to all resources of a specified type
-	// TODO enhance the SubscriptionRequest to support specifying a resource name
-	decision, err := s.authorize(ss.Context(), req.ClusterName, eventsType, metav1.ObjectMeta{})
+	// the subscription with a resource name or label selector is authorized with the narrower attributes, so
+	// the subscriber can be only granted to the resources that it subscribes.
+	decision, err := s.authorize(ss.Context(), req.ClusterName, eventsType,
+		metav1.ObjectMeta{Name: req.ResourceName}, req.LabelSelector)
 	if err != nil {
 		return decision, nil, err
 	}
@@ -142,29 +304,119 @@ func (s *SARAuthorizer) AuthorizeStream(ctx context.Context, ss grpc.ServerStrea
 	return decision, &wrappedAuthorizedStream{ServerStream: ss, authorizedReq: &req}, nil
 }
 
-func (s *SARAuthorizer) authorize(ctx context.Context, cluster string, eventsType types.CloudEventsType, metaObj metav1.ObjectMeta) (authz.Decision, error) {
+func (s *SARAuthorizer) authorize(ctx context.Context, cluster string, eventsType types.CloudEventsType,
+	metaObj metav1.ObjectMeta, labelSelector string) (authz.Decision, error) {
 	user, groups, err := userInfo(ctx)
 	if err != nil {
 		return authz.DecisionDeny, err
 	}
 
-	sar, err := toSubjectAccessReview(cluster, user, groups, eventsType, metaObj)
+	sar, err := toSubjectAccessReview(cluster, user, groups, eventsType, metaObj, labelSelector)
 	if err != nil {
 		return authz.DecisionDeny, err
 	}
 
-	created, err := s.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(
-		ctx, sar, metav1.CreateOptions{})
+	d, err := s.reviewWithCache(ctx, sar)
 	if err != nil {
 		return authz.DecisionDeny, err
 	}
-	if !created.Status.Allowed {
+	if !d.allowed {
 		return authz.DecisionDeny, fmt.Errorf("the event %s is not allowed, (cluster=%s, sar=%v, reason=%v)",
-			eventsType, cluster, sar.Spec, created.Status)
+			eventsType, cluster, sar.Spec, d.reason)
 	}
 	return authz.DecisionAllow, nil
 }
 
+func (s *SARAuthorizer) reviewWithCache(ctx context.Context, sar *authv1.SubjectAccessReview) (*decision, error) {
+	if s.cache == nil {
+		return s.review(ctx, sar)
+	}
+
+	attrs := sar.Spec.ResourceAttributes
+	key := decisionKey{
+		user:        sar.Spec.User,
+		groups:      strings.Join(sar.Spec.Groups, ","),
+		namespace:   attrs.Namespace,
+		group:       attrs.Group,
+		resource:    attrs.Resource,
+		verb:        attrs.Verb,
+		subresource: attrs.Subresource,
+		name:        attrs.Name,
+	}
+	if attrs.LabelSelector != nil {
+		key.selector = attrs.LabelSelector.RawSelector
+	}
+	if cached, ok := s.cache.Get(key); ok {
+		metrics.IncreaseAuthCacheRequests(metrics.SubjectAccessReviewCache, metrics.CacheHit)
+		return cached.(*decision), nil
+	}
+	metrics.IncreaseAuthCacheRequests(metrics.SubjectAccessReviewCache, metrics.CacheMiss)
+
+	resultCh := s.inflight.DoChan(fmt.Sprintf("%#v", key), func() (any, error) {
+		generation := s.generation.Load()
+		// the review is shared by the concurrent requests, so it is not canceled with the first request.
+		d, err := s.review(context.WithoutCancel(ctx), sar)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := s.cacheOpts.DenyTTL
+		if d.allowed {
+			ttl = s.cacheOpts.AllowTTL
+		}
+		if ttl > 0 && generation == s.generation.Load() {
+			s.cache.Add(key, d, ttl)
+		}
+		return d, nil
+	})
+
+	select {
+	case r := <-resultCh:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*decision), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *SARAuthorizer) review(ctx context.Context, sar *authv1.SubjectAccessReview) (*decision, error) {
+	created, err := s.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(
+		ctx, sar, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &decision{allowed: created.Status.Allowed, reason: fmt.Sprintf("%v", created.Status)}, nil
+}
+
+// invalidate removes the cached decisions of the given namespace, all of the cached decisions are removed
+// if the namespace is empty.
+func (s *SARAuthorizer) invalidate(namespace string) {
+	if s.cache == nil {
+		return
+	}
+
+	s.generation.Add(1)
+	s.cache.RemoveAll(func(key any) bool {
+		return len(namespace) == 0 || key.(decisionKey).namespace == namespace
+	})
+}
+
+func (s *SARAuthorizer) invalidateHandler(invalidate func(obj any)) kubecache.ResourceEventHandler {
+	return kubecache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			invalidate(obj)
+		},
+		UpdateFunc: func(_, newObj any) {
+			invalidate(newObj)
+		},
+		DeleteFunc: func(obj any) {
+			invalidate(obj)
+		},
+	}
+}
+
 func userInfo(ctx context.Context) (user string, groups []string, err error) {
 	userValue := ctx.Value(authn.ContextUserKey)
 	groupsValue := ctx.Value(authn.ContextGroupsKey)
@@ -191,7 +443,8 @@ func userInfo(ctx context.Context) (user string, groups []string, err error) {
 	return user, groups, nil
 }
 
-func toSubjectAccessReview(clusterName string, user string, groups []string, eventsType types.CloudEventsType, metaObj metav1.ObjectMeta) (*authv1.SubjectAccessReview, error) {
+func toSubjectAccessReview(clusterName string, user string, groups []string, eventsType types.CloudEventsType,
+	metaObj metav1.ObjectMeta, labelSelector string) (*authv1.SubjectAccessReview, error) {
 	verb, err := toVerb(eventsType.Action)
 	if err != nil {
 		return nil, err
@@ -212,6 +465,11 @@ func toSubjectAccessReview(clusterName string, user string, groups []string, eve
 		sar.Spec.ResourceAttributes.Subresource = "status"
 	}
 
+	// the subscription of the resources that match a label selector
+	if verb == "watch" && len(labelSelector) != 0 {
+		sar.Spec.ResourceAttributes.LabelSelector = &authv1.LabelSelectorAttributes{RawSelector: labelSelector}
+	}
+
 	switch eventsType.CloudEventsDataType {
 	case cluster.ManagedClusterEventDataType:
 		sar.Spec.ResourceAttributes.Group = eventsType.Group
@@ -225,6 +483,10 @@ func toSubjectAccessReview(clusterName string, user string, groups []string, eve
 		lease.LeaseEventDataType:
 		sar.Spec.ResourceAttributes.Group = eventsType.Group
 		sar.Spec.ResourceAttributes.Resource = eventsType.Resource
+		if verb == "watch" {
+			// the subscription of the resource with a name
+			sar.Spec.ResourceAttributes.Name = metaObj.Name
+		}
 		return sar, nil
 	case serviceaccount.TokenRequestDataType:
 		sar.Spec.ResourceAttributes.Group = ""
@@ -236,9 +498,10 @@ func toSubjectAccessReview(clusterName string, user string, groups []string, eve
 			sar.Spec.ResourceAttributes.Name = metaObj.Name
 			return sar, nil
 		case "watch":
-			// for sub request, we use verb subscribe
-			// TODO enhance the SubscriptionRequest to support specifying a resource name
+			// for sub request, we use verb subscribe, the subscription of one service account token is
+			// authorized with its name
 			sar.Spec.ResourceAttributes.Verb = "subscribe"
+			sar.Spec.ResourceAttributes.Name = metaObj.Name
 			return sar, nil
 		}
 
@@ -246,6 +509,9 @@ func toSubjectAccessReview(clusterName string, user string, groups []string, eve
 	case payload.ManifestBundleEventDataType:
 		sar.Spec.ResourceAttributes.Group = workv1.GroupName
 		sar.Spec.ResourceAttributes.Resource = "manifestworks"
+		if verb == "watch" {
+			sar.Spec.ResourceAttributes.Name = metaObj.Name
+		}
 		return sar, nil
 	default:
 		return nil, fmt.Errorf("unsupported event type %s", eventsType.CloudEventsDataType)
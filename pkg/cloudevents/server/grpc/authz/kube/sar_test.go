@@ -3,18 +3,28 @@ package sar
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
 	authv1 "k8s.io/api/authorization/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	clienttesting "k8s.io/client-go/testing"
 
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	workv1 "open-cluster-management.io/api/work/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/addon/v1alpha1"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/cluster"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/lease"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/serviceaccount"
@@ -46,6 +56,25 @@ func TestSARAuthorizeRequest(t *testing.T) {
 	}
 
 	clusterData, _ := json.Marshal(clusterObj)
+	workMeta, _ := json.Marshal(metav1.ObjectMeta{Name: "test-work", Namespace: "test-cluster"})
+	protobufWorkEvent := func(attrs map[string]*pbv1.CloudEventAttributeValue) *pbv1.PublishRequest {
+		attrs["ce-clustername"] = &pbv1.CloudEventAttributeValue{
+			Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: "test-cluster"},
+		}
+		attrs["contenttype"] = &pbv1.CloudEventAttributeValue{
+			Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: types.ApplicationProtobuf},
+		}
+		return &pbv1.PublishRequest{
+			Event: &pbv1.CloudEvent{
+				SpecVersion: "1.0",
+				Id:          "test-id",
+				Source:      "test-source",
+				Type:        "io.open-cluster-management.works.v1alpha1.manifestbundles.spec.update_request",
+				Attributes:  attrs,
+				Data:        &pbv1.CloudEvent_BinaryData{BinaryData: []byte{0x0a, 0x02, 0x7b, 0x7d}},
+			},
+		}
+	}
 
 	testCases := []testCase{
 		{
@@ -121,6 +150,32 @@ func TestSARAuthorizeRequest(t *testing.T) {
 			expectErr:    true,
 			expectDenied: true,
 		},
+		{
+			name: "allowed for protobuf work with metadata extension",
+			request: protobufWorkEvent(map[string]*pbv1.CloudEventAttributeValue{
+				"ce-metadata": {Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: string(workMeta)}},
+			}),
+			userCtx: func() context.Context {
+				return context.WithValue(context.Background(), authn.ContextUserKey, "test-user")
+			},
+			allow: func(sar *authv1.SubjectAccessReview) bool {
+				return sar.Spec.ResourceAttributes.Resource == "manifestworks" &&
+					sar.Spec.ResourceAttributes.Verb == "update" &&
+					sar.Spec.ResourceAttributes.Namespace == "test-cluster"
+			},
+		},
+		{
+			name:    "denied for protobuf work without metadata extension",
+			request: protobufWorkEvent(map[string]*pbv1.CloudEventAttributeValue{}),
+			userCtx: func() context.Context {
+				return context.WithValue(context.Background(), authn.ContextUserKey, "test-user")
+			},
+			allow: func(sar *authv1.SubjectAccessReview) bool {
+				return true
+			},
+			expectErr:    true,
+			expectDenied: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -167,13 +222,14 @@ func TestSARAuthorizeRequest(t *testing.T) {
 
 func TestSARAuthorize(t *testing.T) {
 	type testCase struct {
-		name         string
-		cluster      string
-		resourceName string
-		eventsType   types.CloudEventsType
-		userCtx      func() context.Context
-		allow        func(sar *authv1.SubjectAccessReview) bool
-		expectErr    bool
+		name          string
+		cluster       string
+		resourceName  string
+		labelSelector string
+		eventsType    types.CloudEventsType
+		userCtx       func() context.Context
+		allow         func(sar *authv1.SubjectAccessReview) bool
+		expectErr     bool
 	}
 
 	testCases := []testCase{
@@ -272,6 +328,65 @@ func TestSARAuthorize(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:         "allowed for one service account token subscription",
+			cluster:      "cluster1",
+			resourceName: "test-sa",
+			eventsType: types.CloudEventsType{
+				CloudEventsDataType: serviceaccount.TokenRequestDataType,
+				SubResource:         types.SubResourceSpec,
+				Action:              types.WatchRequestAction,
+			},
+			userCtx: func() context.Context {
+				return context.WithValue(context.Background(), authn.ContextUserKey, "test")
+			},
+			allow: func(sar *authv1.SubjectAccessReview) bool {
+				return sar.Spec.ResourceAttributes.Resource == "serviceaccounts" &&
+					sar.Spec.ResourceAttributes.Subresource == "token" &&
+					sar.Spec.ResourceAttributes.Name == "test-sa" &&
+					sar.Spec.ResourceAttributes.Verb == "subscribe"
+			},
+			expectErr: false,
+		},
+		{
+			name:          "allowed for addon subscription with name and label selector",
+			cluster:       "cluster1",
+			resourceName:  "addon1",
+			labelSelector: "app=addon1",
+			eventsType: types.CloudEventsType{
+				CloudEventsDataType: v1alpha1.ManagedClusterAddOnEventDataType,
+				SubResource:         types.SubResourceSpec,
+				Action:              types.WatchRequestAction,
+			},
+			userCtx: func() context.Context {
+				return context.WithValue(context.Background(), authn.ContextUserKey, "test")
+			},
+			allow: func(sar *authv1.SubjectAccessReview) bool {
+				attrs := sar.Spec.ResourceAttributes
+				return attrs.Resource == "managedclusteraddons" &&
+					attrs.Name == "addon1" &&
+					attrs.Verb == "watch" &&
+					attrs.LabelSelector != nil && attrs.LabelSelector.RawSelector == "app=addon1"
+			},
+			expectErr: false,
+		},
+		{
+			name:         "denied for addon subscription of another name",
+			cluster:      "cluster1",
+			resourceName: "addon2",
+			eventsType: types.CloudEventsType{
+				CloudEventsDataType: v1alpha1.ManagedClusterAddOnEventDataType,
+				SubResource:         types.SubResourceSpec,
+				Action:              types.WatchRequestAction,
+			},
+			userCtx: func() context.Context {
+				return context.WithValue(context.Background(), authn.ContextUserKey, "test")
+			},
+			allow: func(sar *authv1.SubjectAccessReview) bool {
+				return sar.Spec.ResourceAttributes.Name == "addon1"
+			},
+			expectErr: true,
+		},
 		{
 			name:    "allowed for manifest status update",
 			cluster: "cluster1",
@@ -412,7 +527,7 @@ func TestSARAuthorize(t *testing.T) {
 				metaObj.Name = tc.resourceName
 			}
 
-			decision, err := auth.authorize(tc.userCtx(), tc.cluster, tc.eventsType, metaObj)
+			decision, err := auth.authorize(tc.userCtx(), tc.cluster, tc.eventsType, metaObj, tc.labelSelector)
 			if tc.expectErr && err == nil {
 				t.Errorf("expected error, got nil")
 			}
@@ -428,3 +543,184 @@ func TestSARAuthorize(t *testing.T) {
 		})
 	}
 }
+
+// fakePublishStream is a fake publish server stream, it receives the queued requests and records the sent responses.
+type fakePublishStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	requests  []*pbv1.PublishStreamRequest
+	responses []*pbv1.PublishStreamResponse
+}
+
+func (s *fakePublishStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakePublishStream) RecvMsg(m any) error {
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+
+	msg := m.(*pbv1.PublishStreamRequest)
+	proto.Reset(msg)
+	proto.Merge(msg, s.requests[0])
+	s.requests = s.requests[1:]
+	return nil
+}
+
+func (s *fakePublishStream) SendMsg(m any) error {
+	s.responses = append(s.responses, m.(*pbv1.PublishStreamResponse))
+	return nil
+}
+
+func TestSARAuthorizePublishStream(t *testing.T) {
+	newRequest := func(sequence uint64, clusterName string) *pbv1.PublishStreamRequest {
+		return &pbv1.PublishStreamRequest{
+			Sequence: sequence,
+			Event: &pbv1.CloudEvent{
+				SpecVersion: "1.0",
+				Id:          "test-id",
+				Source:      "test-source",
+				Type:        "coordination.k8s.io.v1.leases.status.update_request",
+				Attributes: map[string]*pbv1.CloudEventAttributeValue{
+					"ce-clustername": {
+						Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: clusterName},
+					},
+				},
+				Data: &pbv1.CloudEvent_BinaryData{BinaryData: []byte("{}")},
+			},
+		}
+	}
+
+	client := fake.NewSimpleClientset()
+	client.Fake.PrependReactor(
+		"create",
+		"subjectaccessreviews",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			sar := action.(clienttesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+			allowed := sar.Spec.ResourceAttributes.Namespace == "cluster1"
+			return true, &authv1.SubjectAccessReview{Status: authv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+		},
+	)
+
+	stream := &fakePublishStream{
+		ctx: context.WithValue(context.Background(), authn.ContextUserKey, "test-user"),
+		requests: []*pbv1.PublishStreamRequest{
+			newRequest(1, "cluster1"),
+			newRequest(2, "cluster2"),
+			newRequest(3, "cluster1"),
+		},
+	}
+
+	decision, authorizedStream, err := NewSARAuthorizer(client).AuthorizeStream(stream.Context(), stream,
+		&grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_PublishStream_FullMethodName, IsClientStream: true, IsServerStream: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision != authz.DecisionAllow {
+		t.Fatalf("expected DecisionAllow, got %v", decision)
+	}
+
+	// the denied request is acknowledged by the authorizer and skipped
+	var received []uint64
+	for {
+		req := &pbv1.PublishStreamRequest{}
+		if err := authorizedStream.RecvMsg(req); err != nil {
+			if err != io.EOF {
+				t.Errorf("unexpected error: %v", err)
+			}
+			break
+		}
+		received = append(received, req.Sequence)
+	}
+
+	if len(received) != 2 || received[0] != 1 || received[1] != 3 {
+		t.Errorf("expected requests [1 3], got %v", received)
+	}
+	if len(stream.responses) != 1 {
+		t.Fatalf("expected 1 response, got %d", len(stream.responses))
+	}
+	if stream.responses[0].Sequence != 2 || codes.Code(stream.responses[0].Code) != codes.PermissionDenied {
+		t.Errorf("unexpected response %v", stream.responses[0])
+	}
+}
+
+func TestSARDecisionCache(t *testing.T) {
+	var reviews atomic.Int32
+	client := fake.NewClientset()
+	client.PrependReactor(
+		"create",
+		"subjectaccessreviews",
+		func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+			reviews.Add(1)
+			sar := action.(clienttesting.CreateAction).GetObject().(*authv1.SubjectAccessReview)
+			// the users are allowed to update the lease of the cluster1 only
+			allowed := sar.Spec.ResourceAttributes.Namespace == "cluster1"
+			return true, &authv1.SubjectAccessReview{Status: authv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+		},
+	)
+
+	informerFactory := informers.NewSharedInformerFactory(client, 0)
+	roleBindings := informerFactory.Rbac().V1().RoleBindings()
+	clusterRoleBindings := informerFactory.Rbac().V1().ClusterRoleBindings()
+	auth := NewSARAuthorizer(client,
+		WithDecisionCache(SARCacheOptions{MaxSize: 10, AllowTTL: time.Minute, DenyTTL: time.Minute}),
+		WithRBACInformers(roleBindings, clusterRoleBindings))
+
+	eventsType := types.CloudEventsType{
+		CloudEventsDataType: lease.LeaseEventDataType,
+		SubResource:         types.SubResourceStatus,
+		Action:              types.UpdateRequestAction,
+	}
+	authorize := func(user, cluster string, expected authz.Decision, expectedReviews int32) {
+		t.Helper()
+		ctx := context.WithValue(context.Background(), authn.ContextUserKey, user)
+		decision, _ := auth.authorize(ctx, cluster, eventsType, metav1.ObjectMeta{}, "")
+		if decision != expected {
+			t.Errorf("expected %v, got %v", expected, decision)
+		}
+		if reviews.Load() != expectedReviews {
+			t.Errorf("expected %d reviews, got %d", expectedReviews, reviews.Load())
+		}
+	}
+
+	// the allowed and denied decisions are cached
+	authorize("user1", "cluster1", authz.DecisionAllow, 1)
+	authorize("user1", "cluster1", authz.DecisionAllow, 1)
+	authorize("user1", "cluster2", authz.DecisionDeny, 2)
+	authorize("user1", "cluster2", authz.DecisionDeny, 2)
+	// the decisions are cached per user
+	authorize("user2", "cluster1", authz.DecisionAllow, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+	waitForInvalidation := func(remaining int) {
+		t.Helper()
+		if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true,
+			func(ctx context.Context) (bool, error) {
+				return len(auth.cache.Keys()) == remaining, nil
+			}); err != nil {
+			t.Errorf("expected %d decisions are cached, got %d", remaining, len(auth.cache.Keys()))
+		}
+	}
+
+	// the role binding change invalidates the decisions of its namespace
+	if _, err := client.RbacV1().RoleBindings("cluster2").Create(
+		ctx, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Namespace: "cluster2", Name: "test"}},
+		metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForInvalidation(2)
+	authorize("user1", "cluster1", authz.DecisionAllow, 3)
+	authorize("user1", "cluster2", authz.DecisionDeny, 4)
+
+	// the cluster role binding change invalidates all of the decisions
+	if _, err := client.RbacV1().ClusterRoleBindings().Create(
+		ctx, &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "test"}}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	waitForInvalidation(0)
+	authorize("user1", "cluster1", authz.DecisionAllow, 5)
+}
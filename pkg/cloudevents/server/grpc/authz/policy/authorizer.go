@@ -0,0 +1,311 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/work/payload"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/audit"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
+)
+
+// PolicyAuthorizer authorizes the cloudevents gRPC requests with the CEL policy rules, so the requests can be
+// authorized without the RBAC of a hub kube-apiserver. The policy is loaded from a file, and it is reloaded
+// once the file is changed. The cloudevents requests that are not allowed by a rule are denied, the authorizer
+// has no opinion on the other requests.
+type PolicyAuthorizer struct {
+	policy *policyFile
+}
+
+// validate PolicyAuthorizer implement StreamAuthorizer and UnaryAuthorizer
+var _ authz.StreamAuthorizer = (*PolicyAuthorizer)(nil)
+var _ authz.UnaryAuthorizer = (*PolicyAuthorizer)(nil)
+
+// NewPolicyAuthorizer returns a PolicyAuthorizer with the policy file, an error is returned if the policy file
+// is invalid.
+func NewPolicyAuthorizer(policyFile string) (*PolicyAuthorizer, error) {
+	policy, err := newPolicyFile(policyFile)
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyAuthorizer{policy: policy}, nil
+}
+
+// request is the cloudevents request to authorize, it is converted to the variables of the rule expressions.
+type request struct {
+	method      string
+	clusterName string
+	eventType   types.CloudEventsType
+	extensions  map[string]string
+	metadata    map[string]any
+}
+
+func (a *PolicyAuthorizer) AuthorizeRequest(ctx context.Context, req any) (authz.Decision, error) {
+	pReq, ok := req.(*pbv1.PublishRequest)
+	if !ok {
+		return authz.DecisionNoOpinion, nil
+	}
+
+	return a.authorizeEvent(ctx, pReq.Event)
+}
+
+func (a *PolicyAuthorizer) AuthorizeStream(ctx context.Context, ss grpc.ServerStream, info *grpc.StreamServerInfo) (authz.Decision, grpc.ServerStream, error) {
+	switch info.FullMethod {
+	case pbv1.CloudEventService_PublishStream_FullMethodName:
+		// the events of the publish stream are authorized one by one when they are received
+		return authz.DecisionAllow, &publishAuthorizedStream{ServerStream: ss, authorizer: a}, nil
+	case pbv1.CloudEventService_Subscribe_FullMethodName:
+	default:
+		return authz.DecisionNoOpinion, nil, nil
+	}
+
+	var req pbv1.SubscriptionRequest
+	if err := ss.RecvMsg(&req); err != nil {
+		return authz.DecisionDeny, nil, err
+	}
+
+	eventDataType, err := types.ParseCloudEventsDataType(req.DataType)
+	if err != nil {
+		return authz.DecisionDeny, nil, err
+	}
+
+	decision, err := a.authorize(ss.Context(), &request{
+		method:      MethodSubscribe,
+		clusterName: req.ClusterName,
+		eventType: types.CloudEventsType{
+			CloudEventsDataType: *eventDataType,
+			SubResource:         types.SubResourceSpec,
+			Action:              types.WatchRequestAction,
+		},
+	})
+	if err != nil {
+		return decision, nil, err
+	}
+
+	return decision, &subscriptionAuthorizedStream{ServerStream: ss, authorizedReq: &req}, nil
+}
+
+func (a *PolicyAuthorizer) authorizeEvent(ctx context.Context, pbEvt *pbv1.CloudEvent) (authz.Decision, error) {
+	if pbEvt == nil {
+		return authz.DecisionDeny, fmt.Errorf("missing event in request")
+	}
+
+	eventsType, err := types.ParseCloudEventsType(pbEvt.Type)
+	if err != nil {
+		return authz.DecisionDeny, err
+	}
+
+	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pbEvt))
+	if err != nil {
+		return authz.DecisionDeny, fmt.Errorf("failed to convert protobuf to cloudevent: %v", err)
+	}
+
+	extensions := map[string]string{}
+	for name, value := range evt.Extensions() {
+		extensions[name] = fmt.Sprintf("%v", value)
+	}
+
+	objectMeta, err := eventObjectMeta(*eventsType, evt)
+	if err != nil {
+		return authz.DecisionDeny, err
+	}
+	metadata, err := common.ConvertObjectToUnstructured(&objectMeta)
+	if err != nil {
+		return authz.DecisionDeny, err
+	}
+
+	return a.authorize(ctx, &request{
+		method:      MethodPublish,
+		clusterName: extensions[types.ExtensionClusterName],
+		eventType:   *eventsType,
+		extensions:  extensions,
+		metadata:    metadata.Object,
+	})
+}
+
+// eventObjectMeta returns the metadata of the event resource. The work metadata is carried by the metadata
+// extension and the protobuf event data does not carry the metadata, so these events are denied without a valid
+// metadata extension rather than evaluated with an empty metadata.
+func eventObjectMeta(eventsType types.CloudEventsType, evt *cloudevents.Event) (metav1.ObjectMeta, error) {
+	if value, ok := evt.Extensions()[types.ExtensionWorkMeta]; ok {
+		metaJSON, err := cloudeventstypes.ToString(value)
+		if err != nil {
+			return metav1.ObjectMeta{}, fmt.Errorf("invalid %s extension of the event: %v", types.ExtensionWorkMeta, err)
+		}
+		meta := metav1.ObjectMeta{}
+		if err := json.Unmarshal([]byte(metaJSON), &meta); err != nil {
+			return metav1.ObjectMeta{}, fmt.Errorf("failed to decode %s extension of the event: %v", types.ExtensionWorkMeta, err)
+		}
+		return meta, nil
+	}
+
+	if eventsType.CloudEventsDataType == payload.ManifestBundleEventDataType ||
+		evt.DataContentType() == types.ApplicationProtobuf {
+		return metav1.ObjectMeta{}, fmt.Errorf("missing %s extension in the event", types.ExtensionWorkMeta)
+	}
+
+	var partial metav1.PartialObjectMetadata
+	if err := evt.DataAs(&partial); err != nil {
+		return metav1.ObjectMeta{}, err
+	}
+	return partial.ObjectMeta, nil
+}
+
+// authorize evaluates the policy rules in order, the first matched rule decides the request. A rule that
+// fails to evaluate denies the request, so a broken rule never allows a request by mistake.
+func (a *PolicyAuthorizer) authorize(ctx context.Context, req *request) (authz.Decision, error) {
+	logger := klog.FromContext(ctx)
+
+	user, _ := ctx.Value(authn.ContextUserKey).(string)
+	groups, _ := ctx.Value(authn.ContextGroupsKey).([]string)
+	if groups == nil {
+		groups = []string{}
+	}
+
+	rules, err := a.policy.load()
+	if err != nil {
+		logger.Error(err, "failed to reload the authorization policy, use the last loaded policy")
+	}
+
+	metadata := req.metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	extensions := req.extensions
+	if extensions == nil {
+		extensions = map[string]string{}
+	}
+	vars := map[string]any{
+		UserVarName:        user,
+		GroupsVarName:      groups,
+		MethodVarName:      req.method,
+		ClusterNameVarName: req.clusterName,
+		EventTypeVarName: map[string]string{
+			"group":       req.eventType.Group,
+			"version":     req.eventType.Version,
+			"resource":    req.eventType.Resource,
+			"subResource": string(req.eventType.SubResource),
+			"action":      string(req.eventType.Action),
+		},
+		ExtensionsVarName: extensions,
+		MetadataVarName:   metadata,
+	}
+
+	decision, rule, err := evaluate(rules, vars)
+	// record the decision of the request in its audit event
+	if decision == authz.DecisionAllow {
+		audit.AddAnnotation(ctx, audit.AnnotationAuthorizationDecision, "allow")
+	} else {
+		audit.AddAnnotation(ctx, audit.AnnotationAuthorizationDecision, "deny")
+		if err != nil {
+			audit.AddAnnotation(ctx, audit.AnnotationAuthorizationReason, err.Error())
+		}
+	}
+	if len(rule) != 0 {
+		audit.AddAnnotation(ctx, audit.AnnotationAuthorizationRule, rule)
+	}
+	logger.V(4).Info("authorization decision",
+		"user", user, "groups", groups, "method", req.method, "clusterName", req.clusterName,
+		"eventType", req.eventType.String(), "allowed", decision == authz.DecisionAllow, "rule", rule, "reason", err)
+	return decision, err
+}
+
+// evaluate returns the decision and the name of the rule that decides the request.
+func evaluate(rules []compiledRule, vars map[string]any) (authz.Decision, string, error) {
+	for _, rule := range rules {
+		out, _, err := rule.program.Eval(vars)
+		if err != nil {
+			return authz.DecisionDeny, rule.Name, fmt.Errorf("failed to evaluate rule %s: %v", rule.Name, err)
+		}
+
+		matched, ok := out.Value().(bool)
+		if !ok {
+			return authz.DecisionDeny, rule.Name, fmt.Errorf("rule %s evaluates to %v, but expected bool", rule.Name, out)
+		}
+		if !matched {
+			continue
+		}
+
+		if rule.Effect == EffectAllow {
+			return authz.DecisionAllow, rule.Name, nil
+		}
+		return authz.DecisionDeny, rule.Name, fmt.Errorf("the request is denied by rule %s", rule.Name)
+	}
+
+	return authz.DecisionDeny, "", fmt.Errorf("no policy rule allows the request")
+}
+
+// subscriptionAuthorizedStream caches the subscription request that is already read.
+type subscriptionAuthorizedStream struct {
+	sync.Mutex
+
+	grpc.ServerStream
+	authorizedReq *pbv1.SubscriptionRequest
+}
+
+// RecvMsg set the msg from the cache.
+func (s *subscriptionAuthorizedStream) RecvMsg(m any) error {
+	s.Lock()
+	defer s.Unlock()
+
+	msg, ok := m.(*pbv1.SubscriptionRequest)
+	if !ok {
+		return fmt.Errorf("unsupported request type %T", m)
+	}
+
+	msg.ClusterName = s.authorizedReq.ClusterName
+	msg.Source = s.authorizedReq.Source
+	msg.DataType = s.authorizedReq.DataType
+	msg.ResourceName = s.authorizedReq.ResourceName
+	msg.LabelSelector = s.authorizedReq.LabelSelector
+	return nil
+}
+
+// publishAuthorizedStream authorizes each event of a publish stream. The event that is not allowed is
+// acknowledged with a PermissionDenied response directly, and it is not passed to the stream handler.
+type publishAuthorizedStream struct {
+	grpc.ServerStream
+	authorizer *PolicyAuthorizer
+}
+
+// RecvMsg receives the next authorized publish stream request.
+func (p *publishAuthorizedStream) RecvMsg(m any) error {
+	msg, ok := m.(*pbv1.PublishStreamRequest)
+	if !ok {
+		return fmt.Errorf("unsupported request type %T", m)
+	}
+
+	for {
+		if err := p.ServerStream.RecvMsg(msg); err != nil {
+			return err
+		}
+
+		decision, err := p.authorizer.authorizeEvent(p.Context(), msg.Event)
+		if decision == authz.DecisionAllow {
+			return nil
+		}
+
+		if err := p.ServerStream.SendMsg(&pbv1.PublishStreamResponse{
+			Sequence: msg.Sequence,
+			Code:     int32(codes.PermissionDenied),
+			Message:  fmt.Sprintf("access denied: %v", err),
+		}); err != nil {
+			return err
+		}
+	}
+}
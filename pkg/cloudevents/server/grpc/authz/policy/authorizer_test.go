@@ -0,0 +1,307 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
+)
+
+const testPolicy = `
+rules:
+- name: deny-blocked-clusters
+  effect: Deny
+  expression: clusterName.startsWith('blocked-')
+- name: deny-protected-resources
+  effect: Deny
+  expression: metadata.?labels.?protected.orValue('') == 'true'
+- name: agents-update-own-status
+  effect: Allow
+  expression: >-
+    method == 'publish' && 'system:open-cluster-management:' + clusterName in groups &&
+    eventType.subResource == 'status'
+- name: agents-subscribe-own-spec
+  effect: Allow
+  expression: method == 'subscribe' && user == 'agent:' + clusterName
+- name: source-manages-labeled-works
+  effect: Allow
+  expression: >-
+    user == 'source' && eventType.resource == 'manifestbundles' &&
+    metadata.?labels.?app.orValue('') == 'test' && extensions['resourceversion'] != '0'
+`
+
+func TestPolicyAuthorizeRequest(t *testing.T) {
+	cases := []struct {
+		name     string
+		user     string
+		groups   []string
+		event    *pbv1.CloudEvent
+		expected authz.Decision
+	}{
+		{
+			name:     "agent updates status of its cluster",
+			user:     "agent:cluster1",
+			groups:   []string{"system:open-cluster-management:cluster1"},
+			event:    newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request", "cluster1", "{}", "{}"),
+			expected: authz.DecisionAllow,
+		},
+		{
+			name:     "agent updates status of another cluster",
+			user:     "agent:cluster1",
+			groups:   []string{"system:open-cluster-management:cluster1"},
+			event:    newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request", "cluster2", "{}", "{}"),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:     "agent updates spec of its cluster",
+			user:     "agent:cluster1",
+			groups:   []string{"system:open-cluster-management:cluster1"},
+			event:    newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.update_request", "cluster1", "{}", "{}"),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:     "blocked cluster is denied",
+			user:     "agent:blocked-cluster",
+			groups:   []string{"system:open-cluster-management:blocked-cluster"},
+			event:    newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request", "blocked-cluster", "{}", "{}"),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name: "source creates a labeled work",
+			user: "source",
+			event: newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request", "cluster1",
+				`{"name":"work1","labels":{"app":"test"}}`, "{}"),
+			expected: authz.DecisionAllow,
+		},
+		{
+			name: "source creates an unlabeled work",
+			user: "source",
+			event: newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request", "cluster1",
+				`{"name":"work1"}`, "{}"),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name: "source creates a labeled work with protobuf data",
+			user: "source",
+			event: newProtobufEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request",
+				"cluster1", `{"name":"work1","labels":{"app":"test"}}`),
+			expected: authz.DecisionAllow,
+		},
+		{
+			name:   "protected work with protobuf data is denied",
+			user:   "agent:cluster1",
+			groups: []string{"system:open-cluster-management:cluster1"},
+			event: newProtobufEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request",
+				"cluster1", `{"name":"work1","labels":{"protected":"true"}}`),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:   "protobuf work without metadata extension is denied",
+			user:   "agent:cluster1",
+			groups: []string{"system:open-cluster-management:cluster1"},
+			event: newProtobufEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request",
+				"cluster1", ""),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name: "work without metadata extension is denied",
+			user: "source",
+			event: newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.spec.create_request", "cluster1",
+				"", `{"metadata":{"name":"work1","labels":{"app":"test"}}}`),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:   "work with malformed metadata extension is denied",
+			user:   "agent:cluster1",
+			groups: []string{"system:open-cluster-management:cluster1"},
+			event: newEvent("io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request", "cluster1",
+				`{"name":`, "{}"),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:   "protected addon is denied with the metadata of its data",
+			user:   "agent:cluster1",
+			groups: []string{"system:open-cluster-management:cluster1"},
+			event: newEvent("addon.open-cluster-management.io.v1alpha1.managedclusteraddons.status.update_request",
+				"cluster1", "", `{"metadata":{"name":"addon1","labels":{"protected":"true"}}}`),
+			expected: authz.DecisionDeny,
+		},
+		{
+			name:   "agent updates addon status of its cluster",
+			user:   "agent:cluster1",
+			groups: []string{"system:open-cluster-management:cluster1"},
+			event: newEvent("addon.open-cluster-management.io.v1alpha1.managedclusteraddons.status.update_request",
+				"cluster1", "", `{"metadata":{"name":"addon1"}}`),
+			expected: authz.DecisionAllow,
+		},
+	}
+
+	authorizer, err := NewPolicyAuthorizer(writePolicy(t, filepath.Join(t.TempDir(), "policy.yaml"), testPolicy, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), authn.ContextUserKey, c.user)
+			ctx = context.WithValue(ctx, authn.ContextGroupsKey, c.groups)
+			decision, err := authorizer.AuthorizeRequest(ctx, &pbv1.PublishRequest{Event: c.event})
+			if decision != c.expected {
+				t.Errorf("expected decision %v, but got %v, %v", c.expected, decision, err)
+			}
+		})
+	}
+
+	// the authorizer has no opinion on the other requests
+	if decision, _ := authorizer.AuthorizeRequest(context.Background(), &pbv1.SubscriptionRequest{}); decision != authz.DecisionNoOpinion {
+		t.Errorf("expected no opinion, but got %v", decision)
+	}
+}
+
+func TestPolicyAuthorizeSubscription(t *testing.T) {
+	authorizer, err := NewPolicyAuthorizer(writePolicy(t, filepath.Join(t.TempDir(), "policy.yaml"), testPolicy, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_Subscribe_FullMethodName, IsServerStream: true}
+	for _, cluster := range []string{"cluster1", "cluster2"} {
+		req := &pbv1.SubscriptionRequest{
+			Source:      "test",
+			ClusterName: cluster,
+			DataType:    "io.open-cluster-management.works.v1alpha1.manifestbundles",
+		}
+		stream := &fakeSubscribeStream{
+			ctx: context.WithValue(context.Background(), authn.ContextUserKey, "agent:cluster1"),
+			req: req,
+		}
+
+		decision, authorizedStream, err := authorizer.AuthorizeStream(stream.Context(), stream, info)
+		if cluster == "cluster2" {
+			if decision != authz.DecisionDeny {
+				t.Errorf("expected the subscription of cluster2 is denied, but got %v, %v", decision, err)
+			}
+			continue
+		}
+
+		if decision != authz.DecisionAllow {
+			t.Fatalf("expected the subscription of cluster1 is allowed, but got %v, %v", decision, err)
+		}
+		// the authorized request is received from the authorized stream
+		received := &pbv1.SubscriptionRequest{}
+		if err := authorizedStream.RecvMsg(received); err != nil {
+			t.Fatal(err)
+		}
+		if received.ClusterName != cluster || received.DataType != req.DataType || received.Source != req.Source {
+			t.Errorf("unexpected subscription request %v", received)
+		}
+	}
+}
+
+func TestPolicyReload(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if _, err := NewPolicyAuthorizer(policyPath); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+	if _, err := NewPolicyAuthorizer(writePolicy(t, policyPath, "rules: [{name: test, effect: Allow, expression: user}]", time.Now())); err == nil {
+		t.Errorf("expected error for the non-bool expression, but got nil")
+	}
+
+	allowAll := "rules: [{name: allow-all, effect: Allow, expression: 'true'}]"
+	authorizer, err := NewPolicyAuthorizer(writePolicy(t, policyPath, allowAll, time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.WithValue(context.Background(), authn.ContextUserKey, "test")
+	req := &pbv1.PublishRequest{Event: newEvent(
+		"io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request", "cluster1", "{}", "{}")}
+	if decision, err := authorizer.AuthorizeRequest(ctx, req); decision != authz.DecisionAllow {
+		t.Errorf("expected allowed, but got %v, %v", decision, err)
+	}
+
+	// the changed policy is reloaded
+	writePolicy(t, policyPath, "rules: [{name: deny-all, effect: Deny, expression: 'true'}]", time.Now().Add(time.Minute))
+	if decision, _ := authorizer.AuthorizeRequest(ctx, req); decision != authz.DecisionDeny {
+		t.Errorf("expected denied, but got %v", decision)
+	}
+
+	// the last loaded policy is kept if the changed policy is invalid
+	writePolicy(t, policyPath, "rules: [{name: invalid, effect: Allow, expression: 'invalid('}]", time.Now().Add(2*time.Minute))
+	if decision, _ := authorizer.AuthorizeRequest(ctx, req); decision != authz.DecisionDeny {
+		t.Errorf("expected denied, but got %v", decision)
+	}
+}
+
+type fakeSubscribeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *pbv1.SubscriptionRequest
+}
+
+func (s *fakeSubscribeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSubscribeStream) RecvMsg(m any) error {
+	msg := m.(*pbv1.SubscriptionRequest)
+	msg.Source = s.req.Source
+	msg.ClusterName = s.req.ClusterName
+	msg.DataType = s.req.DataType
+	return nil
+}
+
+// newEvent returns an event with the JSON data, the metadata extension is set if it is not empty.
+func newEvent(eventType, clusterName, meta, data string) *pbv1.CloudEvent {
+	evt := &pbv1.CloudEvent{
+		SpecVersion: "1.0",
+		Id:          "test-id",
+		Source:      "test-source",
+		Type:        eventType,
+		Attributes: map[string]*pbv1.CloudEventAttributeValue{
+			"ce-clustername": {
+				Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: clusterName},
+			},
+			"ce-resourceversion": {
+				Attr: &pbv1.CloudEventAttributeValue_CeInteger{CeInteger: 1},
+			},
+			"datacontenttype": {
+				Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: "application/json"},
+			},
+		},
+		Data: &pbv1.CloudEvent_BinaryData{BinaryData: []byte(data)},
+	}
+	if len(meta) != 0 {
+		evt.Attributes["ce-metadata"] = &pbv1.CloudEventAttributeValue{
+			Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: meta},
+		}
+	}
+	return evt
+}
+
+// newProtobufEvent returns an event with the protobuf data, the metadata extension is set if it is not empty.
+func newProtobufEvent(eventType, clusterName, meta string) *pbv1.CloudEvent {
+	evt := newEvent(eventType, clusterName, meta, "")
+	evt.Attributes["datacontenttype"] = &pbv1.CloudEventAttributeValue{
+		Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: "application/protobuf"},
+	}
+	evt.Data = &pbv1.CloudEvent_BinaryData{BinaryData: []byte{0x0a, 0x00}}
+	return evt
+}
+
+func writePolicy(t *testing.T, path, policy string, modTime time.Time) string {
+	if err := os.WriteFile(path, []byte(policy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,172 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"sigs.k8s.io/yaml"
+
+	"open-cluster-management.io/sdk-go/pkg/cel/common"
+	"open-cluster-management.io/sdk-go/pkg/cel/library"
+)
+
+// The CEL variables of the policy rule expressions.
+const (
+	// UserVarName is the name of the authenticated user.
+	UserVarName = "user"
+	// GroupsVarName is the list of the groups of the authenticated user.
+	GroupsVarName = "groups"
+	// MethodVarName is the request method, either publish or subscribe.
+	MethodVarName = "method"
+	// ClusterNameVarName is the cluster name of the event or subscription.
+	ClusterNameVarName = "clusterName"
+	// EventTypeVarName is the parsed CloudEventsType, it is a map with the keys group, version, resource,
+	// subResource and action.
+	EventTypeVarName = "eventType"
+	// ExtensionsVarName is the map of the event extensions, the values are converted to strings.
+	ExtensionsVarName = "extensions"
+	// MetadataVarName is the object metadata of the event resource, e.g. metadata.name and metadata.labels,
+	// it is decoded from the metadata extension of the work events or from the event data, and it is empty for
+	// a subscription.
+	MetadataVarName = "metadata"
+)
+
+// The request methods.
+const (
+	MethodPublish   = "publish"
+	MethodSubscribe = "subscribe"
+)
+
+// Effect is the decision of a matched policy rule.
+type Effect string
+
+const (
+	EffectAllow Effect = "Allow"
+	EffectDeny  Effect = "Deny"
+)
+
+// Rule is an authorization rule, the rule is matched if its expression evaluates to true.
+type Rule struct {
+	// Name identifies the rule in the decision records.
+	Name string `json:"name"`
+	// Effect is the decision of the request once the rule is matched, Allow or Deny.
+	Effect Effect `json:"effect"`
+	// Expression is a CEL expression which evaluates to a bool.
+	Expression string `json:"expression"`
+}
+
+// Policy is a list of rules that are evaluated in order, the first matched rule decides the request, the
+// request is denied if no rule is matched.
+type Policy struct {
+	Rules []Rule `json:"rules"`
+	// CostLimit is the runtime cost limit of each rule evaluation, defaults to 1000000.
+	CostLimit uint64 `json:"costLimit,omitempty"`
+}
+
+// compiledRule is a rule with its compiled CEL program.
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+func newEnv() (*cel.Env, error) {
+	envOpts := append([]cel.EnvOption{
+		cel.Variable(UserVarName, cel.StringType),
+		cel.Variable(GroupsVarName, cel.ListType(cel.StringType)),
+		cel.Variable(MethodVarName, cel.StringType),
+		cel.Variable(ClusterNameVarName, cel.StringType),
+		cel.Variable(EventTypeVarName, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(ExtensionsVarName, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(MetadataVarName, cel.MapType(cel.StringType, cel.DynType)),
+		library.JsonLib(),
+	}, common.BaseEnvOpts...)
+	return cel.NewEnv(envOpts...)
+}
+
+// compile compiles the rules of the policy, the runtime cost of each rule is tracked with the base
+// environment cost estimator and limited by the policy cost limit.
+func compile(policy *Policy) ([]compiledRule, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]compiledRule, 0, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		if len(rule.Name) == 0 {
+			return nil, fmt.Errorf("the name of rule %d is required", i)
+		}
+		if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+			return nil, fmt.Errorf("the effect of rule %s should be %s or %s", rule.Name, EffectAllow, EffectDeny)
+		}
+
+		program, err := common.CompileProgram(env, rule.Expression, &library.CostEstimator{}, policy.CostLimit, types.BoolType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s: %v", rule.Name, err)
+		}
+
+		rules = append(rules, compiledRule{Rule: rule, program: program})
+	}
+	return rules, nil
+}
+
+// policyFile is a policy that is loaded from a file, the file is reloaded when its modification time or
+// size is changed. If the changed file is invalid, the rules that were loaded last time are kept.
+type policyFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	rules   []compiledRule
+}
+
+func newPolicyFile(path string) (*policyFile, error) {
+	f := &policyFile{path: path}
+	if _, err := f.load(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// load returns the rules of the policy file, the rules that were loaded last time are returned together with
+// the error if the file cannot be reloaded.
+func (f *policyFile) load() ([]compiledRule, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.rules, err
+	}
+
+	if f.rules != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.rules, nil
+	}
+
+	// the file is not reloaded again until it is changed
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.rules, err
+	}
+
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return f.rules, fmt.Errorf("failed to unmarshal policy file %s: %v", f.path, err)
+	}
+
+	rules, err := compile(policy)
+	if err != nil {
+		return f.rules, fmt.Errorf("invalid policy file %s: %v", f.path, err)
+	}
+
+	f.rules = rules
+	return f.rules, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/utils"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/server"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/audit"
 )
 
 type resourceHandler func(ctx context.Context, subID string, res *cloudevents.Event) error
@@ -36,6 +38,7 @@ type resourceHandler func(ctx context.Context, subID string, res *cloudevents.Ev
 type subscriber struct {
 	clusterName string
 	dataType    types.CloudEventsDataType
+	filter      *subscriptionFilter
 	handler     resourceHandler
 }
 
@@ -65,10 +68,29 @@ func NewGRPCBroker(opts *BrokerOptions) *GRPCBroker {
 }
 
 func (bkr *GRPCBroker) RegisterService(ctx context.Context, t types.CloudEventsDataType, service server.Service) {
+	bkr.mu.Lock()
 	bkr.services[t] = service
+	bkr.mu.Unlock()
+
 	service.RegisterHandler(ctx, bkr)
 }
 
+// ReadinessCheck returns a readiness check of the gRPC server, the check fails until the services of the given
+// data types are registered.
+func (bkr *GRPCBroker) ReadinessCheck(dataTypes ...types.CloudEventsDataType) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		bkr.mu.RLock()
+		defer bkr.mu.RUnlock()
+
+		for _, dataType := range dataTypes {
+			if _, ok := bkr.services[dataType]; !ok {
+				return fmt.Errorf("the service of %s is not registered", dataType)
+			}
+		}
+		return nil
+	}
+}
+
 func (bkr *GRPCBroker) Subscribers() sets.Set[string] {
 	bkr.mu.Lock()
 	defer bkr.mu.Unlock()
@@ -83,45 +105,93 @@ func (bkr *GRPCBroker) Subscribers() sets.Set[string] {
 
 // Publish in stub implementation for agent publish resource status.
 func (bkr *GRPCBroker) Publish(ctx context.Context, pubReq *pbv1.PublishRequest) (*emptypb.Empty, error) {
+	if err := bkr.handlePublish(ctx, pubReq.Event); err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// PublishStream in stub implementation for agent publish resource status over a stream.
+// The events are handled in the order they are received, each event is acknowledged with a
+// response that carries the status code which the Publish returns for the event.
+func (bkr *GRPCBroker) PublishStream(pubServer pbv1.CloudEventService_PublishStreamServer) error {
+	ctx := pubServer.Context()
+	logger := klog.FromContext(ctx)
+
+	for {
+		pubReq, err := pubServer.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp := &pbv1.PublishStreamResponse{Sequence: pubReq.Sequence}
+		if err := bkr.handlePublish(ctx, pubReq.Event); err != nil {
+			st := status.Convert(err)
+			resp.Code = int32(st.Code())
+			resp.Message = st.Message()
+		}
+
+		// Return the error without wrapping, as it includes the gRPC error code and message for further handling.
+		if err := pubServer.Send(resp); err != nil {
+			logger.Error(err, "failed to send publish response", "sequence", pubReq.Sequence)
+			return err
+		}
+	}
+}
+
+// handlePublish handles a published event, the returned error is a gRPC status error.
+func (bkr *GRPCBroker) handlePublish(ctx context.Context, pbEvt *pbv1.CloudEvent) error {
 	logger := klog.FromContext(ctx)
-	// WARNING: don't use "evt, err := pb.FromProto(pubReq.Event)" to convert protobuf to cloudevent
-	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pubReq.Event))
+	if pbEvt == nil {
+		return status.Error(codes.InvalidArgument, "missing event in request")
+	}
+
+	// WARNING: don't use "evt, err := pb.FromProto(pbEvt)" to convert protobuf to cloudevent
+	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pbEvt))
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to convert protobuf to cloudevent: %v", err))
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to convert protobuf to cloudevent: %v", err))
 	}
 
 	eventType, err := types.ParseCloudEventsType(evt.Type())
 	if err != nil {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to parse cloud event type %s, %v", evt.Type(), err))
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to parse cloud event type %s, %v", evt.Type(), err))
 	}
 
 	logger.V(4).Info("receive the event with grpc broker", "eventType", evt.Type(), "extensions", evt.Extensions())
 
+	if resourceID, ok := evt.Extensions()[types.ExtensionResourceID]; ok {
+		audit.AddAnnotation(ctx, audit.AnnotationResourceID, fmt.Sprintf("%v", resourceID))
+	}
+
 	// handler resync request
 	if eventType.Action == types.ResyncRequestAction {
 		err := bkr.respondResyncSpecRequest(ctx, eventType.CloudEventsDataType, evt)
 		if err != nil {
-			return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("failed to respond resync spec request: %v", err))
+			return status.Error(codes.FailedPrecondition, fmt.Sprintf("failed to respond resync spec request: %v", err))
 		}
-		return &emptypb.Empty{}, nil
+		return nil
 	}
 
 	service, ok := bkr.services[eventType.CloudEventsDataType]
 	if !ok {
-		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to find service for event type %s", eventType.CloudEventsDataType))
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to find service for event type %s", eventType.CloudEventsDataType))
 	}
 
 	// handle the resource status update according status update type
 	if err := service.HandleStatusUpdate(ctx, evt); err != nil {
 		errStr, marshalErr := json.Marshal(err)
 		if marshalErr != nil {
-			return nil, status.Error(codes.FailedPrecondition, err.Error())
+			return status.Error(codes.FailedPrecondition, err.Error())
 		}
 
-		return nil, status.Error(codes.FailedPrecondition, string(errStr))
+		return status.Error(codes.FailedPrecondition, string(errStr))
 	}
 
-	return &emptypb.Empty{}, nil
+	return nil
 }
 
 // registerSubscriber registers a subscriber with a pre-generated ID.
@@ -130,17 +200,20 @@ func (bkr *GRPCBroker) registerSubscriber(ctx context.Context,
 	id string,
 	dataType types.CloudEventsDataType,
 	subReq *pbv1.SubscriptionRequest,
+	filter *subscriptionFilter,
 	handler resourceHandler) error {
 	logger := klog.FromContext(ctx)
 
 	bkr.mu.Lock()
 	defer bkr.mu.Unlock()
 
-	logger.Info("registering subscriber", "id", id, "clusterName", subReq.ClusterName, "dataType", dataType)
+	logger.Info("registering subscriber", "id", id, "clusterName", subReq.ClusterName, "dataType", dataType,
+		"resourceName", subReq.ResourceName, "labelSelector", subReq.LabelSelector)
 
 	bkr.subscribers[id] = &subscriber{
 		clusterName: subReq.ClusterName,
 		dataType:    dataType,
+		filter:      filter,
 		handler:     handler,
 	}
 
@@ -174,6 +247,10 @@ func (bkr *GRPCBroker) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 	if err != nil {
 		return fmt.Errorf("invalid subscription request: invalid data type %v", err)
 	}
+	filter, err := newSubscriptionFilter(subReq)
+	if err != nil {
+		return fmt.Errorf("invalid subscription request: %v", err)
+	}
 
 	// Generate subscription ID and send header IMMEDIATELY, before any other operations
 	// This ensures the client receives the header as soon as possible after the stream is established
@@ -197,7 +274,7 @@ func (bkr *GRPCBroker) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 	sendErrCh := make(chan error, 1)
 
 	// Register the subscriber with the ID we already created and sent in the header
-	err = bkr.registerSubscriber(klog.NewContext(subCtx, logger), subID, *dataType, subReq, func(handlerCtx context.Context, subID string, evt *cloudevents.Event) error {
+	err = bkr.registerSubscriber(klog.NewContext(subCtx, logger), subID, *dataType, subReq, filter, func(handlerCtx context.Context, subID string, evt *cloudevents.Event) error {
 		// convert the cloudevents.Event to pbv1.CloudEvent
 		// WARNING: don't use "pbEvt, err := pb.ToProto(evt)" to convert cloudevent to protobuf
 		pbEvt := &pbv1.CloudEvent{}
@@ -225,6 +302,10 @@ func (bkr *GRPCBroker) Subscribe(subReq *pbv1.SubscriptionRequest, subServer pbv
 		return err
 	}
 
+	// audit the established subscription, the subscription is audited again once it is completed
+	audit.AddAnnotation(subCtx, audit.AnnotationSubscriptionID, subID)
+	audit.LogResponseStarted(subCtx)
+
 	// send events
 	// The grpc send is not concurrency safe and non-blocking, see: https://github.com/grpc/grpc-go/blob/v1.75.1/stream.go#L1571
 	// Return the error without wrapping, as it includes the gRPC error code and message for further handling.
@@ -323,6 +404,8 @@ func (bkr *GRPCBroker) respondResyncSpecRequest(ctx context.Context, eventDataTy
 		return err
 	}
 
+	bkr.addSubscribedResourceIDs(clusterName, eventDataType, resourceVersions.Versions, evts)
+
 	respEventType := types.CloudEventsType{
 		CloudEventsDataType: eventDataType,
 		SubResource:         types.SubResourceSpec,
@@ -333,6 +416,12 @@ func (bkr *GRPCBroker) respondResyncSpecRequest(ctx context.Context, eventDataTy
 		evt.SetType(respEventType.String())
 		evtLogger := log.WithValues("eventType", evt.Type(), "extensions", evt.Extensions())
 
+		// the resources that are filtered out by the subscriptions of the cluster are not responded
+		if !bkr.isSubscribed(clusterName, eventDataType, evt) {
+			evtLogger.V(4).Info("ignore the event since it is filtered out by the subscriptions")
+			continue
+		}
+
 		// respond with the deleting resource regardless of the resource version
 		if _, ok := evt.Extensions()[types.ExtensionDeletionTimestamp]; ok {
 			evtLogger.V(4).Info("respond spec resync request")
@@ -419,16 +508,53 @@ func (bkr *GRPCBroker) HandleEvent(ctx context.Context, evt *cloudevents.Event)
 		// checks if the event should be processed by the current instance by verifying
 		// the resource consumer name and its data type is in the subscriber list, ensuring
 		// the event will be only processed when the consumer is subscribed to the current
-		// broker.
-		if subscriber.clusterName == clusterName && subscriber.dataType == evtDataType {
+		// broker. The subscriber with a resource name or label selector only receives the events of the
+		// matched resources.
+		if subscriber.clusterName == clusterName && subscriber.dataType == evtDataType && subscriber.filter.matches(evt) {
 			if err := subscriber.handler(ctx, subID, evt); err != nil {
 				return err
 			}
+			subscriber.filter.sent(evt)
 		}
 	}
 	return nil
 }
 
+// addSubscribedResourceIDs records the resources that the cluster reports in its resync request to the filters of
+// its subscribers, so that the subscribers receive the events of these resources that have no resource metadata.
+// A resource that exists on the source is only recorded by the subscribers that match it, the resync request is
+// not bound to a subscription, so a resource that no longer exists is recorded by all the subscribers of the
+// cluster to send its deletion.
+func (bkr *GRPCBroker) addSubscribedResourceIDs(clusterName string, dataType types.CloudEventsDataType,
+	versions []payload.ResourceVersion, evts []*cloudevents.Event) {
+	bkr.mu.RLock()
+	defer bkr.mu.RUnlock()
+	for _, version := range versions {
+		evt, exists := getEvent(version.ResourceID, evts)
+		for _, subscriber := range bkr.subscribers {
+			if subscriber.clusterName != clusterName || subscriber.dataType != dataType {
+				continue
+			}
+			if exists && !subscriber.filter.matches(evt) {
+				continue
+			}
+			subscriber.filter.addResourceIDs(version.ResourceID)
+		}
+	}
+}
+
+// isSubscribed returns true if a subscriber of the cluster and data type receives the event.
+func (bkr *GRPCBroker) isSubscribed(clusterName string, dataType types.CloudEventsDataType, evt *cloudevents.Event) bool {
+	bkr.mu.RLock()
+	defer bkr.mu.RUnlock()
+	for _, subscriber := range bkr.subscribers {
+		if subscriber.clusterName == clusterName && subscriber.dataType == dataType && subscriber.filter.matches(evt) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsConsumerSubscribed returns true if the consumer is subscribed to the broker for resource spec.
 func (bkr *GRPCBroker) IsConsumerSubscribed(consumerName string) bool {
 	bkr.mu.RLock()
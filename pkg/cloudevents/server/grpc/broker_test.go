@@ -2,13 +2,18 @@ package grpc
 
 import (
 	"context"
+	"io"
 	"net"
 	"testing"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/cloudevents/sdk-go/v2/binding"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	grpccli "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc"
 	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
 	grpcv2 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/v2/grpc"
 	cetypes "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
 	"open-cluster-management.io/sdk-go/pkg/cloudevents/server"
@@ -348,3 +353,86 @@ func TestMultipleRapidReconnections(t *testing.T) {
 		}
 	}
 }
+
+func TestPublishStream(t *testing.T) {
+	grpcServer := grpc.NewServer()
+	defer grpcServer.Stop()
+
+	grpcEventServer := NewGRPCBroker(NewBrokerOptions())
+	pbv1.RegisterCloudEventServiceServer(grpcServer, grpcEventServer)
+
+	svc := &testService{evts: make(map[string]*cloudevents.Event)}
+	grpcEventServer.RegisterService(context.Background(), dataType, svc)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := pbv1.NewCloudEventServiceClient(conn).PublishStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evt := cetypes.NewEventBuilder("agent1",
+		cetypes.CloudEventsType{CloudEventsDataType: dataType, SubResource: cetypes.SubResourceStatus}).
+		WithResourceID("test1").
+		WithClusterName("cluster1").NewEvent()
+	unknownEvt := cetypes.NewEventBuilder("agent1",
+		cetypes.CloudEventsType{
+			CloudEventsDataType: cetypes.CloudEventsDataType{Group: "test", Version: "v1", Resource: "unknowns"},
+			SubResource:         cetypes.SubResourceStatus,
+		}).
+		WithResourceID("test2").
+		WithClusterName("cluster1").NewEvent()
+
+	cases := []struct {
+		evt          cloudevents.Event
+		expectedCode codes.Code
+	}{
+		{evt: evt, expectedCode: codes.OK},
+		{evt: unknownEvt, expectedCode: codes.InvalidArgument},
+	}
+
+	for i, c := range cases {
+		pbEvt := &pbv1.CloudEvent{}
+		if err := grpcprotocol.WritePBMessage(context.Background(), binding.ToMessage(&c.evt), pbEvt); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := stream.Send(&pbv1.PublishStreamRequest{Sequence: uint64(i + 1), Event: pbEvt}); err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Sequence != uint64(i+1) {
+			t.Errorf("expected sequence %d, but got %d", i+1, resp.Sequence)
+		}
+		if codes.Code(resp.Code) != c.expectedCode {
+			t.Errorf("expected code %s, but got %s (%s)", c.expectedCode, codes.Code(resp.Code), resp.Message)
+		}
+	}
+
+	if _, ok := svc.evts[evt.ID()]; !ok {
+		t.Error("event not found")
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("expected EOF, but got %v", err)
+	}
+}
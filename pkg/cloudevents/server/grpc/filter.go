@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	cloudeventstypes "github.com/cloudevents/sdk-go/v2/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// subscriptionFilter narrows a subscription to the resources with a name or the resources whose labels match a
+// selector, the subscription without the resource name and label selector receives all the resources of its
+// cluster and data type.
+type subscriptionFilter struct {
+	resourceName string
+	selector     labels.Selector
+
+	mu sync.Mutex
+	// resourceIDs are the ids of the resources that are sent to the subscriber or reported by its resync request.
+	// The events without the resource metadata, e.g. the deletion events without data and the protobuf events
+	// without the metadata extension, are only matched if their resources are known by the subscriber.
+	resourceIDs sets.Set[string]
+}
+
+func newSubscriptionFilter(subReq *pbv1.SubscriptionRequest) (*subscriptionFilter, error) {
+	filter := &subscriptionFilter{resourceName: subReq.ResourceName, resourceIDs: sets.New[string]()}
+	if len(subReq.LabelSelector) != 0 {
+		selector, err := labels.Parse(subReq.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %v", subReq.LabelSelector, err)
+		}
+		filter.selector = selector
+	}
+	return filter, nil
+}
+
+func (f *subscriptionFilter) isEmpty() bool {
+	return len(f.resourceName) == 0 && f.selector == nil
+}
+
+// matches returns true if the event of a resource should be sent to the subscriber.
+func (f *subscriptionFilter) matches(evt *cloudevents.Event) bool {
+	if f.isEmpty() {
+		return true
+	}
+
+	meta, ok := eventObjectMeta(evt)
+	if !ok {
+		resourceID, err := cloudeventstypes.ToString(evt.Extensions()[types.ExtensionResourceID])
+		if err != nil {
+			return false
+		}
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		return f.resourceIDs.Has(resourceID)
+	}
+
+	if len(f.resourceName) != 0 && meta.Name != f.resourceName {
+		return false
+	}
+	if f.selector != nil && !f.selector.Matches(labels.Set(meta.Labels)) {
+		return false
+	}
+	return true
+}
+
+// sent records the resource of the event that is sent to the subscriber, the resource is forgotten once its
+// deletion is sent.
+func (f *subscriptionFilter) sent(evt *cloudevents.Event) {
+	if f.isEmpty() {
+		return
+	}
+
+	resourceID, err := cloudeventstypes.ToString(evt.Extensions()[types.ExtensionResourceID])
+	if err != nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, deleting := evt.Extensions()[types.ExtensionDeletionTimestamp]; deleting {
+		f.resourceIDs.Delete(resourceID)
+		return
+	}
+	f.resourceIDs.Insert(resourceID)
+}
+
+// addResourceIDs records the resources that the subscriber reports in its resync request.
+func (f *subscriptionFilter) addResourceIDs(resourceIDs ...string) {
+	if f.isEmpty() {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.resourceIDs.Insert(resourceIDs...)
+}
+
+// eventObjectMeta returns the metadata of the resource of an event, the metadata is in the metadata extension
+// of the event, or in its JSON data.
+func eventObjectMeta(evt *cloudevents.Event) (metav1.ObjectMeta, bool) {
+	if metaExtension, ok := evt.Extensions()[types.ExtensionWorkMeta]; ok {
+		metaJSON, err := cloudeventstypes.ToString(metaExtension)
+		if err != nil {
+			return metav1.ObjectMeta{}, false
+		}
+		meta := metav1.ObjectMeta{}
+		if err := json.Unmarshal([]byte(metaJSON), &meta); err != nil {
+			return metav1.ObjectMeta{}, false
+		}
+		return meta, true
+	}
+
+	// the protobuf event data does not carry the object metadata
+	if len(evt.Data()) == 0 || evt.DataContentType() == types.ApplicationProtobuf {
+		return metav1.ObjectMeta{}, false
+	}
+
+	partial := metav1.PartialObjectMetadata{}
+	if err := evt.DataAs(&partial); err != nil {
+		return metav1.ObjectMeta{}, false
+	}
+	return partial.ObjectMeta, true
+}
@@ -0,0 +1,285 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	addonv1alpha1 "open-cluster-management.io/api/addon/v1alpha1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/clients/addon/v1alpha1"
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/payload"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+func TestSubscriptionFilter(t *testing.T) {
+	addon := newTestAddonEvent(t, "addon1", map[string]string{"app": "addon1"})
+	workMeta := newTestAddonEvent(t, "", nil)
+	workMeta.SetExtension(types.ExtensionWorkMeta, `{"name":"work1","labels":{"app":"work1"}}`)
+	deleting := types.NewEventBuilder("source", addonEventType()).
+		WithResourceID("addon-id").
+		WithClusterName("cluster1").
+		WithDeletionTimestamp(time.Now()).
+		NewEvent()
+	protobufMeta := newTestProtobufEvent(t, "work-id")
+	protobufMeta.SetExtension(types.ExtensionWorkMeta, `{"name":"work1","labels":{"app":"work1"}}`)
+
+	cases := []struct {
+		name        string
+		subReq      *pbv1.SubscriptionRequest
+		resourceIDs []string
+		evt         *cloudevents.Event
+		expected    bool
+	}{
+		{
+			name:     "no filter",
+			subReq:   &pbv1.SubscriptionRequest{},
+			evt:      addon,
+			expected: true,
+		},
+		{
+			name:     "resource name matches",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "addon1"},
+			evt:      addon,
+			expected: true,
+		},
+		{
+			name:     "resource name does not match",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "addon2"},
+			evt:      addon,
+			expected: false,
+		},
+		{
+			name:     "label selector matches",
+			subReq:   &pbv1.SubscriptionRequest{LabelSelector: "app in (addon1,addon2)"},
+			evt:      addon,
+			expected: true,
+		},
+		{
+			name:     "label selector does not match",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "addon1", LabelSelector: "app!=addon1"},
+			evt:      addon,
+			expected: false,
+		},
+		{
+			name:     "metadata extension matches",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "work1", LabelSelector: "app=work1"},
+			evt:      workMeta,
+			expected: true,
+		},
+		{
+			name:     "deletion without data of an unknown resource",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "addon1"},
+			evt:      &deleting,
+			expected: false,
+		},
+		{
+			name:        "deletion without data of a known resource",
+			subReq:      &pbv1.SubscriptionRequest{ResourceName: "addon1"},
+			resourceIDs: []string{"addon-id"},
+			evt:         &deleting,
+			expected:    true,
+		},
+		{
+			name:     "protobuf event with metadata extension matches",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "work1", LabelSelector: "app=work1"},
+			evt:      protobufMeta,
+			expected: true,
+		},
+		{
+			name:     "protobuf event with metadata extension does not match",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "work2"},
+			evt:      protobufMeta,
+			expected: false,
+		},
+		{
+			name:     "protobuf event without metadata extension of an unknown resource",
+			subReq:   &pbv1.SubscriptionRequest{ResourceName: "work1"},
+			evt:      newTestProtobufEvent(t, "work-id"),
+			expected: false,
+		},
+		{
+			name:        "protobuf event without metadata extension of a known resource",
+			subReq:      &pbv1.SubscriptionRequest{ResourceName: "work1"},
+			resourceIDs: []string{"work-id"},
+			evt:         newTestProtobufEvent(t, "work-id"),
+			expected:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			filter, err := newSubscriptionFilter(c.subReq)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filter.addResourceIDs(c.resourceIDs...)
+			if matched := filter.matches(c.evt); matched != c.expected {
+				t.Errorf("expected %v, but got %v", c.expected, matched)
+			}
+		})
+	}
+
+	if _, err := newSubscriptionFilter(&pbv1.SubscriptionRequest{LabelSelector: "app in"}); err == nil {
+		t.Errorf("expected error for the invalid label selector")
+	}
+}
+
+func TestHandleEventWithSubscriptionFilters(t *testing.T) {
+	broker := NewGRPCBroker(NewBrokerOptions())
+	ctx := context.Background()
+
+	received := map[string]sets.Set[string]{}
+	register := func(id string, subReq *pbv1.SubscriptionRequest) {
+		filter, err := newSubscriptionFilter(subReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		received[id] = sets.New[string]()
+		if err := broker.registerSubscriber(ctx, id, v1alpha1.ManagedClusterAddOnEventDataType, subReq, filter,
+			func(_ context.Context, subID string, evt *cloudevents.Event) error {
+				meta, _ := eventObjectMeta(evt)
+				received[subID].Insert(meta.Name)
+				return nil
+			}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	register("all", &pbv1.SubscriptionRequest{ClusterName: "cluster1"})
+	register("addon1", &pbv1.SubscriptionRequest{ClusterName: "cluster1", ResourceName: "addon1"})
+	register("selected", &pbv1.SubscriptionRequest{ClusterName: "cluster1", LabelSelector: "app=addon2"})
+
+	for _, evt := range []*cloudevents.Event{
+		newTestAddonEvent(t, "addon1", map[string]string{"app": "addon1"}),
+		newTestAddonEvent(t, "addon2", map[string]string{"app": "addon2"}),
+		newTestAddonEvent(t, "addon3", nil),
+	} {
+		if err := broker.HandleEvent(ctx, evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[string]sets.Set[string]{
+		"all":      sets.New("addon1", "addon2", "addon3"),
+		"addon1":   sets.New("addon1"),
+		"selected": sets.New("addon2"),
+	}
+	for id, names := range expected {
+		if !received[id].Equal(names) {
+			t.Errorf("expected subscriber %s receives %v, but got %v", id, sets.List(names), sets.List(received[id]))
+		}
+	}
+
+	if !broker.isSubscribed("cluster1", v1alpha1.ManagedClusterAddOnEventDataType,
+		newTestAddonEvent(t, "addon4", nil)) {
+		t.Errorf("expected the addon is subscribed by the subscriber without filters")
+	}
+}
+
+func TestHandleDeletionWithSubscriptionFilters(t *testing.T) {
+	broker := NewGRPCBroker(NewBrokerOptions())
+	ctx := context.Background()
+
+	deletions := map[string]int{}
+	for id, subReq := range map[string]*pbv1.SubscriptionRequest{
+		"all":      {ClusterName: "cluster1"},
+		"addon1":   {ClusterName: "cluster1", ResourceName: "addon1"},
+		"selected": {ClusterName: "cluster1", LabelSelector: "app=addon2"},
+	} {
+		filter, err := newSubscriptionFilter(subReq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := broker.registerSubscriber(ctx, id, v1alpha1.ManagedClusterAddOnEventDataType, subReq, filter,
+			func(_ context.Context, subID string, evt *cloudevents.Event) error {
+				if _, ok := evt.Extensions()[types.ExtensionDeletionTimestamp]; ok {
+					deletions[subID]++
+				}
+				return nil
+			}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deleting := func(resourceID string) *cloudevents.Event {
+		evt := types.NewEventBuilder("source", addonEventType()).
+			WithResourceID(resourceID).
+			WithClusterName("cluster1").
+			WithDeletionTimestamp(time.Now()).
+			NewEvent()
+		return &evt
+	}
+
+	// the deletion is only sent to the subscribers that received the resource
+	for _, evt := range []*cloudevents.Event{
+		newTestAddonEvent(t, "addon1", map[string]string{"app": "addon1"}),
+		deleting("addon1"),
+		// the resource is forgotten once its deletion is sent
+		deleting("addon1"),
+	} {
+		if err := broker.HandleEvent(ctx, evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the resources in the resync request are known by the subscribers that match them, the resource that
+	// no longer exists on the source is known by all the subscribers of the cluster
+	broker.addSubscribedResourceIDs("cluster1", v1alpha1.ManagedClusterAddOnEventDataType,
+		[]payload.ResourceVersion{{ResourceID: "addon2"}, {ResourceID: "addon3"}},
+		[]*cloudevents.Event{newTestAddonEvent(t, "addon2", map[string]string{"app": "addon2"})})
+	for _, evt := range []*cloudevents.Event{deleting("addon2"), deleting("addon3")} {
+		if err := broker.HandleEvent(ctx, evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[string]int{"all": 4, "addon1": 2, "selected": 2}
+	for id, count := range expected {
+		if deletions[id] != count {
+			t.Errorf("expected subscriber %s receives %d deletions, but got %d", id, count, deletions[id])
+		}
+	}
+}
+
+func addonEventType() types.CloudEventsType {
+	return types.CloudEventsType{
+		CloudEventsDataType: v1alpha1.ManagedClusterAddOnEventDataType,
+		SubResource:         types.SubResourceSpec,
+		Action:              types.UpdateRequestAction,
+	}
+}
+
+func newTestAddonEvent(t *testing.T, name string, labels map[string]string) *cloudevents.Event {
+	addon := &addonv1alpha1.ManagedClusterAddOn{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "cluster1", Labels: labels},
+	}
+	data, err := json.Marshal(addon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evt := types.NewEventBuilder("source", addonEventType()).
+		WithResourceID(name).
+		WithClusterName("cluster1").
+		NewEvent()
+	if err := evt.SetData(cloudevents.ApplicationJSON, data); err != nil {
+		t.Fatal(err)
+	}
+	return &evt
+}
+
+func newTestProtobufEvent(t *testing.T, resourceID string) *cloudevents.Event {
+	evt := types.NewEventBuilder("source", addonEventType()).
+		WithResourceID(resourceID).
+		WithClusterName("cluster1").
+		NewEvent()
+	if err := evt.SetData(types.ApplicationProtobuf, []byte{0x0a, 0x00}); err != nil {
+		t.Fatal(err)
+	}
+	return &evt
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/cloudevents/sdk-go/v2/binding"
@@ -131,31 +132,12 @@ func NewCloudEventsMetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
 			recordCloudEventsMetrics(cluster, dataType, method, err, startTime)
 			return nil, err
 		}
-		// convert the request to cloudevent and extract the source
-		evt, err := binding.ToEvent(ctx, protocol.NewMessage(pubReq.Event))
+		clusterVal, dataTypeVal, err := clusterAndDataTypeFromEvent(ctx, pubReq.Event)
 		if err != nil {
-			err = fmt.Errorf("failed to convert to cloudevent: %v", err)
 			recordCloudEventsMetrics(cluster, dataType, method, err, startTime)
 			return nil, err
 		}
-
-		// extract the cluster name from event extensions
-		clusterVal, err := cetypes.ToString(evt.Context.GetExtensions()[types.ExtensionClusterName])
-		if err != nil {
-			err = fmt.Errorf("failed to get clustername extension: %v", err)
-			recordCloudEventsMetrics(cluster, dataType, method, err, startTime)
-			return nil, err
-		}
-		cluster = clusterVal
-
-		// extract the data type from event type
-		eventType, err := types.ParseCloudEventsType(evt.Type())
-		if err != nil {
-			err = fmt.Errorf("failed to parse cloud event type %s, %v", evt.Type(), err)
-			recordCloudEventsMetrics(cluster, dataType, method, err, startTime)
-			return nil, err
-		}
-		dataType = eventType.CloudEventsDataType.String()
+		cluster, dataType = clusterVal, dataTypeVal
 
 		grpcCECalledCountMetric.WithLabelValues(cluster, dataType, method).Inc()
 		grpcCEMessageReceivedCountMetric.WithLabelValues(cluster, dataType, method).Inc()
@@ -174,6 +156,29 @@ func NewCloudEventsMetricsUnaryInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
+// clusterAndDataTypeFromEvent extracts the cluster name and the data type from a protobuf cloudevent.
+func clusterAndDataTypeFromEvent(ctx context.Context, pbEvt *pbv1.CloudEvent) (string, string, error) {
+	// convert the request to cloudevent and extract the source
+	evt, err := binding.ToEvent(ctx, protocol.NewMessage(pbEvt))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert to cloudevent: %v", err)
+	}
+
+	// extract the cluster name from event extensions
+	cluster, err := cetypes.ToString(evt.Context.GetExtensions()[types.ExtensionClusterName])
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get clustername extension: %v", err)
+	}
+
+	// extract the data type from event type
+	eventType, err := types.ParseCloudEventsType(evt.Type())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse cloud event type %s, %v", evt.Type(), err)
+	}
+
+	return cluster, eventType.CloudEventsDataType.String(), nil
+}
+
 //nolint:unparam // dataType is always "unknown" at current call sites but kept for consistency
 func recordCloudEventsMetrics(cluster, dataType, method string, err error, startTime time.Time) {
 	duration := time.Since(startTime).Seconds()
@@ -191,16 +196,43 @@ type wrappedCloudEventsMetricsStream struct {
 	method      string
 	grpc.ServerStream
 	ctx context.Context
+
+	// publishing tracks the received events of the publish stream by the request sequence,
+	// the metrics of an event are emitted once the event is acknowledged.
+	publishing map[uint64]publishingEvent
+}
+
+// publishingEvent is an event that is received from the publish stream and waiting for acknowledgement.
+type publishingEvent struct {
+	cluster   string
+	dataType  string
+	startTime time.Time
 }
 
 // RecvMsg wraps the RecvMsg method of the embedded grpc.ServerStream.
-// It captures the cluster and data type from the SubscriptionRequest and emits metrics.
+// It captures the cluster and data type from the SubscriptionRequest or the event of the
+// PublishStreamRequest and emits metrics.
 func (w *wrappedCloudEventsMetricsStream) RecvMsg(m interface{}) error {
 	err := w.ServerStream.RecvMsg(m)
 	if err != nil {
 		return err
 	}
 
+	if pubReq, ok := m.(*pbv1.PublishStreamRequest); ok {
+		cluster, dataType, err := clusterAndDataTypeFromEvent(w.ctx, pubReq.Event)
+		if err != nil {
+			cluster, dataType = "unknown", "unknown"
+		}
+
+		if w.publishing == nil {
+			w.publishing = map[uint64]publishingEvent{}
+		}
+		w.publishing[pubReq.Sequence] = publishingEvent{cluster: cluster, dataType: dataType, startTime: time.Now()}
+		grpcCECalledCountMetric.WithLabelValues(cluster, dataType, w.method).Inc()
+		grpcCEMessageReceivedCountMetric.WithLabelValues(cluster, dataType, w.method).Inc()
+		return nil
+	}
+
 	subReq, ok := m.(*pbv1.SubscriptionRequest)
 	if !ok {
 		return fmt.Errorf("invalid request type for Subscribe method")
@@ -223,6 +255,21 @@ func (w *wrappedCloudEventsMetricsStream) SendMsg(m interface{}) error {
 		return err
 	}
 
+	if pubResp, ok := m.(*pbv1.PublishStreamResponse); ok {
+		evt, ok := w.publishing[pubResp.Sequence]
+		if !ok {
+			return nil
+		}
+		delete(w.publishing, pubResp.Sequence)
+
+		code := codes.Code(pubResp.Code).String()
+		grpcCEMessageSentCountMetric.WithLabelValues(evt.cluster, evt.dataType, w.method).Inc()
+		grpcCEProcessedCountMetric.WithLabelValues(evt.cluster, evt.dataType, w.method, code).Inc()
+		grpcCEProcessingDurationMetric.WithLabelValues(evt.cluster, evt.dataType, w.method, code).Observe(
+			time.Since(evt.startTime).Seconds())
+		return nil
+	}
+
 	if w.clusterName != nil && w.dataType != nil && *w.clusterName != "" && *w.dataType != "" {
 		grpcCEMessageSentCountMetric.WithLabelValues(*w.clusterName, *w.dataType, w.method).Inc()
 	}
@@ -232,7 +279,7 @@ func (w *wrappedCloudEventsMetricsStream) SendMsg(m interface{}) error {
 
 // newWrappedCloudEventsMetricsStream creates a wrappedCloudEventsMetricsStream with the specified type and cluster reference.
 func newWrappedCloudEventsMetricsStream(ctx context.Context, clusterName, dataType *string, method string, ss grpc.ServerStream) grpc.ServerStream {
-	return &wrappedCloudEventsMetricsStream{clusterName, dataType, method, ss, ctx}
+	return &wrappedCloudEventsMetricsStream{clusterName: clusterName, dataType: dataType, method: method, ServerStream: ss, ctx: ctx}
 }
 
 // NewCloudEventsMetricsStreamInterceptor creates a stream server interceptor for server metrics.
@@ -252,6 +299,11 @@ func NewCloudEventsMetricsStreamInterceptor() grpc.StreamServerInterceptor {
 		// call rpc handler to handle RPC request
 		err := handler(srv, wrappedCEMetricsStream)
 
+		// the events of the publish stream are processed one by one, the metrics are emitted per event
+		if info.FullMethod == pbv1.CloudEventService_PublishStream_FullMethodName {
+			return err
+		}
+
 		// get status code from error
 		status := statusFromError(err)
 		code := status.Code()
@@ -0,0 +1,263 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+// minRSAKeySize is the minimum size in bits of the RSA keys that are accepted to sign and verify the signatures.
+const minRSAKeySize = 2048
+
+// JSONWebKey is a public key of a JSON Web Key Set (RFC 7517).
+type JSONWebKey struct {
+	// KeyID is the `kid` of the key.
+	KeyID string
+	// Algorithm is the `alg` of the key, it is optional.
+	Algorithm string
+	// Use is the `use` of the key, it is optional.
+	Use string
+	// Key is the public key, it is one of *rsa.PublicKey, *ecdsa.PublicKey and ed25519.PublicKey.
+	Key crypto.PublicKey
+}
+
+type rawJSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+	Use       string `json:"use,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// ParseJWKS parses a JSON Web Key Set, the keys whose type is not supported and the keys that are not used for
+// signatures are ignored.
+func ParseJWKS(data []byte) ([]JSONWebKey, error) {
+	keySet := struct {
+		Keys []rawJSONWebKey `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWKS: %v", err)
+	}
+
+	keys := []JSONWebKey{}
+	for i, raw := range keySet.Keys {
+		if len(raw.Use) != 0 && raw.Use != "sig" {
+			continue
+		}
+
+		key, err := raw.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %d (kid=%q) in JWKS: %v", i, raw.KeyID, err)
+		}
+		if key == nil {
+			continue
+		}
+
+		keys = append(keys, JSONWebKey{KeyID: raw.KeyID, Algorithm: raw.Algorithm, Use: raw.Use, Key: key})
+	}
+
+	return keys, nil
+}
+
+func (k rawJSONWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		key := &rsa.PublicKey{N: n, E: int(e.Int64())}
+		if err := checkRSAKeySize(key); err != nil {
+			return nil, err
+		}
+		return key, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("the point is not on curve %s", k.Curve)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key size %d", len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		// the symmetric keys and unknown key types are not supported
+		return nil, nil
+	}
+}
+
+func checkRSAKeySize(key *rsa.PublicKey) error {
+	if size := key.N.BitLen(); size < minRSAKeySize {
+		return fmt.Errorf("the RSA key size %d is less than %d bits", size, minRSAKeySize)
+	}
+	return nil
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("missing key parameter")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// ParsePublicKeyPEM parses a PEM encoded public key or certificate.
+func ParsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM data")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded private key, it supports PKCS#1, PKCS#8 and SEC 1 private keys.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM data")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// KeySetFile is a JSON Web Key Set that is loaded from a file, the file is reloaded when its modification time or
+// size is changed.
+type KeySetFile struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	keys    []JSONWebKey
+}
+
+// NewKeySetFile returns a KeySetFile for the given file path.
+func NewKeySetFile(path string) *KeySetFile {
+	return &KeySetFile{path: path}
+}
+
+// Keys returns the current keys of the key set file. If the file cannot be reloaded, the keys that were loaded last
+// time are returned together with the error.
+func (f *KeySetFile) Keys() ([]JSONWebKey, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.keys, err
+	}
+
+	if f.keys != nil && info.ModTime().Equal(f.modTime) && info.Size() == f.size {
+		return f.keys, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return f.keys, err
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return f.keys, err
+	}
+
+	f.keys = keys
+	f.modTime = info.ModTime()
+	f.size = info.Size()
+	return f.keys, nil
+}
+
+// Lookup returns the key with the given key ID from the key set file.
+func (f *KeySetFile) Lookup(keyID string) (crypto.PublicKey, error) {
+	keys, err := f.Keys()
+	for _, key := range keys {
+		if key.KeyID == keyID {
+			return key.Key, nil
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("key %q is not found in %s", keyID, f.path)
+}
@@ -0,0 +1,260 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// The supported JWS signature algorithms.
+const (
+	RS256 = "RS256"
+	RS512 = "RS512"
+	PS256 = "PS256"
+	ES256 = "ES256"
+	ES384 = "ES384"
+	ES512 = "ES512"
+	EdDSA = "EdDSA"
+)
+
+// Header is the protected header of a JWS.
+type Header struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid,omitempty"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// AlgorithmForKey returns the default signature algorithm of a public or private key.
+func AlgorithmForKey(key any) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if err := checkRSAKeySize(k); err != nil {
+			return "", err
+		}
+		return RS256, nil
+	case *rsa.PrivateKey:
+		if err := checkRSAKeySize(&k.PublicKey); err != nil {
+			return "", err
+		}
+		return RS256, nil
+	case *ecdsa.PublicKey:
+		return ecdsaAlgorithm(k.Curve)
+	case *ecdsa.PrivateKey:
+		return ecdsaAlgorithm(k.Curve)
+	case ed25519.PublicKey, ed25519.PrivateKey:
+		return EdDSA, nil
+	default:
+		return "", fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// Sign signs the compact serialization of the header and payload with the signer, and returns the compact JWS.
+func Sign(header Header, payload []byte, signer crypto.Signer) (string, error) {
+	signingInput, err := signingInput(header, payload)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signRaw(header.Algorithm, signer, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// SignDetached signs the header and payload with the signer, and returns a compact JWS whose payload is detached
+// (RFC 7515 Appendix F), the receiver needs to rebuild the payload to verify the signature.
+func SignDetached(header Header, payload []byte, signer crypto.Signer) (string, error) {
+	jws, err := Sign(header, payload, signer)
+	if err != nil {
+		return "", err
+	}
+
+	parts := strings.Split(jws, ".")
+	return parts[0] + ".." + parts[2], nil
+}
+
+// ParseCompact parses a compact JWS and returns its header, payload, signing input and signature. If a detached
+// payload is given, the JWS must not carry its own payload, and the detached payload is used to verify it.
+func ParseCompact(jws string, detachedPayload []byte) (*Header, []byte, []byte, []byte, error) {
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 {
+		return nil, nil, nil, nil, fmt.Errorf("invalid compact JWS, expected 3 parts but got %d", len(parts))
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to decode JWS header: %v", err)
+	}
+
+	header := &Header{}
+	if err := json.Unmarshal(headerJSON, header); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to unmarshal JWS header: %v", err)
+	}
+
+	payload := detachedPayload
+	encodedPayload := parts[1]
+	if detachedPayload != nil && len(encodedPayload) != 0 {
+		return nil, nil, nil, nil, fmt.Errorf("the JWS payload is expected to be detached")
+	}
+	if len(encodedPayload) == 0 {
+		encodedPayload = base64.RawURLEncoding.EncodeToString(detachedPayload)
+	} else {
+		if payload, err = base64.RawURLEncoding.DecodeString(encodedPayload); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to decode JWS payload: %v", err)
+		}
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to decode JWS signature: %v", err)
+	}
+
+	return header, payload, []byte(parts[0] + "." + encodedPayload), signature, nil
+}
+
+// VerifySignature verifies the signature of the signing input with the public key and algorithm.
+func VerifySignature(alg string, key crypto.PublicKey, signingInput, signature []byte) error {
+	switch alg {
+	case RS256, RS512, PS256:
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires a RSA public key, but got %T", alg, key)
+		}
+		if err := checkRSAKeySize(pub); err != nil {
+			return err
+		}
+		hash, digest := digestFor(alg, signingInput)
+		if alg == PS256 {
+			return rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+	case ES256, ES384, ES512:
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires an ECDSA public key, but got %T", alg, key)
+		}
+		expected, err := ecdsaAlgorithm(pub.Curve)
+		if err != nil {
+			return err
+		}
+		if expected != alg {
+			return fmt.Errorf("algorithm %s does not match the key curve %s", alg, pub.Curve.Params().Name)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid %s signature length %d", alg, len(signature))
+		}
+		_, digest := digestFor(alg, signingInput)
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return fmt.Errorf("invalid %s signature", alg)
+		}
+		return nil
+	case EdDSA:
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return fmt.Errorf("algorithm %s requires an Ed25519 public key, but got %T", alg, key)
+		}
+		if !ed25519.Verify(pub, signingInput, signature) {
+			return fmt.Errorf("invalid %s signature", alg)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+}
+
+func signingInput(header Header, payload []byte) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(payload), nil
+}
+
+func signRaw(alg string, signer crypto.Signer, signingInput []byte) ([]byte, error) {
+	switch alg {
+	case RS256, RS512:
+		hash, digest := digestFor(alg, signingInput)
+		return signer.Sign(rand.Reader, digest, hash)
+	case PS256:
+		hash, digest := digestFor(alg, signingInput)
+		return signer.Sign(rand.Reader, digest, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: hash})
+	case ES256, ES384, ES512:
+		pub, ok := signer.Public().(*ecdsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("algorithm %s requires an ECDSA key, but got %T", alg, signer.Public())
+		}
+		hash, digest := digestFor(alg, signingInput)
+		der, err := signer.Sign(rand.Reader, digest, hash)
+		if err != nil {
+			return nil, err
+		}
+		// convert the ASN.1 signature to the fixed size R || S format that is required by JWS
+		r, s, err := parseECDSASignature(der)
+		if err != nil {
+			return nil, err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature, nil
+	case EdDSA:
+		return signer.Sign(rand.Reader, signingInput, crypto.Hash(0))
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm %q", alg)
+	}
+}
+
+func digestFor(alg string, data []byte) (crypto.Hash, []byte) {
+	switch alg {
+	case RS512, ES512:
+		sum := sha512.Sum512(data)
+		return crypto.SHA512, sum[:]
+	case ES384:
+		sum := sha512.Sum384(data)
+		return crypto.SHA384, sum[:]
+	default:
+		sum := sha256.Sum256(data)
+		return crypto.SHA256, sum[:]
+	}
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return ES256, nil
+	case elliptic.P384():
+		return ES384, nil
+	case elliptic.P521():
+		return ES512, nil
+	default:
+		return "", fmt.Errorf("unsupported elliptic curve %s", curve.Params().Name)
+	}
+}
+
+func parseECDSASignature(der []byte) (*big.Int, *big.Int, error) {
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse ECDSA signature: %v", err)
+	}
+	return sig.R, sig.S, nil
+}
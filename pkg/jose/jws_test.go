@@ -0,0 +1,202 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestKeys(t *testing.T) map[string]crypto.Signer {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]crypto.Signer{RS256: rsaKey, ES256: ecKey, EdDSA: edKey}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	for expectedAlg, key := range newTestKeys(t) {
+		t.Run(expectedAlg, func(t *testing.T) {
+			alg, err := AlgorithmForKey(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if alg != expectedAlg {
+				t.Errorf("expected algorithm %s, but got %s", expectedAlg, alg)
+			}
+
+			payload := []byte(`{"test":"payload"}`)
+			jws, err := SignDetached(Header{Algorithm: alg, KeyID: "key1"}, payload, key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			header, _, signingInput, signature, err := ParseCompact(jws, payload)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if header.KeyID != "key1" {
+				t.Errorf("unexpected key id %s", header.KeyID)
+			}
+			if err := VerifySignature(header.Algorithm, key.Public(), signingInput, signature); err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+
+			_, _, signingInput, signature, err = ParseCompact(jws, []byte(`{"test":"changed"}`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := VerifySignature(header.Algorithm, key.Public(), signingInput, signature); err == nil {
+				t.Errorf("expected error, but got nil")
+			}
+		})
+	}
+}
+
+func TestVerifySignatureKeyMismatch(t *testing.T) {
+	keys := newTestKeys(t)
+	jws, err := Sign(Header{Algorithm: ES256}, []byte("payload"), keys[ES256])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, payload, signingInput, signature, err := ParseCompact(jws, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != "payload" {
+		t.Errorf("unexpected payload %s", payload)
+	}
+	if err := VerifySignature(header.Algorithm, keys[RS256].Public(), signingInput, signature); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+	if err := VerifySignature("none", keys[ES256].Public(), signingInput, signature); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
+
+func TestWeakRSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AlgorithmForKey(key); err == nil {
+		t.Errorf("expected error for the 1024 bits RSA key")
+	}
+
+	payload := []byte("payload")
+	signingInput, err := signingInput(Header{Algorithm: RS256}, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := signRaw(RS256, key, []byte(signingInput))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySignature(RS256, key.Public(), []byte(signingInput), signature); err == nil {
+		t.Errorf("expected error for the signature of the 1024 bits RSA key")
+	}
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes())},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseJWKS(data); err == nil {
+		t.Errorf("expected error for the JWKS with the 1024 bits RSA key")
+	}
+}
+
+func TestKeySetFile(t *testing.T) {
+	keys := newTestKeys(t)
+	rsaKey := keys[RS256].Public().(*rsa.PublicKey)
+	ecKey := keys[ES256].Public().(*ecdsa.PublicKey)
+	edKey := keys[EdDSA].Public().(ed25519.PublicKey)
+
+	encode := func(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+	jwks := map[string]any{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+			{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+			{"kty": "EC", "kid": "enc", "use": "enc", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+			{"kty": "EC", "kid": "sig", "use": "sig", "crv": "P-256", "x": encode(ecKey.X.Bytes()), "y": encode(ecKey.Y.Bytes())},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJSON(t, path, jwks)
+
+	keySet := NewKeySetFile(path)
+	loaded, err := keySet.Keys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loaded) != 3 {
+		t.Errorf("expected 3 keys, but got %d", len(loaded))
+	}
+	if _, err := keySet.Lookup("enc"); err == nil {
+		t.Errorf("expected the encryption key is ignored")
+	}
+
+	key, err := keySet.Lookup("rsa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rsaKey.Equal(key) {
+		t.Errorf("unexpected rsa key")
+	}
+	if _, err := keySet.Lookup("ed"); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+
+	// the key set file is reloaded after it is changed
+	jwks["keys"] = []map[string]string{{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": encode(edKey)}}
+	writeJSON(t, path, jwks)
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err = keySet.Lookup("ed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !edKey.Equal(key) {
+		t.Errorf("unexpected ed25519 key")
+	}
+	if _, err := keySet.Lookup("rsa"); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+}
+
+func writeJSON(t *testing.T, path string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
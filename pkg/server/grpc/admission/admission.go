@@ -0,0 +1,383 @@
+package admission
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/util/cache"
+	k8smetrics "k8s.io/component-base/metrics"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+// RetryAfterKey is the metadata key of the rejected request, its value is the number of seconds that the client
+// should wait before retrying the request.
+const RetryAfterKey = "retry-after"
+
+// The reasons of the rejected requests.
+const (
+	ReasonUserRateLimit     = "user_rate_limit"
+	ReasonClusterRateLimit  = "cluster_rate_limit"
+	ReasonDataTypeRateLimit = "data_type_rate_limit"
+	ReasonSubscriptionLimit = "subscription_limit"
+)
+
+const (
+	// maxLimiters is the maximum number of the limiters of each kind, the least recently used limiters are evicted.
+	maxLimiters = 100000
+	// limiterTTL is the time that an idle limiter is kept, an evicted limiter is refilled when it is recreated.
+	limiterTTL = 10 * time.Minute
+	// subscriptionRetryAfter is the retry-after of the rejected subscriptions.
+	subscriptionRetryAfter = 10 * time.Second
+)
+
+// RejectionsMetric is a counter metric that tracks the total number of the requests rejected by the admission
+// control on the gRPC server. It is registered with the gRPC server metrics once the admission control is enabled.
+var RejectionsMetric = k8smetrics.NewCounterVec(&k8smetrics.CounterOpts{
+	Subsystem:      "grpc_server",
+	Name:           "admission_rejections_total",
+	StabilityLevel: k8smetrics.ALPHA,
+	Help:           "Total number of the requests rejected by the admission control on the gRPC server.",
+}, []string{"reason"})
+
+// UserInfoFunc returns the authenticated user of the request.
+type UserInfoFunc func(ctx context.Context) string
+
+// Controller admits the gRPC requests with the token bucket limits per user, per cluster and per data type, and
+// limits the number of the concurrent subscriptions per cluster. The rejected requests get the ResourceExhausted
+// status with the retry-after metadata.
+//
+// The per user limit is applied before the authorization, so that the unauthorized requests are limited as well.
+// The cluster and the data type are set by the client, so the other limits are applied after the authorization,
+// otherwise a user is able to exhaust the limits of the clusters that it is not authorized to.
+type Controller struct {
+	options  Options
+	userInfo UserInfoFunc
+
+	userLimiters     *keyedLimiter
+	clusterLimiters  *keyedLimiter
+	dataTypeLimiters *keyedLimiter
+
+	mu            sync.Mutex
+	subscriptions map[string]int
+}
+
+// NewController returns an admission Controller with the options, the user of the request is returned by the
+// userInfo.
+func NewController(options Options, userInfo UserInfoFunc) *Controller {
+	return &Controller{
+		options:          options,
+		userInfo:         userInfo,
+		userLimiters:     newKeyedLimiter(options.PerUser, ReasonUserRateLimit),
+		clusterLimiters:  newKeyedLimiter(options.PerCluster, ReasonClusterRateLimit),
+		dataTypeLimiters: newKeyedLimiter(options.PerDataType, ReasonDataTypeRateLimit),
+		subscriptions:    map[string]int{},
+	}
+}
+
+// UnaryInterceptor returns a unary interceptor that admits the requests with the per user limit, it should be
+// chained after the authentication interceptor and before the authorization interceptor.
+func (c *Controller) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if reason, retryAfter := c.admitUser(c.userInfo(ctx)); len(reason) != 0 {
+			_ = grpc.SetTrailer(ctx, retryAfterMetadata(retryAfter))
+			return nil, rejectedError(reason, retryAfter)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// AuthorizedUnaryInterceptor returns a unary interceptor that admits the published events with the per cluster
+// and per data type limits, it should be chained after the authorization interceptor.
+func (c *Controller) AuthorizedUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		pubReq, ok := req.(*pbv1.PublishRequest)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if reason, retryAfter := c.admitEvent(pubReq.Event); len(reason) != 0 {
+			_ = grpc.SetTrailer(ctx, retryAfterMetadata(retryAfter))
+			return nil, rejectedError(reason, retryAfter)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor returns a stream interceptor that admits the requests with the per user limit, it should be
+// chained after the authentication interceptor and before the authorization interceptor. Each event of a publish
+// stream is admitted once it is received, the rejected event is responded with the ResourceExhausted code directly.
+func (c *Controller) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		user := c.userInfo(ss.Context())
+
+		if info.FullMethod == pbv1.CloudEventService_PublishStream_FullMethodName {
+			return handler(srv, &admittedPublishStream{
+				ServerStream: ss,
+				admit: func(*pbv1.CloudEvent) (string, time.Duration) {
+					return c.admitUser(user)
+				},
+			})
+		}
+
+		if reason, retryAfter := c.admitUser(user); len(reason) != 0 {
+			ss.SetTrailer(retryAfterMetadata(retryAfter))
+			return rejectedError(reason, retryAfter)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// AuthorizedStreamInterceptor returns a stream interceptor that admits the events of a publish stream with the
+// per cluster and per data type limits, and admits the subscriptions with the concurrent subscriptions of their
+// clusters. It should be chained after the authorization interceptor.
+func (c *Controller) AuthorizedStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		switch info.FullMethod {
+		case pbv1.CloudEventService_PublishStream_FullMethodName:
+			return handler(srv, &admittedPublishStream{ServerStream: ss, admit: c.admitEvent})
+		case pbv1.CloudEventService_Subscribe_FullMethodName:
+			return c.admitSubscription(srv, ss, handler)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// admitSubscription admits the subscription with the concurrent subscriptions of its cluster, the subscription
+// request is read before the handler and it is replayed to the handler.
+func (c *Controller) admitSubscription(srv interface{}, ss grpc.ServerStream, handler grpc.StreamHandler) error {
+	subReq := &pbv1.SubscriptionRequest{}
+	if err := ss.RecvMsg(subReq); err != nil {
+		return err
+	}
+
+	if !c.acquireSubscription(subReq.ClusterName) {
+		RejectionsMetric.WithLabelValues(ReasonSubscriptionLimit).Inc()
+		ss.SetTrailer(retryAfterMetadata(subscriptionRetryAfter))
+		return rejectedError(ReasonSubscriptionLimit, subscriptionRetryAfter)
+	}
+	defer c.releaseSubscription(subReq.ClusterName)
+
+	return handler(srv, &replayedStream{ServerStream: ss, req: subReq})
+}
+
+// limiterCheck is a limiter of a request and the key of the request in the limiter.
+type limiterCheck struct {
+	limiters *keyedLimiter
+	key      string
+}
+
+// admitUser admits a request with the limit of its user.
+func (c *Controller) admitUser(user string) (string, time.Duration) {
+	return c.admit([]limiterCheck{{limiters: c.userLimiters, key: user}})
+}
+
+// admitEvent admits a published event with the limits of its cluster and data type.
+func (c *Controller) admitEvent(evt *pbv1.CloudEvent) (string, time.Duration) {
+	if evt == nil {
+		return "", 0
+	}
+
+	var checks []limiterCheck
+	if attr, ok := evt.Attributes["ce-"+types.ExtensionClusterName]; ok {
+		checks = append(checks, limiterCheck{limiters: c.clusterLimiters, key: attr.GetCeString()})
+	}
+	if eventType, err := types.ParseCloudEventsType(evt.Type); err == nil {
+		checks = append(checks, limiterCheck{limiters: c.dataTypeLimiters, key: eventType.CloudEventsDataType.String()})
+	}
+	return c.admit(checks)
+}
+
+// admit reserves a token from each limiter of the request, the reserved tokens are canceled if any of the
+// limiters rejects the request. It returns the reason and retry-after of the rejected request.
+func (c *Controller) admit(checks []limiterCheck) (string, time.Duration) {
+	now := time.Now()
+	reservations := make([]*rate.Reservation, 0, len(checks))
+	for _, check := range checks {
+		r := check.limiters.reserve(check.key, now)
+		if r == nil {
+			continue
+		}
+
+		if delay := r.DelayFrom(now); !r.OK() || delay > 0 {
+			r.CancelAt(now)
+			for _, reserved := range reservations {
+				reserved.CancelAt(now)
+			}
+			RejectionsMetric.WithLabelValues(check.limiters.reason).Inc()
+			return check.limiters.reason, delay
+		}
+		reservations = append(reservations, r)
+	}
+	return "", 0
+}
+
+func (c *Controller) acquireSubscription(clusterName string) bool {
+	if c.options.MaxSubscriptionsPerCluster == 0 {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscriptions[clusterName] >= c.options.MaxSubscriptionsPerCluster {
+		return false
+	}
+	c.subscriptions[clusterName]++
+	return true
+}
+
+func (c *Controller) releaseSubscription(clusterName string) {
+	if c.options.MaxSubscriptionsPerCluster == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.subscriptions[clusterName]--
+	if c.subscriptions[clusterName] <= 0 {
+		delete(c.subscriptions, clusterName)
+	}
+}
+
+// keyedLimiter keeps a token bucket limiter for each key.
+type keyedLimiter struct {
+	limit  RateLimit
+	reason string
+
+	mu       sync.Mutex
+	limiters *cache.LRUExpireCache
+}
+
+func newKeyedLimiter(limit RateLimit, reason string) *keyedLimiter {
+	return &keyedLimiter{
+		limit:    limit,
+		reason:   reason,
+		limiters: cache.NewLRUExpireCache(maxLimiters),
+	}
+}
+
+// reserve reserves a token of the key, it returns nil if the limit is disabled.
+func (l *keyedLimiter) reserve(key string, now time.Time) *rate.Reservation {
+	if !l.limit.enabled() {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.limit.QPS), l.limit.burst())
+	}
+	// refresh the ttl of the limiter once it is used
+	l.limiters.Add(key, limiter, limiterTTL)
+	return limiter.(*rate.Limiter).ReserveN(now, 1)
+}
+
+// admittedPublishStream admits each event of a publish stream. The rejected event is responded with the
+// ResourceExhausted code directly, and it is not passed to the stream handler.
+type admittedPublishStream struct {
+	grpc.ServerStream
+	admit func(evt *pbv1.CloudEvent) (string, time.Duration)
+}
+
+func (s *admittedPublishStream) RecvMsg(m any) error {
+	msg, ok := m.(*pbv1.PublishStreamRequest)
+	if !ok {
+		return s.ServerStream.RecvMsg(m)
+	}
+
+	for {
+		if err := s.ServerStream.RecvMsg(msg); err != nil {
+			return err
+		}
+
+		reason, retryAfter := s.admit(msg.Event)
+		if len(reason) == 0 {
+			return nil
+		}
+
+		if err := s.ServerStream.SendMsg(&pbv1.PublishStreamResponse{
+			Sequence: msg.Sequence,
+			Code:     int32(codes.ResourceExhausted),
+			Message:  status.Convert(rejectedError(reason, retryAfter)).Message(),
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// replayedStream replays the request that is already read to the stream handler.
+type replayedStream struct {
+	grpc.ServerStream
+
+	mu  sync.Mutex
+	req proto.Message
+}
+
+func (s *replayedStream) RecvMsg(m any) error {
+	s.mu.Lock()
+	req := s.req
+	s.req = nil
+	s.mu.Unlock()
+
+	if req == nil {
+		return s.ServerStream.RecvMsg(m)
+	}
+
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return fmt.Errorf("unsupported request type %T", m)
+	}
+	proto.Reset(msg)
+	proto.Merge(msg, req)
+	return nil
+}
+
+func retryAfterSeconds(retryAfter time.Duration) int64 {
+	return int64(math.Max(1, math.Ceil(retryAfter.Seconds())))
+}
+
+func retryAfterMetadata(retryAfter time.Duration) metadata.MD {
+	return metadata.Pairs(RetryAfterKey, strconv.FormatInt(retryAfterSeconds(retryAfter), 10))
+}
+
+func rejectedError(reason string, retryAfter time.Duration) error {
+	return status.Error(codes.ResourceExhausted,
+		fmt.Sprintf("request is rejected by %s, retry after %ds", reason, retryAfterSeconds(retryAfter)))
+}
@@ -0,0 +1,276 @@
+package admission
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+const (
+	testWorkType    = "io.open-cluster-management.works.v1alpha1.manifestbundles.status.update_request"
+	testClusterType = "io.open-cluster-management.cluster.v1.clusters.status.update_request"
+)
+
+type userKey struct{}
+
+func testUserInfo(ctx context.Context) string {
+	user, _ := ctx.Value(userKey{}).(string)
+	return user
+}
+
+func TestUnaryAdmission(t *testing.T) {
+	controller := NewController(Options{
+		PerUser:     RateLimit{QPS: 0.001, Burst: 5},
+		PerCluster:  RateLimit{QPS: 0.001, Burst: 2},
+		PerDataType: RateLimit{QPS: 0.001, Burst: 1},
+	}, testUserInfo)
+	interceptor := controller.UnaryInterceptor()
+	authorizedInterceptor := controller.AuthorizedUnaryInterceptor()
+
+	publish := func(user, eventType, clusterName string) error {
+		ctx := context.WithValue(context.Background(), userKey{}, user)
+		req := &pbv1.PublishRequest{Event: newEvent(eventType, clusterName)}
+		info := &grpc.UnaryServerInfo{FullMethod: pbv1.CloudEventService_Publish_FullMethodName}
+		_, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return authorizedInterceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+		})
+		return err
+	}
+
+	steps := []struct {
+		name      string
+		user      string
+		eventType string
+		cluster   string
+		expected  string
+	}{
+		{name: "first work event", user: "agent1", eventType: testWorkType, cluster: "cluster1"},
+		{name: "work data type is limited", user: "agent1", eventType: testWorkType, cluster: "cluster1", expected: ReasonDataTypeRateLimit},
+		// the token of cluster1 that is reserved by the rejected request is returned
+		{name: "cluster data type is admitted", user: "agent1", eventType: testClusterType, cluster: "cluster1"},
+		{name: "cluster1 is limited", user: "agent1", eventType: testClusterType, cluster: "cluster1", expected: ReasonClusterRateLimit},
+		{name: "cluster2 data type is limited", user: "agent1", eventType: testClusterType, cluster: "cluster2", expected: ReasonDataTypeRateLimit},
+		// the tokens of the user are taken before the authorization, even if the event is rejected later
+		{name: "user is limited", user: "agent1", expected: ReasonUserRateLimit},
+		{name: "other user is admitted", user: "agent2"},
+	}
+
+	for _, step := range steps {
+		err := publish(step.user, step.eventType, step.cluster)
+		if len(step.expected) == 0 {
+			if err != nil {
+				t.Errorf("%s: expected the request is admitted, but got %v", step.name, err)
+			}
+			continue
+		}
+
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("%s: expected ResourceExhausted, but got %v", step.name, err)
+			continue
+		}
+		if msg := status.Convert(err).Message(); !strings.Contains(msg, step.expected) {
+			t.Errorf("%s: expected rejected by %s, but got %s", step.name, step.expected, msg)
+		}
+	}
+}
+
+func TestUnaryAdmissionBeforeAuthorization(t *testing.T) {
+	controller := NewController(Options{
+		PerUser:    RateLimit{QPS: 0.001, Burst: 2},
+		PerCluster: RateLimit{QPS: 0.001, Burst: 1},
+	}, testUserInfo)
+	interceptor := controller.UnaryInterceptor()
+
+	ctx := context.WithValue(context.Background(), userKey{}, "agent1")
+	info := &grpc.UnaryServerInfo{FullMethod: pbv1.CloudEventService_Publish_FullMethodName}
+	for i := 0; i < 2; i++ {
+		// the cluster of the event is not authorized yet, so it is not limited before the authorization
+		req := &pbv1.PublishRequest{Event: newEvent(testWorkType, "cluster1")}
+		if _, err := interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		}); err != nil {
+			t.Errorf("expected the request %d is admitted, but got %v", i, err)
+		}
+	}
+}
+
+func TestPublishStreamAdmission(t *testing.T) {
+	controller := NewController(Options{PerCluster: RateLimit{QPS: 0.001, Burst: 1}}, testUserInfo)
+	interceptor := controller.AuthorizedStreamInterceptor()
+
+	stream := &fakeStream{ctx: context.Background(), requests: []any{
+		&pbv1.PublishStreamRequest{Sequence: 1, Event: newEvent(testWorkType, "cluster1")},
+		&pbv1.PublishStreamRequest{Sequence: 2, Event: newEvent(testWorkType, "cluster1")},
+		&pbv1.PublishStreamRequest{Sequence: 3, Event: newEvent(testWorkType, "cluster2")},
+	}}
+	received := []uint64{}
+	info := &grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_PublishStream_FullMethodName}
+	err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		for {
+			req := &pbv1.PublishStreamRequest{}
+			if err := ss.RecvMsg(req); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			received = append(received, req.Sequence)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second event of cluster1 is rejected without passing to the handler
+	if len(received) != 2 || received[0] != 1 || received[1] != 3 {
+		t.Errorf("expected events 1 and 3 are received, but got %v", received)
+	}
+	if len(stream.responses) != 1 || stream.responses[0].Sequence != 2 ||
+		stream.responses[0].Code != int32(codes.ResourceExhausted) {
+		t.Errorf("expected event 2 is rejected, but got %v", stream.responses)
+	}
+}
+
+func TestSubscriptionAdmission(t *testing.T) {
+	controller := NewController(Options{MaxSubscriptionsPerCluster: 1}, testUserInfo)
+	interceptor := controller.AuthorizedStreamInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_Subscribe_FullMethodName}
+
+	subscribe := func(clusterName string, handler grpc.StreamHandler) (*fakeStream, error) {
+		stream := &fakeStream{ctx: context.Background(), requests: []any{
+			&pbv1.SubscriptionRequest{ClusterName: clusterName, DataType: "test"},
+		}}
+		return stream, interceptor(nil, stream, info, handler)
+	}
+
+	established := make(chan struct{})
+	closed := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		_, err := subscribe("cluster1", func(srv any, ss grpc.ServerStream) error {
+			// the subscription request is replayed to the handler
+			req := &pbv1.SubscriptionRequest{}
+			if err := ss.RecvMsg(req); err != nil {
+				return err
+			}
+			if req.ClusterName != "cluster1" || req.DataType != "test" {
+				t.Errorf("unexpected subscription request %v", req)
+			}
+			close(established)
+			<-closed
+			return nil
+		})
+		done <- err
+	}()
+	<-established
+
+	noop := func(srv any, ss grpc.ServerStream) error { return nil }
+	stream, err := subscribe("cluster1", noop)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected the second subscription of cluster1 is rejected, but got %v", err)
+	}
+	if retryAfter := stream.trailer.Get(RetryAfterKey); len(retryAfter) != 1 || retryAfter[0] != "10" {
+		t.Errorf("expected retry-after 10, but got %v", retryAfter)
+	}
+	if _, err := subscribe("cluster2", noop); err != nil {
+		t.Errorf("expected the subscription of cluster2 is admitted, but got %v", err)
+	}
+
+	// the subscription of cluster1 is admitted once the first one is closed
+	close(closed)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := subscribe("cluster1", noop); err != nil {
+		t.Errorf("expected the subscription of cluster1 is admitted, but got %v", err)
+	}
+}
+
+func TestValidateOptions(t *testing.T) {
+	if (Options{}).Enabled() {
+		t.Errorf("expected the admission is disabled by default")
+	}
+	if err := (Options{PerUser: RateLimit{QPS: 10}, MaxSubscriptionsPerCluster: 2}).Validate(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	if err := (Options{PerCluster: RateLimit{QPS: -1}}).Validate(); err == nil {
+		t.Errorf("expected error for the negative qps")
+	}
+	if err := (Options{MaxSubscriptionsPerCluster: -1}).Validate(); err == nil {
+		t.Errorf("expected error for the negative max subscriptions")
+	}
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	sync.Mutex
+
+	ctx       context.Context
+	requests  []any
+	responses []*pbv1.PublishStreamResponse
+	trailer   metadata.MD
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) SetTrailer(md metadata.MD) {
+	s.trailer = metadata.Join(s.trailer, md)
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	switch msg := m.(type) {
+	case *pbv1.PublishStreamRequest:
+		r := req.(*pbv1.PublishStreamRequest)
+		msg.Sequence = r.Sequence
+		msg.Event = r.Event
+	case *pbv1.SubscriptionRequest:
+		r := req.(*pbv1.SubscriptionRequest)
+		msg.ClusterName = r.ClusterName
+		msg.DataType = r.DataType
+	}
+	return nil
+}
+
+func (s *fakeStream) SendMsg(m any) error {
+	if resp, ok := m.(*pbv1.PublishStreamResponse); ok {
+		s.responses = append(s.responses, resp)
+	}
+	return nil
+}
+
+func newEvent(eventType, clusterName string) *pbv1.CloudEvent {
+	evt := &pbv1.CloudEvent{
+		SpecVersion: "1.0",
+		Id:          "test-id",
+		Source:      "test-source",
+		Type:        eventType,
+		Attributes:  map[string]*pbv1.CloudEventAttributeValue{},
+	}
+	if len(clusterName) != 0 {
+		evt.Attributes["ce-clustername"] = &pbv1.CloudEventAttributeValue{
+			Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: clusterName},
+		}
+	}
+	return evt
+}
@@ -0,0 +1,61 @@
+package admission
+
+import (
+	"fmt"
+	"math"
+)
+
+// RateLimit is a token bucket limit, the limit is disabled if the QPS is zero.
+type RateLimit struct {
+	// QPS is the number of the requests that are admitted per second.
+	QPS float64 `json:"qps" yaml:"qps"`
+	// Burst is the maximum number of the requests that are admitted at once, defaults to the ceiling of QPS.
+	Burst int `json:"burst" yaml:"burst"`
+}
+
+func (l RateLimit) enabled() bool {
+	return l.QPS > 0
+}
+
+func (l RateLimit) burst() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+	return int(math.Ceil(l.QPS))
+}
+
+// Options configures the admission control of the gRPC server, the admission control is disabled if no limit
+// is set.
+type Options struct {
+	// PerUser limits the requests of each authenticated user.
+	PerUser RateLimit `json:"per_user" yaml:"per_user"`
+	// PerCluster limits the published events of each cluster.
+	PerCluster RateLimit `json:"per_cluster" yaml:"per_cluster"`
+	// PerDataType limits the published events of each cloudevents data type.
+	PerDataType RateLimit `json:"per_data_type" yaml:"per_data_type"`
+	// MaxSubscriptionsPerCluster is the maximum number of the concurrent subscriptions of each cluster, zero
+	// means unlimited.
+	MaxSubscriptionsPerCluster int `json:"max_subscriptions_per_cluster" yaml:"max_subscriptions_per_cluster"`
+}
+
+// Enabled returns true if any limit is set.
+func (o Options) Enabled() bool {
+	return o.PerUser.enabled() || o.PerCluster.enabled() || o.PerDataType.enabled() || o.MaxSubscriptionsPerCluster > 0
+}
+
+// Validate checks the limits are not negative.
+func (o Options) Validate() error {
+	for name, limit := range map[string]RateLimit{
+		"per_user":      o.PerUser,
+		"per_cluster":   o.PerCluster,
+		"per_data_type": o.PerDataType,
+	} {
+		if limit.QPS < 0 || limit.Burst < 0 {
+			return fmt.Errorf("the qps and burst of admission %s limit must not be negative", name)
+		}
+	}
+	if o.MaxSubscriptionsPerCluster < 0 {
+		return fmt.Errorf("admission max_subscriptions_per_cluster must not be negative")
+	}
+	return nil
+}
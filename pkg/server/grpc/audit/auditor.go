@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// BackendOptions configures the buffering and batching of the audit events.
+type BackendOptions struct {
+	// BufferSize is the number of the audit events that can be buffered, the audit events are dropped once the
+	// buffer is full, so the auditing never blocks the requests.
+	BufferSize int
+	// MaxBatchSize is the maximum number of the audit events that are sent to the sinks in one batch.
+	MaxBatchSize int
+	// MaxBatchWait is the maximum time to wait for a batch to be filled before it is sent to the sinks.
+	MaxBatchWait time.Duration
+}
+
+func NewBackendOptions() *BackendOptions {
+	return &BackendOptions{
+		BufferSize:   10000,
+		MaxBatchSize: 100,
+		MaxBatchWait: time.Second,
+	}
+}
+
+// Auditor decides the audit level of the requests with the policy and sends the audit events to the sinks.
+// The audit events are buffered and sent to the sinks in batches by Run.
+type Auditor struct {
+	policy  *Policy
+	options *BackendOptions
+	sinks   []Sink
+	buffer  chan *Event
+}
+
+// NewAuditor returns an Auditor with the policy, backend options and sinks.
+func NewAuditor(policy *Policy, options *BackendOptions, sinks ...Sink) *Auditor {
+	if options == nil {
+		options = NewBackendOptions()
+	}
+	return &Auditor{
+		policy:  policy,
+		options: options,
+		sinks:   sinks,
+		buffer:  make(chan *Event, options.BufferSize),
+	}
+}
+
+// LevelFor returns the audit level of the request.
+func (a *Auditor) LevelFor(user string, groups []string, dataType string) Level {
+	if a.policy == nil {
+		return LevelNone
+	}
+	return a.policy.LevelFor(user, groups, dataType)
+}
+
+// Audit buffers the audit event without blocking, the event is dropped if the buffer is full.
+func (a *Auditor) Audit(evt *Event) {
+	select {
+	case a.buffer <- evt:
+	default:
+		addEvents(resultDropped, 1)
+		klog.V(4).Infof("audit buffer is full, drop the audit event %s of %s", evt.AuditID, evt.Method)
+	}
+}
+
+// Run sends the buffered audit events to the sinks until the context is done, the remaining buffered events
+// are sent before it returns.
+func (a *Auditor) Run(ctx context.Context) {
+	batch := make([]*Event, 0, a.options.MaxBatchSize)
+	ticker := time.NewTicker(a.options.MaxBatchWait)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case evt := <-a.buffer:
+					batch = append(batch, evt)
+					if len(batch) >= a.options.MaxBatchSize {
+						batch = a.flush(batch)
+					}
+				default:
+					a.flush(batch)
+					return
+				}
+			}
+		case evt := <-a.buffer:
+			batch = append(batch, evt)
+			if len(batch) >= a.options.MaxBatchSize {
+				batch = a.flush(batch)
+			}
+		case <-ticker.C:
+			batch = a.flush(batch)
+		}
+	}
+}
+
+// flush sends the batch to the sinks and returns the emptied batch.
+func (a *Auditor) flush(batch []*Event) []*Event {
+	if len(batch) == 0 {
+		return batch
+	}
+
+	result := resultSent
+	for _, sink := range a.sinks {
+		if err := sink.ProcessEvents(batch...); err != nil {
+			klog.Errorf("failed to process %d audit events, %v", len(batch), err)
+			result = resultFailed
+		}
+	}
+	addEvents(result, len(batch))
+
+	return batch[:0]
+}
@@ -0,0 +1,162 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/binding"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/peer"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	grpcprotocol "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protocol"
+	"open-cluster-management.io/sdk-go/pkg/cloudevents/generic/types"
+)
+
+type contextKey struct{}
+
+// UserInfoFunc returns the authenticated user and groups of the request.
+type UserInfoFunc func(ctx context.Context) (string, []string)
+
+// eventInfo is the audited attributes of a published event or a subscription.
+type eventInfo struct {
+	clusterName string
+	dataType    string
+	eventType   string
+	eventID     string
+	request     json.RawMessage
+}
+
+// requestContext is the audit context of a request, it accumulates the annotations that are added by the
+// request handlers until the audit event is recorded.
+type requestContext struct {
+	sync.Mutex
+
+	auditor     *Auditor
+	auditID     string
+	method      string
+	user        string
+	groups      []string
+	sourceIP    string
+	info        eventInfo
+	annotations map[string]string
+}
+
+func newRequestContext(ctx context.Context, auditor *Auditor, userInfo UserInfoFunc, method string) *requestContext {
+	user, groups := userInfo(ctx)
+	rc := &requestContext{
+		auditor: auditor,
+		auditID: uuid.NewString(),
+		method:  method,
+		user:    user,
+		groups:  groups,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		rc.sourceIP = p.Addr.String()
+	}
+	return rc
+}
+
+// AddAnnotation adds an annotation to the audit event of the request, it is a no-op if the request is not
+// audited. For a publish stream, the annotation is recorded with the audit event of the current event.
+func AddAnnotation(ctx context.Context, key, value string) {
+	rc, ok := ctx.Value(contextKey{}).(*requestContext)
+	if !ok {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	if rc.annotations == nil {
+		rc.annotations = map[string]string{}
+	}
+	rc.annotations[key] = value
+}
+
+// LogResponseStarted records the ResponseStarted stage of a long-running request, e.g. the subscription is
+// established, it is a no-op if the request is not audited.
+func LogResponseStarted(ctx context.Context) {
+	rc, ok := ctx.Value(contextKey{}).(*requestContext)
+	if !ok {
+		return
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+
+	rc.record(StageResponseStarted, rc.info, "", "")
+}
+
+// setInfo sets the audited attributes of the request.
+func (rc *requestContext) setInfo(info eventInfo) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	rc.info = info
+}
+
+// newEventInfo returns the audited attributes of a published event, the event is encoded only if it is
+// audited at the Request level.
+func (rc *requestContext) newEventInfo(ctx context.Context, pbEvt *pbv1.CloudEvent) eventInfo {
+	if pbEvt == nil {
+		return eventInfo{}
+	}
+
+	info := eventInfo{eventType: pbEvt.Type, eventID: pbEvt.Id}
+	if eventType, err := types.ParseCloudEventsType(pbEvt.Type); err == nil {
+		info.dataType = eventType.CloudEventsDataType.String()
+	}
+	if attr, ok := pbEvt.Attributes["ce-"+types.ExtensionClusterName]; ok {
+		info.clusterName = attr.GetCeString()
+	}
+
+	if rc.auditor.LevelFor(rc.user, rc.groups, info.dataType) != LevelRequest {
+		return info
+	}
+
+	// the invalid event is audited without the request, it is rejected by the broker
+	evt, err := binding.ToEvent(ctx, grpcprotocol.NewMessage(pbEvt))
+	if err != nil {
+		return info
+	}
+	if data, err := json.Marshal(evt); err == nil {
+		info.request = data
+	}
+	return info
+}
+
+// record sends the audit event of the request to the auditor if the request is audited, the caller must hold
+// the lock.
+func (rc *requestContext) record(stage Stage, info eventInfo, code, message string) {
+	level := rc.auditor.LevelFor(rc.user, rc.groups, info.dataType)
+	if level == LevelNone {
+		return
+	}
+
+	evt := &Event{
+		Level:       level,
+		AuditID:     rc.auditID,
+		Stage:       stage,
+		Timestamp:   metav1.NewMicroTime(time.Now()),
+		Method:      rc.method,
+		User:        rc.user,
+		Groups:      rc.groups,
+		SourceIP:    rc.sourceIP,
+		ClusterName: info.clusterName,
+		DataType:    info.dataType,
+		EventType:   info.eventType,
+		EventID:     info.eventID,
+		Code:        code,
+		Message:     message,
+		Annotations: maps.Clone(rc.annotations),
+	}
+	if level == LevelRequest {
+		evt.Request = info.request
+	}
+	rc.auditor.Audit(evt)
+}
@@ -0,0 +1,128 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+// NewUnaryInterceptor returns a unary interceptor that audits the requests with the auditor, the identity of
+// the request is returned by the userInfo. It should be chained after the authentication interceptor to record
+// the identity, and before the authorization interceptor to record the denied requests.
+func NewUnaryInterceptor(auditor *Auditor, userInfo UserInfoFunc) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		rc := newRequestContext(ctx, auditor, userInfo, info.FullMethod)
+		if pubReq, ok := req.(*pbv1.PublishRequest); ok {
+			rc.setInfo(rc.newEventInfo(ctx, pubReq.Event))
+		}
+
+		resp, err := handler(context.WithValue(ctx, contextKey{}, rc), req)
+
+		st := status.Convert(err)
+		rc.Lock()
+		rc.record(StageResponseComplete, rc.info, st.Code().String(), st.Message())
+		rc.Unlock()
+		return resp, err
+	}
+}
+
+// NewStreamInterceptor returns a stream interceptor that audits the requests with the auditor. Each event of
+// a publish stream is audited once it is responded, and a subscription is audited once it is completed, the
+// broker records the ResponseStarted stage of a subscription once it is established.
+func NewStreamInterceptor(auditor *Auditor, userInfo UserInfoFunc) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		rc := newRequestContext(ss.Context(), auditor, userInfo, info.FullMethod)
+		stream := &auditedStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), contextKey{}, rc),
+			rc:           rc,
+			pending:      map[uint64]eventInfo{},
+		}
+
+		err := handler(srv, stream)
+
+		st := status.Convert(err)
+		rc.Lock()
+		defer rc.Unlock()
+		if info.FullMethod != pbv1.CloudEventService_PublishStream_FullMethodName {
+			rc.record(StageResponseComplete, rc.info, st.Code().String(), st.Message())
+			return err
+		}
+
+		// the events that are not responded are completed with the error of the stream
+		code := st.Code()
+		if code == codes.OK {
+			code = codes.Aborted
+		}
+		stream.Lock()
+		defer stream.Unlock()
+		for _, pending := range stream.pending {
+			rc.record(StageResponseComplete, pending, code.String(), st.Message())
+		}
+		return err
+	}
+}
+
+// auditedStream records the attributes of the received requests, and audits the events of a publish stream
+// once they are responded.
+type auditedStream struct {
+	grpc.ServerStream
+	sync.Mutex
+
+	ctx     context.Context
+	rc      *requestContext
+	pending map[uint64]eventInfo
+}
+
+func (s *auditedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	switch msg := m.(type) {
+	case *pbv1.SubscriptionRequest:
+		s.rc.setInfo(eventInfo{clusterName: msg.ClusterName, dataType: msg.DataType})
+	case *pbv1.PublishStreamRequest:
+		s.Lock()
+		s.pending[msg.Sequence] = s.rc.newEventInfo(s.ctx, msg.Event)
+		s.Unlock()
+	}
+	return nil
+}
+
+func (s *auditedStream) SendMsg(m any) error {
+	if resp, ok := m.(*pbv1.PublishStreamResponse); ok {
+		s.Lock()
+		pending, found := s.pending[resp.Sequence]
+		delete(s.pending, resp.Sequence)
+		s.Unlock()
+
+		if found {
+			s.rc.Lock()
+			s.rc.record(StageResponseComplete, pending, codes.Code(resp.Code).String(), resp.Message)
+			// the annotations are added for the responded event
+			s.rc.annotations = nil
+			s.rc.Unlock()
+		}
+	}
+	return s.ServerStream.SendMsg(m)
+}
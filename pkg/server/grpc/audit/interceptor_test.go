@@ -0,0 +1,260 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+)
+
+const testDataType = "io.open-cluster-management.works.v1alpha1.manifestbundles"
+
+func TestUnaryInterceptor(t *testing.T) {
+	auditor := NewAuditor(&Policy{Rules: []PolicyRule{{Level: LevelRequest}}}, nil)
+	interceptor := NewUnaryInterceptor(auditor, testUserInfo)
+
+	info := &grpc.UnaryServerInfo{FullMethod: pbv1.CloudEventService_Publish_FullMethodName}
+	req := &pbv1.PublishRequest{Event: newEvent("event1", "cluster1")}
+	_, err := interceptor(context.Background(), req, info, func(ctx context.Context, req any) (any, error) {
+		AddAnnotation(ctx, AnnotationAuthorizationDecision, "deny")
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	events := receiveEvents(t, auditor, 1)
+	evt := events[0]
+	if evt.Stage != StageResponseComplete || evt.Level != LevelRequest || evt.Method != info.FullMethod ||
+		evt.User != "agent1" || evt.ClusterName != "cluster1" || evt.DataType != testDataType ||
+		evt.EventID != "event1" || evt.Code != codes.PermissionDenied.String() ||
+		evt.Annotations[AnnotationAuthorizationDecision] != "deny" {
+		t.Errorf("unexpected audit event %v", evt)
+	}
+
+	request := map[string]any{}
+	if err := json.Unmarshal(evt.Request, &request); err != nil {
+		t.Fatal(err)
+	}
+	if request["id"] != "event1" || request["clustername"] != "cluster1" {
+		t.Errorf("unexpected request %v", request)
+	}
+}
+
+func TestPublishStreamInterceptor(t *testing.T) {
+	auditor := NewAuditor(&Policy{Rules: []PolicyRule{{Level: LevelMetadata}}}, nil)
+	interceptor := NewStreamInterceptor(auditor, testUserInfo)
+
+	stream := &fakeStream{ctx: context.Background(), requests: []any{
+		&pbv1.PublishStreamRequest{Sequence: 1, Event: newEvent("event1", "cluster1")},
+		&pbv1.PublishStreamRequest{Sequence: 2, Event: newEvent("event2", "cluster2")},
+		&pbv1.PublishStreamRequest{Sequence: 3, Event: newEvent("event3", "cluster3")},
+	}}
+	info := &grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_PublishStream_FullMethodName}
+	err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		for _, code := range []codes.Code{codes.OK, codes.FailedPrecondition} {
+			req := &pbv1.PublishStreamRequest{}
+			if err := ss.RecvMsg(req); err != nil {
+				return err
+			}
+			AddAnnotation(ss.Context(), AnnotationResourceID, req.Event.Id)
+			if err := ss.SendMsg(&pbv1.PublishStreamResponse{Sequence: req.Sequence, Code: int32(code)}); err != nil {
+				return err
+			}
+		}
+		// the third event is not responded
+		req := &pbv1.PublishStreamRequest{}
+		if err := ss.RecvMsg(req); err != nil {
+			return err
+		}
+		return status.Error(codes.Unavailable, "stream is closed")
+	})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	events := receiveEvents(t, auditor, 3)
+	expected := []struct{ eventID, clusterName, code string }{
+		{"event1", "cluster1", codes.OK.String()},
+		{"event2", "cluster2", codes.FailedPrecondition.String()},
+		{"event3", "cluster3", codes.Unavailable.String()},
+	}
+	for i, e := range expected {
+		evt := events[i]
+		if evt.EventID != e.eventID || evt.ClusterName != e.clusterName || evt.Code != e.code || evt.Request != nil {
+			t.Errorf("unexpected audit event %v", evt)
+		}
+		// the annotations are recorded with the event that is handled
+		if i < 2 && (len(evt.Annotations) != 1 || evt.Annotations[AnnotationResourceID] != e.eventID) {
+			t.Errorf("unexpected annotations %v", evt.Annotations)
+		}
+	}
+}
+
+func TestSubscribeInterceptor(t *testing.T) {
+	auditor := NewAuditor(&Policy{Rules: []PolicyRule{
+		{Level: LevelMetadata, DataTypes: []string{testDataType}},
+	}}, nil)
+	interceptor := NewStreamInterceptor(auditor, testUserInfo)
+
+	stream := &fakeStream{ctx: context.Background(), requests: []any{
+		&pbv1.SubscriptionRequest{ClusterName: "cluster1", DataType: testDataType},
+	}}
+	info := &grpc.StreamServerInfo{FullMethod: pbv1.CloudEventService_Subscribe_FullMethodName}
+	if err := interceptor(nil, stream, info, func(srv any, ss grpc.ServerStream) error {
+		if err := ss.RecvMsg(&pbv1.SubscriptionRequest{}); err != nil {
+			return err
+		}
+		AddAnnotation(ss.Context(), AnnotationSubscriptionID, "sub1")
+		LogResponseStarted(ss.Context())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	events := receiveEvents(t, auditor, 2)
+	for i, stage := range []Stage{StageResponseStarted, StageResponseComplete} {
+		evt := events[i]
+		if evt.Stage != stage || evt.ClusterName != "cluster1" || evt.DataType != testDataType ||
+			evt.Annotations[AnnotationSubscriptionID] != "sub1" || evt.AuditID != events[0].AuditID {
+			t.Errorf("unexpected audit event %v", evt)
+		}
+	}
+}
+
+func TestAuditorBackpressure(t *testing.T) {
+	sink := &recordingSink{}
+	auditor := NewAuditor(&Policy{}, &BackendOptions{BufferSize: 3, MaxBatchSize: 2, MaxBatchWait: time.Hour}, sink)
+
+	// the events are dropped without blocking once the buffer is full
+	for i := 0; i < 5; i++ {
+		auditor.Audit(&Event{AuditID: "test"})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		auditor.Run(ctx)
+	}()
+
+	// the first full batch is sent without waiting
+	if err := waitFor(func() bool { return len(sink.batchSizes()) == 1 }); err != nil {
+		t.Fatalf("expected a full batch is sent, but got %v", sink.batchSizes())
+	}
+
+	// the remaining event is flushed once the auditor is stopped
+	cancel()
+	<-done
+	if sizes := sink.batchSizes(); len(sizes) != 2 || sizes[0] != 2 || sizes[1] != 1 {
+		t.Errorf("expected batches [2 1], but got %v", sizes)
+	}
+}
+
+func testUserInfo(ctx context.Context) (string, []string) {
+	return "agent1", []string{"system:open-cluster-management:cluster1"}
+}
+
+func receiveEvents(t *testing.T, auditor *Auditor, count int) []*Event {
+	events := []*Event{}
+	for i := 0; i < count; i++ {
+		select {
+		case evt := <-auditor.buffer:
+			events = append(events, evt)
+		default:
+			t.Fatalf("expected %d audit events, but got %d", count, len(events))
+		}
+	}
+	if len(auditor.buffer) != 0 {
+		t.Fatalf("expected %d audit events, but got %d", count, count+len(auditor.buffer))
+	}
+	return events
+}
+
+func waitFor(condition func() bool) error {
+	for i := 0; i < 100; i++ {
+		if condition() {
+			return nil
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return context.DeadlineExceeded
+}
+
+type recordingSink struct {
+	sync.Mutex
+	sizes []int
+}
+
+func (s *recordingSink) ProcessEvents(events ...*Event) error {
+	s.Lock()
+	defer s.Unlock()
+	s.sizes = append(s.sizes, len(events))
+	return nil
+}
+
+func (s *recordingSink) batchSizes() []int {
+	s.Lock()
+	defer s.Unlock()
+	return append([]int{}, s.sizes...)
+}
+
+type fakeStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []any
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg(m any) error {
+	if len(s.requests) == 0 {
+		return io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+
+	switch msg := m.(type) {
+	case *pbv1.PublishStreamRequest:
+		r := req.(*pbv1.PublishStreamRequest)
+		msg.Sequence = r.Sequence
+		msg.Event = r.Event
+	case *pbv1.SubscriptionRequest:
+		r := req.(*pbv1.SubscriptionRequest)
+		msg.ClusterName = r.ClusterName
+		msg.DataType = r.DataType
+	}
+	return nil
+}
+
+func (s *fakeStream) SendMsg(m any) error {
+	return nil
+}
+
+func newEvent(id, clusterName string) *pbv1.CloudEvent {
+	return &pbv1.CloudEvent{
+		SpecVersion: "1.0",
+		Id:          id,
+		Source:      "test-source",
+		Type:        testDataType + ".status.update_request",
+		Attributes: map[string]*pbv1.CloudEventAttributeValue{
+			"ce-clustername": {
+				Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: clusterName},
+			},
+			"datacontenttype": {
+				Attr: &pbv1.CloudEventAttributeValue_CeString{CeString: "application/json"},
+			},
+		},
+		Data: &pbv1.CloudEvent_BinaryData{BinaryData: []byte("{}")},
+	}
+}
@@ -0,0 +1,25 @@
+package audit
+
+import (
+	k8smetrics "k8s.io/component-base/metrics"
+)
+
+// Values of the audit events metric result label.
+const (
+	resultSent    = "sent"
+	resultDropped = "dropped"
+	resultFailed  = "failed"
+)
+
+// EventsMetric is a counter metric that tracks the total number of the audit events on the gRPC server, the
+// result is sent, dropped or failed. It is registered with the gRPC server metrics once an auditor is set.
+var EventsMetric = k8smetrics.NewCounterVec(&k8smetrics.CounterOpts{
+	Subsystem:      "grpc_server",
+	Name:           "audit_events_total",
+	StabilityLevel: k8smetrics.ALPHA,
+	Help:           "Total number of the audit events on the gRPC server.",
+}, []string{"result"})
+
+func addEvents(result string, count int) {
+	EventsMetric.WithLabelValues(result).Add(float64(count))
+}
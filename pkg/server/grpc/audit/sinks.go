@@ -0,0 +1,203 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+// Sink processes the batches of the audit events.
+type Sink interface {
+	ProcessEvents(events ...*Event) error
+}
+
+// LogSink writes the audit events to the klog.
+type LogSink struct{}
+
+var _ Sink = &LogSink{}
+
+func NewLogSink() *LogSink {
+	return &LogSink{}
+}
+
+func (s *LogSink) ProcessEvents(events ...*Event) error {
+	for _, evt := range events {
+		klog.InfoS("audit", "auditID", evt.AuditID, "stage", evt.Stage, "method", evt.Method,
+			"user", evt.User, "groups", evt.Groups, "clusterName", evt.ClusterName, "eventType", evt.EventType,
+			"eventID", evt.EventID, "code", evt.Code, "annotations", evt.Annotations)
+	}
+	return nil
+}
+
+// FileSinkOptions configures the file and rotation of a FileSink.
+type FileSinkOptions struct {
+	// Path is the path of the audit file.
+	Path string
+	// MaxSize is the maximum size in bytes of the audit file before it is rotated, defaults to 100MiB.
+	MaxSize int64
+	// MaxBackups is the maximum number of the rotated audit files to retain, defaults to 5.
+	MaxBackups int
+}
+
+// FileSink writes the audit events to a file as JSON lines. The file is rotated once it exceeds the max size,
+// the rotated files are named as <path>.1, <path>.2, ..., <path>.1 is the newest one.
+type FileSink struct {
+	sync.Mutex
+
+	options FileSinkOptions
+	file    *os.File
+	size    int64
+}
+
+var _ Sink = &FileSink{}
+
+// NewFileSink opens the audit file with the options, the events are appended to the existing file.
+func NewFileSink(options FileSinkOptions) (*FileSink, error) {
+	if len(options.Path) == 0 {
+		return nil, fmt.Errorf("the audit file path is required")
+	}
+	if options.MaxSize == 0 {
+		options.MaxSize = 100 * 1024 * 1024
+	}
+	if options.MaxBackups == 0 {
+		options.MaxBackups = 5
+	}
+
+	s := &FileSink{options: options}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *FileSink) ProcessEvents(events ...*Event) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for _, evt := range events {
+		data, err := json.Marshal(evt)
+		if err != nil {
+			return err
+		}
+		data = append(data, '\n')
+
+		if s.size > 0 && s.size+int64(len(data)) > s.options.MaxSize {
+			if err := s.rotate(); err != nil {
+				return err
+			}
+		}
+
+		n, err := s.file.Write(data)
+		s.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the audit file.
+func (s *FileSink) Close() error {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileSink) open() error {
+	file, err := os.OpenFile(s.options.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups, renames the current file to <path>.1 and opens a new file.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	for i := s.options.MaxBackups - 1; i > 0; i-- {
+		if err := os.Rename(s.backup(i), s.backup(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.options.Path, s.backup(1)); err != nil {
+		return err
+	}
+
+	return s.open()
+}
+
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.options.Path, i)
+}
+
+// defaultWebhookTimeout is the timeout to post a batch of the audit events if it is not specified.
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookSink posts the batches of the audit events to a webhook as a JSON list.
+type WebhookSink struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+var _ Sink = &WebhookSink{}
+
+// NewWebhookSink returns a WebhookSink that posts the audit events to the url with the client, the
+// http.DefaultClient is used if the client is nil, and the timeout defaults to 10s if it is not positive.
+func NewWebhookSink(url string, client *http.Client, timeout time.Duration) *WebhookSink {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+	return &WebhookSink{url: url, client: client, timeout: timeout}
+}
+
+func (s *WebhookSink) ProcessEvents(events ...*Event) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("the audit webhook %s responded with status %d", s.url, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	evt := &Event{AuditID: "id", Method: "/test", User: "test"}
+	data, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineSize := int64(len(data) + 1)
+
+	// each file holds two events
+	sink, err := NewFileSink(FileSinkOptions{Path: path, MaxSize: 2 * lineSize, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+
+	for i := 0; i < 7; i++ {
+		if err := sink.ProcessEvents(evt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 7 events are written as audit.log.2(2), audit.log.1(2) and audit.log(1), the oldest file is removed
+	expected := map[string]int{path: 1, path + ".1": 2, path + ".2": 2}
+	for file, lines := range expected {
+		if actual := countEvents(t, file); actual != lines {
+			t.Errorf("expected %d events in %s, but got %d", lines, file, actual)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected %s.3 is not retained, but got %v", path, err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	received := make(chan []*Event, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events := []*Event{}
+		if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if events[0].User == "rejected" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		received <- events
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, server.Client(), 5*time.Second)
+	if err := sink.ProcessEvents(&Event{AuditID: "1"}, &Event{AuditID: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	if events := <-received; len(events) != 2 || events[0].AuditID != "1" || events[1].AuditID != "2" {
+		t.Errorf("unexpected events %v", events)
+	}
+
+	if err := sink.ProcessEvents(&Event{User: "rejected"}); err == nil {
+		t.Errorf("expected error for the failed response, but got nil")
+	}
+
+	// the unset timeout defaults to 10s, so the events are still posted
+	sink = NewWebhookSink(server.URL, server.Client(), 0)
+	if sink.timeout != defaultWebhookTimeout {
+		t.Errorf("expected the default timeout %v, but got %v", defaultWebhookTimeout, sink.timeout)
+	}
+	if err := sink.ProcessEvents(&Event{AuditID: "3"}); err != nil {
+		t.Fatal(err)
+	}
+	if events := <-received; len(events) != 1 || events[0].AuditID != "3" {
+		t.Errorf("unexpected events %v", events)
+	}
+}
+
+func countEvents(t *testing.T, path string) int {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	count := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		evt := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), evt); err != nil {
+			t.Fatal(fmt.Errorf("invalid audit event %s: %v", scanner.Text(), err))
+		}
+		count++
+	}
+	return count
+}
@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/yaml"
+)
+
+// Level defines the amount of information recorded for a request.
+type Level string
+
+const (
+	// LevelNone disables the auditing of the request.
+	LevelNone Level = "None"
+	// LevelMetadata records the request metadata, e.g. the user, method, cluster and event type, but not the
+	// event data.
+	LevelMetadata Level = "Metadata"
+	// LevelRequest records the request metadata and the published event.
+	LevelRequest Level = "Request"
+)
+
+// Stage is the stage of the request when the audit event is recorded.
+type Stage string
+
+const (
+	// StageResponseStarted is recorded once a subscription is established, the subscription is long-running.
+	StageResponseStarted Stage = "ResponseStarted"
+	// StageResponseComplete is recorded once the request is completed.
+	StageResponseComplete Stage = "ResponseComplete"
+)
+
+// The annotation keys of the audit events.
+const (
+	// AnnotationSubscriptionID is the id of the subscription that is registered by the broker.
+	AnnotationSubscriptionID = "broker.open-cluster-management.io/subscription-id"
+	// AnnotationResourceID is the resource id of the published event.
+	AnnotationResourceID = "broker.open-cluster-management.io/resource-id"
+	// AnnotationAuthorizationDecision is the authorization decision of the request, allow or deny.
+	AnnotationAuthorizationDecision = "authorization.open-cluster-management.io/decision"
+	// AnnotationAuthorizationRule is the name of the policy rule that decides the request.
+	AnnotationAuthorizationRule = "authorization.open-cluster-management.io/rule"
+	// AnnotationAuthorizationReason is the reason why the request is denied.
+	AnnotationAuthorizationReason = "authorization.open-cluster-management.io/reason"
+)
+
+// Event is an audit record of a gRPC request, a request of the PublishStream has an audit event for each
+// published event.
+type Event struct {
+	Level     Level            `json:"level"`
+	AuditID   string           `json:"auditID"`
+	Stage     Stage            `json:"stage"`
+	Timestamp metav1.MicroTime `json:"timestamp"`
+	// Method is the full gRPC method of the request.
+	Method   string   `json:"method"`
+	User     string   `json:"user"`
+	Groups   []string `json:"groups,omitempty"`
+	SourceIP string   `json:"sourceIP,omitempty"`
+	// ClusterName, DataType and EventType are the attributes of the published event or the subscription.
+	ClusterName string `json:"clusterName,omitempty"`
+	DataType    string `json:"dataType,omitempty"`
+	EventType   string `json:"eventType,omitempty"`
+	EventID     string `json:"eventID,omitempty"`
+	// Code is the gRPC status code of the request, it is empty for the ResponseStarted stage.
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Annotations are the additional information of the request that is added by the request handlers,
+	// e.g. the authorization decision.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Request is the published event, it is only recorded at the Request level.
+	Request json.RawMessage `json:"request,omitempty"`
+}
+
+// PolicyRule maps the requests to an audit level, the rule matches a request if each of its non-empty
+// fields matches the request.
+type PolicyRule struct {
+	Level Level `json:"level"`
+	// Users are the user names that the rule matches.
+	Users []string `json:"users,omitempty"`
+	// UserGroups are the groups that the rule matches, a user matches if it is in one of the groups.
+	UserGroups []string `json:"userGroups,omitempty"`
+	// DataTypes are the cloudevents data types that the rule matches, e.g.
+	// io.open-cluster-management.works.v1alpha1.manifestbundles.
+	DataTypes []string `json:"dataTypes,omitempty"`
+}
+
+// Policy defines the audit level of the requests, the rules are evaluated in order, the first matched rule
+// sets the audit level, the request is not audited if no rule is matched.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// LoadPolicy loads the audit policy from a yaml or json file.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal audit policy file %s: %v", path, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid audit policy file %s: %v", path, err)
+	}
+	return policy, nil
+}
+
+// Validate validates the levels of the policy rules.
+func (p *Policy) Validate() error {
+	for i, rule := range p.Rules {
+		switch rule.Level {
+		case LevelNone, LevelMetadata, LevelRequest:
+		default:
+			return fmt.Errorf("unsupported level %q of rule %d", rule.Level, i)
+		}
+	}
+	return nil
+}
+
+// LevelFor returns the audit level of the request.
+func (p *Policy) LevelFor(user string, groups []string, dataType string) Level {
+	for _, rule := range p.Rules {
+		if len(rule.Users) != 0 && !sets.New(rule.Users...).Has(user) {
+			continue
+		}
+		if len(rule.UserGroups) != 0 && !sets.New(rule.UserGroups...).HasAny(groups...) {
+			continue
+		}
+		if len(rule.DataTypes) != 0 && !sets.New(rule.DataTypes...).Has(dataType) {
+			continue
+		}
+		return rule.Level
+	}
+	return LevelNone
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testPolicy = `
+rules:
+- level: None
+  users: ["system:health-checker"]
+- level: Request
+  userGroups: ["system:open-cluster-management:cluster1"]
+  dataTypes: ["io.open-cluster-management.works.v1alpha1.manifestbundles"]
+- level: Metadata
+  userGroups: ["system:open-cluster-management:cluster1", "sources"]
+`
+
+func TestPolicyLevelFor(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(testPolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := LoadPolicy(policyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name     string
+		user     string
+		groups   []string
+		dataType string
+		expected Level
+	}{
+		{
+			name:     "ignored user",
+			user:     "system:health-checker",
+			groups:   []string{"sources"},
+			expected: LevelNone,
+		},
+		{
+			name:     "agent publishes manifestbundles",
+			user:     "agent1",
+			groups:   []string{"system:open-cluster-management:cluster1"},
+			dataType: "io.open-cluster-management.works.v1alpha1.manifestbundles",
+			expected: LevelRequest,
+		},
+		{
+			name:     "agent publishes other data type",
+			user:     "agent1",
+			groups:   []string{"system:open-cluster-management:cluster1"},
+			dataType: "io.open-cluster-management.cluster.v1.clusters",
+			expected: LevelMetadata,
+		},
+		{
+			name:     "source publishes manifestbundles",
+			user:     "source1",
+			groups:   []string{"sources"},
+			dataType: "io.open-cluster-management.works.v1alpha1.manifestbundles",
+			expected: LevelMetadata,
+		},
+		{
+			name:     "no rule matched",
+			user:     "other",
+			expected: LevelNone,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if level := policy.LevelFor(c.user, c.groups, c.dataType); level != c.expected {
+				t.Errorf("expected level %s, but got %s", c.expected, level)
+			}
+		})
+	}
+}
+
+func TestLoadInvalidPolicy(t *testing.T) {
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if _, err := LoadPolicy(policyFile); err == nil {
+		t.Errorf("expected error for the missing policy file, but got nil")
+	}
+
+	if err := os.WriteFile(policyFile, []byte("rules: [{level: Response}]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(policyFile); err == nil {
+		t.Errorf("expected error for the unsupported level, but got nil")
+	}
+
+	if err := os.WriteFile(policyFile, []byte("rules: [{level: None, namespaces: [test]}]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(policyFile); err == nil {
+		t.Errorf("expected error for the unknown field, but got nil")
+	}
+}
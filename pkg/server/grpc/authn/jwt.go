@@ -0,0 +1,304 @@
+package authn
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+
+	"open-cluster-management.io/sdk-go/pkg/jose"
+)
+
+const (
+	defaultUsernameClaim = "sub"
+	defaultGroupsClaim   = "groups"
+	defaultClockSkew     = 30 * time.Second
+)
+
+// JWTAuthenticatorOptions configures the JWTAuthenticator.
+type JWTAuthenticatorOptions struct {
+	// Issuer is the required `iss` claim of the tokens.
+	Issuer string
+	// Audiences are the accepted audiences, the `aud` claim of the tokens must contain one of them.
+	Audiences []string
+	// Keys are the static public keys to verify the tokens, they are keyed by the key ID.
+	Keys map[string]crypto.PublicKey
+	// KeySetFile is the path of a JSON Web Key Set file to verify the tokens, the file is reloaded once
+	// it is changed, so the keys can be rotated without restarting the server.
+	KeySetFile string
+	// UsernameClaim is the claim to use as the user name, defaults to `sub`.
+	UsernameClaim string
+	// UsernamePrefix is prepended to the user name, e.g. `oidc:`, to avoid clashing with the other users.
+	UsernamePrefix string
+	// GroupsClaim is the claim to use as the user groups, defaults to `groups`. The claim can be a string
+	// or an array of strings.
+	GroupsClaim string
+	// GroupsPrefix is prepended to each group.
+	GroupsPrefix string
+	// ClockSkew is the leeway to check the `exp` and `nbf` claims, defaults to 30s.
+	ClockSkew time.Duration
+}
+
+// JWTAuthenticator authenticates the requests with the bearer JWT tokens, the tokens are validated locally
+// with the configured keys, so it does not depend on a kube-apiserver.
+type JWTAuthenticator struct {
+	opts       JWTAuthenticatorOptions
+	keySetFile *jose.KeySetFile
+	now        func() time.Time
+
+	mu sync.Mutex
+	// keySetFileErr is the last error to reload the key set file, it is logged once until the error is changed.
+	keySetFileErr string
+}
+
+var _ Authenticator = &JWTAuthenticator{}
+
+func NewJWTAuthenticator(opts JWTAuthenticatorOptions) (*JWTAuthenticator, error) {
+	if len(opts.Issuer) == 0 {
+		return nil, fmt.Errorf("the issuer is required")
+	}
+	if len(opts.Audiences) == 0 {
+		return nil, fmt.Errorf("at least one audience is required")
+	}
+	if len(opts.Keys) == 0 && len(opts.KeySetFile) == 0 {
+		return nil, fmt.Errorf("either keys or key set file is required")
+	}
+	for id, key := range opts.Keys {
+		if _, err := jose.AlgorithmForKey(key); err != nil {
+			return nil, fmt.Errorf("invalid key %q: %v", id, err)
+		}
+	}
+	if len(opts.UsernameClaim) == 0 {
+		opts.UsernameClaim = defaultUsernameClaim
+	}
+	if len(opts.GroupsClaim) == 0 {
+		opts.GroupsClaim = defaultGroupsClaim
+	}
+	if opts.ClockSkew == 0 {
+		opts.ClockSkew = defaultClockSkew
+	}
+
+	a := &JWTAuthenticator{opts: opts, now: time.Now}
+	if len(opts.KeySetFile) != 0 {
+		a.keySetFile = jose.NewKeySetFile(opts.KeySetFile)
+		if _, err := a.keySetFile.Keys(); err != nil {
+			return nil, fmt.Errorf("failed to load key set file %s: %v", opts.KeySetFile, err)
+		}
+	}
+	return a, nil
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context) (context.Context, error) {
+	// Extract the metadata from the context
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, status.Error(codes.InvalidArgument, "missing metadata")
+	}
+
+	// Extract the access token from the metadata
+	authorization, ok := md["authorization"]
+	if !ok || len(authorization) == 0 {
+		return ctx, status.Error(codes.Unauthenticated, "invalid token")
+	}
+
+	user, groups, err := a.authenticateToken(ctx, strings.TrimPrefix(authorization[0], "Bearer "))
+	if err != nil {
+		return ctx, status.Errorf(codes.Unauthenticated, "token not authenticated: %v", err)
+	}
+
+	return newContextWithIdentity(ctx, user, groups), nil
+}
+
+func (a *JWTAuthenticator) authenticateToken(ctx context.Context, token string) (string, []string, error) {
+	header, payload, signingInput, signature, err := jose.ParseCompact(token, nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := a.verifySignature(ctx, header, signingInput, signature); err != nil {
+		return "", nil, err
+	}
+
+	claims := map[string]any{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return "", nil, fmt.Errorf("failed to decode the claims: %v", err)
+	}
+
+	if err := a.validateClaims(claims); err != nil {
+		return "", nil, err
+	}
+
+	user, ok := claims[a.opts.UsernameClaim].(string)
+	if !ok || len(user) == 0 {
+		return "", nil, fmt.Errorf("the user name claim %q is not a non-empty string", a.opts.UsernameClaim)
+	}
+
+	groups, err := stringsClaim(claims, a.opts.GroupsClaim)
+	if err != nil {
+		return "", nil, err
+	}
+	for i := range groups {
+		groups[i] = a.opts.GroupsPrefix + groups[i]
+	}
+
+	return a.opts.UsernamePrefix + user, groups, nil
+}
+
+// verifySignature verifies the token signature with the key of the token key ID, the token without a key ID
+// is verified with each of the keys.
+func (a *JWTAuthenticator) verifySignature(ctx context.Context, header *jose.Header, signingInput, signature []byte) error {
+	keys, err := a.candidateKeys(ctx, header.KeyID)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, key := range keys {
+		if len(key.Algorithm) != 0 && key.Algorithm != header.Algorithm {
+			lastErr = fmt.Errorf("the algorithm %s does not match the key algorithm %s", header.Algorithm, key.Algorithm)
+			continue
+		}
+		if lastErr = jose.VerifySignature(header.Algorithm, key.Key, signingInput, signature); lastErr == nil {
+			return nil
+		}
+	}
+	if lastErr == nil {
+		return fmt.Errorf("no key is found to verify the token (kid=%q)", header.KeyID)
+	}
+	return lastErr
+}
+
+func (a *JWTAuthenticator) candidateKeys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	keys := []jose.JSONWebKey{}
+	for id, key := range a.opts.Keys {
+		if len(keyID) == 0 || id == keyID {
+			keys = append(keys, jose.JSONWebKey{KeyID: id, Key: key})
+		}
+	}
+
+	if a.keySetFile != nil {
+		// the keys that were loaded last time are used if the key set file cannot be reloaded
+		fileKeys, err := a.keySetFile.Keys()
+		if err != nil && len(fileKeys) == 0 {
+			return nil, err
+		}
+		a.recordKeySetFileError(ctx, err)
+		for _, key := range fileKeys {
+			if len(keyID) == 0 || key.KeyID == keyID {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// recordKeySetFileError logs the failure to reload the key set file when it is changed, and logs the recovery once
+// the key set file is reloaded, so a broken key rotation is noticed without flooding the logs.
+func (a *JWTAuthenticator) recordKeySetFileError(ctx context.Context, err error) {
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if errMsg == a.keySetFileErr {
+		return
+	}
+	a.keySetFileErr = errMsg
+
+	logger := klog.FromContext(ctx)
+	if err != nil {
+		logger.Error(err, "failed to reload the key set file, the keys that were loaded last time are used",
+			"keySetFile", a.opts.KeySetFile)
+		return
+	}
+	logger.Info("the key set file is reloaded", "keySetFile", a.opts.KeySetFile)
+}
+
+func (a *JWTAuthenticator) validateClaims(claims map[string]any) error {
+	if issuer, _ := claims["iss"].(string); issuer != a.opts.Issuer {
+		return fmt.Errorf("unexpected issuer %q", issuer)
+	}
+
+	audiences, err := stringsClaim(claims, "aud")
+	if err != nil {
+		return err
+	}
+	if !sets.New(a.opts.Audiences...).HasAny(audiences...) {
+		return fmt.Errorf("unexpected audiences %v", audiences)
+	}
+
+	now := a.now()
+	expiry, ok, err := timeClaim(claims, "exp")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("the exp claim is required")
+	}
+	if now.After(expiry.Add(a.opts.ClockSkew)) {
+		return fmt.Errorf("the token is expired at %s", expiry.UTC().Format(time.RFC3339))
+	}
+
+	notBefore, ok, err := timeClaim(claims, "nbf")
+	if err != nil {
+		return err
+	}
+	if ok && now.Add(a.opts.ClockSkew).Before(notBefore) {
+		return fmt.Errorf("the token is not valid before %s", notBefore.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// stringsClaim returns the claim that is either a string or an array of strings.
+func stringsClaim(claims map[string]any, name string) ([]string, error) {
+	switch value := claims[name].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("the %s claim is not an array of strings", name)
+			}
+			values = append(values, s)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("the %s claim is neither a string nor an array of strings", name)
+	}
+}
+
+// timeClaim returns the claim of the NumericDate (seconds since the epoch).
+func timeClaim(claims map[string]any, name string) (time.Time, bool, error) {
+	value, ok := claims[name]
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("the %s claim is not a number", name)
+	}
+	seconds, err := number.Float64()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s claim: %v", name, err)
+	}
+	return time.Unix(int64(seconds), 0), true, nil
+}
@@ -0,0 +1,298 @@
+package authn
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"open-cluster-management.io/sdk-go/pkg/jose"
+)
+
+func TestJWTAuthenticator(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"iss":    "https://issuer",
+			"aud":    []string{"other", "grpc"},
+			"sub":    "agent1",
+			"groups": []string{"agents"},
+			"exp":    now.Add(time.Hour).Unix(),
+			"nbf":    now.Add(-time.Minute).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		keyID          string
+		signer         crypto.Signer
+		claims         func() map[string]any
+		expectedUser   string
+		expectedGroups []string
+		valid          bool
+	}{
+		{
+			name:           "valid token",
+			keyID:          "ec",
+			signer:         ecKey,
+			claims:         validClaims,
+			expectedUser:   "oidc:agent1",
+			expectedGroups: []string{"oidc:agents"},
+			valid:          true,
+		},
+		{
+			name:   "valid token without key id",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				claims["aud"] = "grpc"
+				claims["groups"] = "agents"
+				delete(claims, "nbf")
+				return claims
+			},
+			expectedUser:   "oidc:agent1",
+			expectedGroups: []string{"oidc:agents"},
+			valid:          true,
+		},
+		{
+			name:   "token signed by unknown key",
+			keyID:  "ec",
+			signer: otherKey,
+			claims: validClaims,
+		},
+		{
+			name:   "unexpected issuer",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				claims["iss"] = "https://other"
+				return claims
+			},
+		},
+		{
+			name:   "unexpected audience",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return claims
+			},
+		},
+		{
+			name:   "expired token",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				claims["exp"] = now.Add(-time.Minute).Unix()
+				return claims
+			},
+		},
+		{
+			name:   "token without expiration",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				delete(claims, "exp")
+				return claims
+			},
+		},
+		{
+			name:   "token is not valid yet",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				claims["nbf"] = now.Add(time.Minute).Unix()
+				return claims
+			},
+		},
+		{
+			name:   "token without user name",
+			keyID:  "ec",
+			signer: ecKey,
+			claims: func() map[string]any {
+				claims := validClaims()
+				delete(claims, "sub")
+				return claims
+			},
+		},
+	}
+
+	authenticator, err := NewJWTAuthenticator(JWTAuthenticatorOptions{
+		Issuer:         "https://issuer",
+		Audiences:      []string{"grpc"},
+		Keys:           map[string]crypto.PublicKey{"ec": ecKey.Public()},
+		UsernamePrefix: "oidc:",
+		GroupsPrefix:   "oidc:",
+		ClockSkew:      time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, err := authenticator.Authenticate(newTokenContext(t, test.keyID, test.signer, test.claims()))
+			if !test.valid {
+				if err == nil {
+					t.Errorf("authenticator.Authenticate() = %v, wanted error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("authenticator.Authenticate() = %v", err)
+			}
+			if user := ctx.Value(ContextUserKey); user != test.expectedUser {
+				t.Errorf("expected user %q, but got %v", test.expectedUser, user)
+			}
+			if groups := ctx.Value(ContextGroupsKey); !equality.Semantic.DeepEqual(groups, test.expectedGroups) {
+				t.Errorf("expected groups %v, but got %v", test.expectedGroups, groups)
+			}
+		})
+	}
+}
+
+func TestJWTAuthenticatorKeySetFile(t *testing.T) {
+	keySetFile := filepath.Join(t.TempDir(), "jwks.json")
+	if _, err := NewJWTAuthenticator(JWTAuthenticatorOptions{
+		Issuer:     "https://issuer",
+		Audiences:  []string{"grpc"},
+		KeySetFile: keySetFile,
+	}); err == nil {
+		t.Errorf("expected error, but got nil")
+	}
+
+	key1, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeKeySet(t, keySetFile, map[string]*ecdsa.PrivateKey{"key1": key1}, time.Now())
+
+	authenticator, err := NewJWTAuthenticator(JWTAuthenticatorOptions{
+		Issuer:     "https://issuer",
+		Audiences:  []string{"grpc"},
+		KeySetFile: keySetFile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	claims := map[string]any{
+		"iss": "https://issuer",
+		"aud": "grpc",
+		"sub": "agent1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	if _, err := authenticator.Authenticate(newTokenContext(t, "key1", key1, claims)); err != nil {
+		t.Errorf("authenticator.Authenticate() = %v", err)
+	}
+	if _, err := authenticator.Authenticate(newTokenContext(t, "key2", key2, claims)); err == nil {
+		t.Errorf("expected the token signed by key2 is not authenticated")
+	}
+
+	// the keys that were loaded last time are used if the key set file is broken, and the failure is recorded
+	if err := os.WriteFile(keySetFile, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(keySetFile, time.Now().Add(30*time.Second), time.Now().Add(30*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authenticator.Authenticate(newTokenContext(t, "key1", key1, claims)); err != nil {
+		t.Errorf("authenticator.Authenticate() = %v", err)
+	}
+	if len(authenticator.keySetFileErr) == 0 {
+		t.Errorf("expected the failure to reload the key set file is recorded")
+	}
+
+	// the key set file is reloaded after the keys are rotated
+	writeKeySet(t, keySetFile, map[string]*ecdsa.PrivateKey{"key2": key2}, time.Now().Add(time.Minute))
+	if _, err := authenticator.Authenticate(newTokenContext(t, "key2", key2, claims)); err != nil {
+		t.Errorf("authenticator.Authenticate() = %v", err)
+	}
+	if _, err := authenticator.Authenticate(newTokenContext(t, "key1", key1, claims)); err == nil {
+		t.Errorf("expected the token signed by the removed key1 is not authenticated")
+	}
+	if len(authenticator.keySetFileErr) != 0 {
+		t.Errorf("expected the recorded failure is cleared once the key set file is reloaded")
+	}
+}
+
+func TestJWTAuthenticatorWeakRSAKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJWTAuthenticator(JWTAuthenticatorOptions{
+		Issuer:    "https://issuer",
+		Audiences: []string{"grpc"},
+		Keys:      map[string]crypto.PublicKey{"key1": key.Public()},
+	}); err == nil {
+		t.Errorf("expected error for the 1024 bits RSA key")
+	}
+}
+
+func newTokenContext(t *testing.T, keyID string, signer crypto.Signer, claims map[string]any) context.Context {
+	alg, err := jose.AlgorithmForKey(signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jose.Sign(jose.Header{Algorithm: alg, KeyID: keyID, Type: "JWT"}, payload, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.MD{"authorization": []string{"Bearer " + token}})
+}
+
+func writeKeySet(t *testing.T, path string, keys map[string]*ecdsa.PrivateKey, modTime time.Time) {
+	encode := base64.RawURLEncoding.EncodeToString
+	jwks := []map[string]string{}
+	for kid, key := range keys {
+		jwks = append(jwks, map[string]string{
+			"kty": "EC", "kid": kid, "crv": "P-256", "x": encode(key.X.Bytes()), "y": encode(key.Y.Bytes()),
+		})
+	}
+	data, err := json.Marshal(map[string]any{"keys": jwks})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
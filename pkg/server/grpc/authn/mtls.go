@@ -2,6 +2,9 @@ package authn
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"regexp"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -9,11 +12,89 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// IdentitySource is the field of the client certificate that an identity rule matches.
+type IdentitySource string
+
+const (
+	// IdentitySourceCommonName matches the common name of the certificate subject.
+	IdentitySourceCommonName IdentitySource = "CommonName"
+	// IdentitySourceURISAN matches the URI subject alternative names, e.g. the SPIFFE ID.
+	IdentitySourceURISAN IdentitySource = "URISAN"
+	// IdentitySourceDNSSAN matches the DNS subject alternative names.
+	IdentitySourceDNSSAN IdentitySource = "DNSSAN"
+)
+
+// IdentityRule maps a field of the client certificate to the user and groups. The Pattern is a regular
+// expression, and the User and Groups are templates that reference its submatches, e.g. $1 or ${cluster}.
+//
+// For example, the rule below maps the SPIFFE ID spiffe://hub.example.com/cluster/cluster1/agent to the user
+// agent:cluster1 in the group system:open-cluster-management:cluster1.
+//
+//	source: URISAN
+//	pattern: ^spiffe://hub\.example\.com/cluster/(?P<cluster>[a-z0-9-]+)/agent$
+//	user: agent:${cluster}
+//	groups: ["system:open-cluster-management:${cluster}"]
+type IdentityRule struct {
+	Source  IdentitySource `json:"source" yaml:"source"`
+	Pattern string         `json:"pattern" yaml:"pattern"`
+	User    string         `json:"user" yaml:"user"`
+	Groups  []string       `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+type compiledIdentityRule struct {
+	IdentityRule
+	pattern *regexp.Regexp
+}
+
+// IdentityMapper maps the identity of the client certificates with the identity rules.
+type IdentityMapper struct {
+	rules []compiledIdentityRule
+}
+
+// NewIdentityMapper compiles the identity rules, the rules are evaluated in order, the first rule that matches
+// the certificate decides its user and groups.
+func NewIdentityMapper(rules ...IdentityRule) (*IdentityMapper, error) {
+	m := &IdentityMapper{}
+	for i, rule := range rules {
+		switch rule.Source {
+		case IdentitySourceCommonName, IdentitySourceURISAN, IdentitySourceDNSSAN:
+		default:
+			return nil, fmt.Errorf("unsupported source %q of identity rule %d", rule.Source, i)
+		}
+		if len(rule.User) == 0 {
+			return nil, fmt.Errorf("the user of identity rule %d is required", i)
+		}
+
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of identity rule %d: %v", i, err)
+		}
+		m.rules = append(m.rules, compiledIdentityRule{IdentityRule: rule, pattern: pattern})
+	}
+	return m, nil
+}
+
 type MtlsAuthenticator struct {
+	identityMapper *IdentityMapper
 }
 
-func NewMtlsAuthenticator() *MtlsAuthenticator {
-	return &MtlsAuthenticator{}
+type MtlsAuthenticatorOption func(*MtlsAuthenticator)
+
+// WithIdentityMapper maps the identity of the client certificates with the mapper, the certificate that is not
+// matched by any rule is rejected. Without the mapper, the identity is the common name and organizations of the
+// certificate subject.
+func WithIdentityMapper(mapper *IdentityMapper) MtlsAuthenticatorOption {
+	return func(a *MtlsAuthenticator) {
+		a.identityMapper = mapper
+	}
+}
+
+func NewMtlsAuthenticator(opts ...MtlsAuthenticatorOption) *MtlsAuthenticator {
+	a := &MtlsAuthenticator{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func (a *MtlsAuthenticator) Authenticate(ctx context.Context) (context.Context, error) {
@@ -31,12 +112,52 @@ func (a *MtlsAuthenticator) Authenticate(ctx context.Context) (context.Context,
 		return ctx, status.Error(codes.Unauthenticated, "could not verify peer certificate")
 	}
 
-	if tlsAuth.State.VerifiedChains[0][0] == nil {
+	cert := tlsAuth.State.VerifiedChains[0][0]
+	if cert == nil {
 		return ctx, status.Error(codes.Unauthenticated, "could not verify peer certificate")
 	}
 
-	user := tlsAuth.State.VerifiedChains[0][0].Subject.CommonName
-	groups := tlsAuth.State.VerifiedChains[0][0].Subject.Organization
-	newCtx := newContextWithIdentity(ctx, user, groups)
-	return newCtx, nil
+	if a.identityMapper == nil {
+		return newContextWithIdentity(ctx, cert.Subject.CommonName, cert.Subject.Organization), nil
+	}
+
+	user, groups, ok := a.identityMapper.Map(cert)
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "no identity rule matches the peer certificate")
+	}
+	return newContextWithIdentity(ctx, user, groups), nil
+}
+
+// Map returns the user and groups of the first rule that matches the certificate.
+func (m *IdentityMapper) Map(cert *x509.Certificate) (string, []string, bool) {
+	for _, rule := range m.rules {
+		var values []string
+		switch rule.Source {
+		case IdentitySourceCommonName:
+			values = []string{cert.Subject.CommonName}
+		case IdentitySourceURISAN:
+			for _, uri := range cert.URIs {
+				values = append(values, uri.String())
+			}
+		case IdentitySourceDNSSAN:
+			values = cert.DNSNames
+		}
+
+		for _, value := range values {
+			match := rule.pattern.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+
+			expand := func(template string) string {
+				return string(rule.pattern.ExpandString(nil, template, value, match))
+			}
+			groups := make([]string, 0, len(rule.Groups))
+			for _, group := range rule.Groups {
+				groups = append(groups, expand(group))
+			}
+			return expand(rule.User), groups, true
+		}
+	}
+	return "", nil, false
 }
@@ -5,10 +5,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"net/url"
 	"testing"
 
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 func TestMtlsAuthenticator(t *testing.T) {
@@ -64,3 +66,88 @@ func TestMtlsAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestMtlsAuthenticatorIdentityMapper(t *testing.T) {
+	mapper, err := NewIdentityMapper(
+		IdentityRule{
+			Source:  IdentitySourceURISAN,
+			Pattern: `^spiffe://hub\.example\.com/cluster/(?P<cluster>[a-z0-9-]+)/agent$`,
+			User:    "agent:${cluster}",
+			Groups:  []string{"system:open-cluster-management:${cluster}"},
+		},
+		IdentityRule{
+			Source:  IdentitySourceDNSSAN,
+			Pattern: `^([a-z0-9-]+)\.sources\.example\.com$`,
+			User:    "source:$1",
+			Groups:  []string{"sources"},
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spiffeID, err := url.Parse("spiffe://hub.example.com/cluster/cluster1/agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		cert           *x509.Certificate
+		expectedUser   string
+		expectedGroups []string
+		valid          bool
+	}{
+		{
+			name:           "spiffe id",
+			cert:           &x509.Certificate{Subject: pkix.Name{CommonName: "ignored"}, URIs: []*url.URL{spiffeID}},
+			expectedUser:   "agent:cluster1",
+			expectedGroups: []string{"system:open-cluster-management:cluster1"},
+			valid:          true,
+		},
+		{
+			name:           "dns san",
+			cert:           &x509.Certificate{DNSNames: []string{"localhost", "maestro.sources.example.com"}},
+			expectedUser:   "source:maestro",
+			expectedGroups: []string{"sources"},
+			valid:          true,
+		},
+		{
+			name: "no rule matched",
+			cert: &x509.Certificate{Subject: pkix.Name{CommonName: "agent", Organization: []string{"agents"}}},
+		},
+	}
+
+	authenticator := NewMtlsAuthenticator(WithIdentityMapper(mapper))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+				State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{test.cert}}},
+			}})
+			ctx, err := authenticator.Authenticate(ctx)
+			if !test.valid {
+				if err == nil {
+					t.Errorf("authenticator.Authenticate() = %v, wanted error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("authenticator.Authenticate() = %v", err)
+			}
+			if user := ctx.Value(ContextUserKey); user != test.expectedUser {
+				t.Errorf("expected user %q, but got %v", test.expectedUser, user)
+			}
+			if groups := ctx.Value(ContextGroupsKey); !equality.Semantic.DeepEqual(groups, test.expectedGroups) {
+				t.Errorf("expected groups %v, but got %v", test.expectedGroups, groups)
+			}
+		})
+	}
+
+	if _, err := NewIdentityMapper(IdentityRule{Source: "Email", Pattern: ".*", User: "test"}); err == nil {
+		t.Errorf("expected error for the unsupported source")
+	}
+	if _, err := NewIdentityMapper(IdentityRule{Source: IdentitySourceCommonName, Pattern: "(", User: "test"}); err == nil {
+		t.Errorf("expected error for the invalid pattern")
+	}
+}
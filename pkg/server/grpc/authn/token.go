@@ -2,24 +2,89 @@ package authn
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"strings"
+	"time"
 
+	"golang.org/x/sync/singleflight"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+
+	"open-cluster-management.io/sdk-go/pkg/jose"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/metrics"
 )
 
+// TokenReviewCacheOptions configures the cache of the TokenReview results.
+type TokenReviewCacheOptions struct {
+	// MaxSize is the maximum number of the cached tokens, the least recently used token is evicted once
+	// the cache is full.
+	MaxSize int
+	// SuccessTTL is the duration to cache an authenticated token, a token is not cached beyond its expiration.
+	SuccessTTL time.Duration
+	// FailureTTL is the duration to cache an unauthenticated token, the failures are not cached if it is zero.
+	FailureTTL time.Duration
+}
+
+// NewTokenReviewCacheOptions returns the default TokenReviewCacheOptions.
+func NewTokenReviewCacheOptions() TokenReviewCacheOptions {
+	return TokenReviewCacheOptions{
+		MaxSize:    10000,
+		SuccessTTL: 2 * time.Minute,
+		FailureTTL: 10 * time.Second,
+	}
+}
+
+// TokenAuthenticatorOption configures the TokenAuthenticator.
+type TokenAuthenticatorOption func(*TokenAuthenticator)
+
+// WithTokenReviewCache caches the TokenReview results, so the kube-apiserver is not requested for each RPC.
+func WithTokenReviewCache(opts TokenReviewCacheOptions) TokenAuthenticatorOption {
+	return func(t *TokenAuthenticator) {
+		t.cacheOpts = opts
+		t.cache = cache.NewLRUExpireCache(opts.MaxSize)
+	}
+}
+
+// WithAudiences requires the token is issued for at least one of the given audiences.
+func WithAudiences(audiences ...string) TokenAuthenticatorOption {
+	return func(t *TokenAuthenticator) {
+		t.audiences = audiences
+	}
+}
+
 type TokenAuthenticator struct {
-	client kubernetes.Interface
+	client    kubernetes.Interface
+	audiences []string
+
+	cacheOpts TokenReviewCacheOptions
+	cache     *cache.LRUExpireCache
+	// inflight deduplicates the concurrent reviews of the same token.
+	inflight singleflight.Group
 }
 
 var _ Authenticator = &TokenAuthenticator{}
 
-func NewTokenAuthenticator(client kubernetes.Interface) *TokenAuthenticator {
-	return &TokenAuthenticator{client: client}
+func NewTokenAuthenticator(client kubernetes.Interface, opts ...TokenAuthenticatorOption) *TokenAuthenticator {
+	t := &TokenAuthenticator{client: client}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// reviewResult is the cached result of a TokenReview.
+type reviewResult struct {
+	authenticated bool
+	username      string
+	groups        []string
 }
 
 func (t *TokenAuthenticator) Authenticate(ctx context.Context) (context.Context, error) {
@@ -36,19 +101,109 @@ func (t *TokenAuthenticator) Authenticate(ctx context.Context) (context.Context,
 	}
 
 	token := strings.TrimPrefix(authorization[0], "Bearer ")
+	result, err := t.reviewWithCache(ctx, token)
+	if err != nil {
+		return ctx, err
+	}
+
+	if !result.authenticated {
+		return ctx, status.Error(codes.Unauthenticated, "token not authenticated")
+	}
+
+	newCtx := newContextWithIdentity(ctx, result.username, result.groups)
+	return newCtx, nil
+}
+
+func (t *TokenAuthenticator) reviewWithCache(ctx context.Context, token string) (*reviewResult, error) {
+	if t.cache == nil {
+		return t.review(ctx, token)
+	}
+
+	key := tokenHash(token)
+	if cached, ok := t.cache.Get(key); ok {
+		metrics.IncreaseAuthCacheRequests(metrics.TokenReviewCache, metrics.CacheHit)
+		return cached.(*reviewResult), nil
+	}
+	metrics.IncreaseAuthCacheRequests(metrics.TokenReviewCache, metrics.CacheMiss)
+
+	resultCh := t.inflight.DoChan(key, func() (any, error) {
+		// the review is shared by the concurrent requests, so it is not canceled with the first request.
+		result, err := t.review(context.WithoutCancel(ctx), token)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := t.cacheOpts.FailureTTL
+		if result.authenticated {
+			ttl = t.cacheOpts.SuccessTTL
+			if expiry, ok := tokenExpiry(token); ok && time.Until(expiry) < ttl {
+				ttl = time.Until(expiry)
+			}
+		}
+		if ttl > 0 {
+			t.cache.Add(key, result, ttl)
+		}
+		return result, nil
+	})
+
+	select {
+	case r := <-resultCh:
+		if r.Err != nil {
+			return nil, r.Err
+		}
+		return r.Val.(*reviewResult), nil
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func (t *TokenAuthenticator) review(ctx context.Context, token string) (*reviewResult, error) {
 	tr, err := t.client.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
 		Spec: authenticationv1.TokenReviewSpec{
-			Token: token,
+			Token:     token,
+			Audiences: t.audiences,
 		},
 	}, metav1.CreateOptions{})
 	if err != nil {
-		return ctx, err
+		return nil, err
 	}
 
 	if !tr.Status.Authenticated {
-		return ctx, status.Error(codes.Unauthenticated, "token not authenticated")
+		return &reviewResult{}, nil
 	}
 
-	newCtx := newContextWithIdentity(ctx, tr.Status.User.Username, tr.Status.User.Groups)
-	return newCtx, nil
+	// the token must be issued for one of the required audiences, the audiences of the token are the
+	// required audiences if the token authenticator of the kube-apiserver does not return them.
+	if len(t.audiences) != 0 && len(tr.Status.Audiences) != 0 &&
+		!sets.New(t.audiences...).HasAny(tr.Status.Audiences...) {
+		return &reviewResult{}, nil
+	}
+
+	return &reviewResult{
+		authenticated: true,
+		username:      tr.Status.User.Username,
+		groups:        tr.Status.User.Groups,
+	}, nil
+}
+
+// tokenHash returns the hash of the token, so the tokens are not kept in the cache.
+func tokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// tokenExpiry returns the expiration of a JWT token, the token signature was verified by the TokenReview.
+func tokenExpiry(token string) (time.Time, bool) {
+	_, payload, _, _, err := jose.ParseCompact(token, nil)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	claims := struct {
+		Expiry int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Expiry == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Expiry, 0), true
 }
@@ -2,13 +2,19 @@ package authn
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
 	"google.golang.org/grpc/metadata"
 	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
 	clienttesting "k8s.io/client-go/testing"
-	"testing"
 )
 
 func TestTokenAuthenticator(t *testing.T) {
@@ -71,3 +77,147 @@ func TestTokenAuthenticator(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenAuthenticatorCache(t *testing.T) {
+	expiringToken := newJWT(time.Now().Add(time.Second))
+
+	tests := []struct {
+		name            string
+		token           string
+		audiences       []string
+		reviewAudiences []string
+		authenticated   bool
+		valid           bool
+		// wait is the duration between the two authentications of the token
+		wait            time.Duration
+		expectedReviews int
+	}{
+		{
+			name:            "authenticated token is cached",
+			token:           "foo",
+			authenticated:   true,
+			valid:           true,
+			expectedReviews: 1,
+		},
+		{
+			name:            "unauthenticated token is cached",
+			token:           "foo",
+			valid:           false,
+			expectedReviews: 1,
+		},
+		{
+			name:            "token is not cached beyond its expiration",
+			token:           expiringToken,
+			authenticated:   true,
+			valid:           true,
+			wait:            2 * time.Second,
+			expectedReviews: 2,
+		},
+		{
+			name:            "token audience matches",
+			token:           "foo",
+			audiences:       []string{"grpc", "test"},
+			reviewAudiences: []string{"test"},
+			authenticated:   true,
+			valid:           true,
+			expectedReviews: 1,
+		},
+		{
+			name:            "token audience does not match",
+			token:           "foo",
+			audiences:       []string{"grpc"},
+			reviewAudiences: []string{"test"},
+			authenticated:   true,
+			valid:           false,
+			expectedReviews: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var reviews atomic.Int32
+			client := fake.NewClientset()
+			client.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+				reviews.Add(1)
+				tr := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+				if !equality.Semantic.DeepEqual(tr.Spec.Audiences, test.audiences) {
+					return true, nil, fmt.Errorf("unexpected audiences %v", tr.Spec.Audiences)
+				}
+				tr.Status = authenticationv1.TokenReviewStatus{
+					Authenticated: test.authenticated,
+					Audiences:     test.reviewAudiences,
+				}
+				return true, tr, nil
+			})
+
+			authenticator := NewTokenAuthenticator(client,
+				WithAudiences(test.audiences...),
+				WithTokenReviewCache(TokenReviewCacheOptions{
+					MaxSize:    10,
+					SuccessTTL: time.Minute,
+					FailureTTL: time.Minute,
+				}))
+			ctx := metadata.NewIncomingContext(context.Background(),
+				metadata.MD{"authorization": []string{"Bearer " + test.token}})
+
+			for i := 0; i < 2; i++ {
+				if i == 1 {
+					time.Sleep(test.wait)
+				}
+				_, err := authenticator.Authenticate(ctx)
+				if test.valid && err != nil {
+					t.Errorf("authenticator.Authenticate() = %v", err)
+				}
+				if !test.valid && err == nil {
+					t.Errorf("authenticator.Authenticate() = %v, wanted error", err)
+				}
+			}
+
+			if int(reviews.Load()) != test.expectedReviews {
+				t.Errorf("expected %d reviews, but got %d", test.expectedReviews, reviews.Load())
+			}
+		})
+	}
+}
+
+func TestTokenAuthenticatorConcurrentReviews(t *testing.T) {
+	var reviews atomic.Int32
+	release := make(chan struct{})
+	client := fake.NewClientset()
+	client.PrependReactor("create", "tokenreviews", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+		reviews.Add(1)
+		<-release
+		tr := action.(clienttesting.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		tr.Status = authenticationv1.TokenReviewStatus{Authenticated: true}
+		return true, tr, nil
+	})
+
+	authenticator := NewTokenAuthenticator(client, WithTokenReviewCache(NewTokenReviewCacheOptions()))
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{"authorization": []string{"Bearer foo"}})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := authenticator.Authenticate(ctx); err != nil {
+				t.Errorf("authenticator.Authenticate() = %v", err)
+			}
+		}()
+	}
+
+	// wait for the requests to wait on the review
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if reviews.Load() != 1 {
+		t.Errorf("expected the concurrent reviews are deduplicated, but got %d reviews", reviews.Load())
+	}
+}
+
+func newJWT(expiry time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiry.Unix())))
+	return header + "." + payload + ".c2ln"
+}
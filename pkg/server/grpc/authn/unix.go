@@ -0,0 +1,73 @@
+package authn
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnixPeerInfo is the auth info of a connection on a Unix domain socket listener, it is the credentials of the
+// peer process that are read from the socket with SO_PEERCRED.
+type UnixPeerInfo struct {
+	credentials.CommonAuthInfo
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+// AuthType returns the type of UnixPeerInfo as a string.
+func (UnixPeerInfo) AuthType() string {
+	return "unix"
+}
+
+type unixIdentity struct {
+	user   string
+	groups []string
+}
+
+// UnixPeerAuthenticator authenticates the requests on a Unix domain socket listener with the user id and group id of
+// the peer process. Which processes are able to connect is decided by the file permission of the socket.
+type UnixPeerAuthenticator struct {
+	users map[uint32]unixIdentity
+}
+
+type UnixPeerAuthenticatorOption func(*UnixPeerAuthenticator)
+
+// WithUnixUser maps the user id of the peer process to the user and groups. Without the mapping, the user is
+// system:unix:uid:<uid> and the group is system:unix:gid:<gid>.
+func WithUnixUser(uid uint32, user string, groups ...string) UnixPeerAuthenticatorOption {
+	return func(a *UnixPeerAuthenticator) {
+		a.users[uid] = unixIdentity{user: user, groups: groups}
+	}
+}
+
+func NewUnixPeerAuthenticator(opts ...UnixPeerAuthenticatorOption) *UnixPeerAuthenticator {
+	a := &UnixPeerAuthenticator{users: map[uint32]unixIdentity{}}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+func (a *UnixPeerAuthenticator) Authenticate(ctx context.Context) (context.Context, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "no peer found")
+	}
+
+	peerInfo, ok := p.AuthInfo.(UnixPeerInfo)
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, "unexpected peer transport credentials")
+	}
+
+	if identity, ok := a.users[peerInfo.UID]; ok {
+		return newContextWithIdentity(ctx, identity.user, identity.groups), nil
+	}
+	return newContextWithIdentity(ctx,
+		fmt.Sprintf("system:unix:uid:%d", peerInfo.UID),
+		[]string{fmt.Sprintf("system:unix:gid:%d", peerInfo.GID)}), nil
+}
@@ -0,0 +1,63 @@
+package authn
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func TestUnixPeerAuthenticator(t *testing.T) {
+	tests := []struct {
+		name           string
+		authInfo       credentials.AuthInfo
+		expectedUser   string
+		expectedGroups []string
+		valid          bool
+	}{
+		{
+			name:     "not a unix peer",
+			authInfo: credentials.TLSInfo{},
+			valid:    false,
+		},
+		{
+			name:           "mapped user",
+			authInfo:       UnixPeerInfo{UID: 1000, GID: 1000},
+			expectedUser:   "sidecar",
+			expectedGroups: []string{"system:sidecars"},
+			valid:          true,
+		},
+		{
+			name:           "unmapped user",
+			authInfo:       UnixPeerInfo{UID: 1001, GID: 100},
+			expectedUser:   "system:unix:uid:1001",
+			expectedGroups: []string{"system:unix:gid:100"},
+			valid:          true,
+		},
+	}
+
+	authenticator := NewUnixPeerAuthenticator(WithUnixUser(1000, "sidecar", "system:sidecars"))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: test.authInfo})
+			ctx, err := authenticator.Authenticate(ctx)
+			if !test.valid {
+				if err == nil {
+					t.Errorf("authenticator.Authenticate() = %v, wanted error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("authenticator.Authenticate() = %v", err)
+			}
+
+			user := ctx.Value(ContextUserKey).(string)
+			groups := ctx.Value(ContextGroupsKey).([]string)
+			if user != test.expectedUser || !equality.Semantic.DeepEqual(groups, test.expectedGroups) {
+				t.Errorf("expected %s %v, but got %s %v", test.expectedUser, test.expectedGroups, user, groups)
+			}
+		})
+	}
+}
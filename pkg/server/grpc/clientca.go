@@ -0,0 +1,243 @@
+package grpc
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// dynamicClientCA is the client CA bundle and the optional certificate revocation lists, the files are reloaded
+// once they are changed, so the client CAs can be rotated and the client certificates can be revoked without
+// restarting the server. If the changed files are invalid, the last loaded ones are kept.
+type dynamicClientCA struct {
+	caFile  string
+	crlFile string
+	now     func() time.Time
+
+	mu       sync.Mutex
+	caState  fileState
+	crlState fileState
+	pool     *x509.CertPool
+	// revoked is the revocation lists, keyed by the raw subject of their issuer
+	revoked map[string]*revocationList
+}
+
+// revocationList is the serial numbers of the revoked certificates of an issuer, and the time that the issuer
+// publishes its next revocation list.
+type revocationList struct {
+	serials    sets.Set[string]
+	nextUpdate time.Time
+}
+
+// stale returns true if the next revocation list of the issuer should have been published, a stale revocation list
+// is never trusted, so the clients of the issuer are rejected until the revocation list file is updated.
+func (l *revocationList) stale(now time.Time) bool {
+	return !l.nextUpdate.IsZero() && now.After(l.nextUpdate)
+}
+
+// fileState is the modification time and size of a loaded file.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+func newDynamicClientCA(caFile, crlFile string) (*dynamicClientCA, error) {
+	d := &dynamicClientCA{caFile: caFile, crlFile: crlFile, now: time.Now}
+	changed, err := d.changed()
+	if err != nil {
+		return nil, err
+	}
+	if err := d.reload(changed); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// GetConfigForClient returns the config with the current client CAs for each TLS handshake.
+func (d *dynamicClientCA) GetConfigForClient(base *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		pool, _ := d.current()
+
+		config := base.Clone()
+		config.ClientCAs = pool
+		return config, nil
+	}
+}
+
+// VerifyConnection rejects the client certificate chains that contain a revoked certificate or a certificate whose
+// issuer has a stale revocation list. Unlike the VerifyPeerCertificate, it is called for the resumed TLS sessions as
+// well, so a revoked client is not able to reconnect with a session ticket.
+func (d *dynamicClientCA) VerifyConnection(cs tls.ConnectionState) error {
+	_, revoked := d.current()
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	now := d.now()
+	for _, chain := range cs.VerifiedChains {
+		for i := 0; i < len(chain)-1; i++ {
+			crl, ok := revoked[string(chain[i+1].RawSubject)]
+			if !ok {
+				continue
+			}
+			if crl.stale(now) {
+				return fmt.Errorf("the client revocation list of %q is stale since %s",
+					chain[i+1].Subject.String(), crl.nextUpdate.Format(time.RFC3339))
+			}
+			if crl.serials.Has(chain[i].SerialNumber.String()) {
+				return fmt.Errorf("certificate %q with serial number %s is revoked",
+					chain[i].Subject.String(), chain[i].SerialNumber.String())
+			}
+		}
+	}
+	return nil
+}
+
+// current reloads the changed files and returns the current client CAs and revoked certificates.
+func (d *dynamicClientCA) current() (*x509.CertPool, map[string]*revocationList) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	changed, err := d.changed()
+	if err != nil {
+		klog.Errorf("failed to check the client CA files, use the last loaded client CAs: %v", err)
+		return d.pool, d.revoked
+	}
+	if changed == nil {
+		return d.pool, d.revoked
+	}
+
+	if err := d.reload(changed); err != nil {
+		klog.Errorf("failed to reload the client CA files, use the last loaded client CAs: %v", err)
+	} else {
+		klog.Infof("reloaded the client CA file %s and the revocation list file %q", d.caFile, d.crlFile)
+	}
+	return d.pool, d.revoked
+}
+
+// changed returns the states of the files if any of them is changed, otherwise it returns nil.
+func (d *dynamicClientCA) changed() ([]fileState, error) {
+	states := []fileState{}
+	for _, file := range []string{d.caFile, d.crlFile} {
+		if len(file) == 0 {
+			states = append(states, fileState{})
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, fileState{modTime: info.ModTime(), size: info.Size()})
+	}
+
+	if d.pool != nil && states[0] == d.caState && states[1] == d.crlState {
+		return nil, nil
+	}
+	return states, nil
+}
+
+// reload loads the files, the states are recorded even if the files are invalid, so they are not reloaded until
+// they are changed again.
+func (d *dynamicClientCA) reload(states []fileState) error {
+	d.caState, d.crlState = states[0], states[1]
+
+	caPEM, err := os.ReadFile(d.caFile)
+	if err != nil {
+		return fmt.Errorf("failed to read server client CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if ok := pool.AppendCertsFromPEM(caPEM); !ok {
+		return fmt.Errorf("failed to append server client CA to cert pool")
+	}
+
+	revoked := map[string]*revocationList{}
+	if len(d.crlFile) != 0 {
+		revoked, err = loadRevocationLists(d.crlFile, caPEM, d.now())
+		if err != nil {
+			return err
+		}
+	}
+
+	d.pool = pool
+	d.revoked = revoked
+	return nil
+}
+
+// loadRevocationLists loads the PEM encoded revocation lists from the file, each revocation list must be signed by
+// one of the client CAs, and it must not be stale.
+func loadRevocationLists(crlFile string, caPEM []byte, now time.Time) (map[string]*revocationList, error) {
+	crlPEM, err := os.ReadFile(crlFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client revocation list file: %v", err)
+	}
+
+	cas, err := parseCertificates(caPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := map[string]*revocationList{}
+	for block, rest := pem.Decode(crlPEM); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "X509 CRL" {
+			continue
+		}
+
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse client revocation list: %v", err)
+		}
+
+		trusted := false
+		for _, ca := range cas {
+			if bytes.Equal(ca.RawSubject, crl.RawIssuer) && crl.CheckSignatureFrom(ca) == nil {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			return nil, fmt.Errorf("the client revocation list of %q is not signed by a client CA", crl.Issuer.String())
+		}
+
+		if (&revocationList{nextUpdate: crl.NextUpdate}).stale(now) {
+			return nil, fmt.Errorf("the client revocation list of %q is stale, its next update %s has passed",
+				crl.Issuer.String(), crl.NextUpdate.Format(time.RFC3339))
+		}
+
+		list, ok := revoked[string(crl.RawIssuer)]
+		if !ok {
+			list = &revocationList{serials: sets.New[string](), nextUpdate: crl.NextUpdate}
+			revoked[string(crl.RawIssuer)] = list
+		}
+		if crl.NextUpdate.Before(list.nextUpdate) {
+			list.nextUpdate = crl.NextUpdate
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			list.serials.Insert(entry.SerialNumber.String())
+		}
+	}
+	return revoked, nil
+}
+
+func parseCertificates(data []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse server client CA: %v", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
@@ -0,0 +1,281 @@
+package grpc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDynamicClientCA(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	crlFile := filepath.Join(dir, "ca.crl")
+
+	ca, caKey := newTestCA(t, "ca")
+	otherCA, otherCAKey := newTestCA(t, "other-ca")
+	client1 := newTestClientCert(t, ca, caKey, 1)
+	client2 := newTestClientCert(t, ca, caKey, 2)
+
+	writeTestFile(t, caFile, pemEncode("CERTIFICATE", ca.Raw), time.Now())
+	writeTestFile(t, crlFile, newTestCRL(t, ca, caKey, 2), time.Now())
+
+	clientCA, err := newDynamicClientCA(caFile, crlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := clientCA.VerifyConnection(verifiedChains(client1, ca)); err != nil {
+		t.Errorf("expected client1 is not revoked, but got %v", err)
+	}
+	if err := clientCA.VerifyConnection(verifiedChains(client2, ca)); err == nil {
+		t.Errorf("expected client2 is revoked")
+	}
+
+	// the changed revocation list is reloaded
+	writeTestFile(t, crlFile, newTestCRL(t, ca, caKey, 1), time.Now().Add(time.Minute))
+	if err := clientCA.VerifyConnection(verifiedChains(client1, ca)); err == nil {
+		t.Errorf("expected client1 is revoked")
+	}
+	if err := clientCA.VerifyConnection(verifiedChains(client2, ca)); err != nil {
+		t.Errorf("expected client2 is not revoked, but got %v", err)
+	}
+
+	// the revocation list that is not signed by a client CA is not loaded, the last loaded one is kept
+	writeTestFile(t, crlFile, newTestCRL(t, otherCA, otherCAKey, 2), time.Now().Add(2*time.Minute))
+	if err := clientCA.VerifyConnection(verifiedChains(client1, ca)); err == nil {
+		t.Errorf("expected client1 is still revoked")
+	}
+	if _, err := newDynamicClientCA(caFile, crlFile); err == nil {
+		t.Errorf("expected error for the untrusted revocation list")
+	}
+
+	// the stale revocation list is not loaded, the last loaded one is kept
+	writeTestFile(t, crlFile, newTestCRLWithNextUpdate(t, ca, caKey, time.Now().Add(-time.Minute), 2),
+		time.Now().Add(150*time.Second))
+	if err := clientCA.VerifyConnection(verifiedChains(client1, ca)); err == nil {
+		t.Errorf("expected client1 is still revoked")
+	}
+	if _, err := newDynamicClientCA(caFile, crlFile); err == nil {
+		t.Errorf("expected error for the stale revocation list")
+	}
+
+	// the clients are rejected once the loaded revocation list is stale
+	clientCA.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if err := clientCA.VerifyConnection(verifiedChains(client2, ca)); err == nil {
+		t.Errorf("expected client2 is rejected with the stale revocation list")
+	}
+	clientCA.now = time.Now
+	if err := clientCA.VerifyConnection(verifiedChains(client2, ca)); err != nil {
+		t.Errorf("expected client2 is not revoked, but got %v", err)
+	}
+
+	// the rotated client CA is used for the new handshakes
+	writeTestFile(t, crlFile, newTestCRL(t, ca, caKey, 1), time.Now().Add(3*time.Minute))
+	writeTestFile(t, caFile, append(pemEncode("CERTIFICATE", ca.Raw), pemEncode("CERTIFICATE", otherCA.Raw)...),
+		time.Now().Add(3*time.Minute))
+	expected := x509.NewCertPool()
+	expected.AddCert(ca)
+	expected.AddCert(otherCA)
+	config, err := clientCA.GetConfigForClient(&tls.Config{})(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !config.ClientCAs.Equal(expected) {
+		t.Errorf("expected the rotated client CAs are used")
+	}
+}
+
+func newTestCA(t *testing.T, name string) (*x509.Certificate, crypto.Signer) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func newTestClientCert(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, serial int64) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestDynamicClientCAResumedSession(t *testing.T) {
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.crt")
+	crlFile := filepath.Join(dir, "ca.crl")
+
+	ca, caKey := newTestCA(t, "ca")
+	writeTestFile(t, caFile, pemEncode("CERTIFICATE", ca.Raw), time.Now())
+	writeTestFile(t, crlFile, newTestCRL(t, ca, caKey), time.Now())
+
+	clientCA, err := newDynamicClientCA(caFile, crlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConfig := &tls.Config{
+		Certificates:     []tls.Certificate{newTestKeyPair(t, ca, caKey, 10, x509.ExtKeyUsageServerAuth)},
+		ClientAuth:       tls.VerifyClientCertIfGiven,
+		VerifyConnection: clientCA.VerifyConnection,
+	}
+	serverConfig.GetConfigForClient = clientCA.GetConfigForClient(serverConfig.Clone())
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	clientConfig := &tls.Config{
+		Certificates:       []tls.Certificate{newTestKeyPair(t, ca, caKey, 2, x509.ExtKeyUsageClientAuth)},
+		RootCAs:            roots,
+		ServerName:         "localhost",
+		ClientSessionCache: tls.NewLRUClientSessionCache(1),
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	handshake := func() (bool, error) {
+		serverErr := make(chan error, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				serverErr <- err
+				return
+			}
+			defer conn.Close()
+			serverErr <- conn.(*tls.Conn).Handshake()
+		}()
+
+		conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+		if err != nil {
+			return false, err
+		}
+		defer conn.Close()
+		// the TLS 1.3 session ticket is sent after the handshake, wait for the server to close the connection
+		_, _ = conn.Read(make([]byte, 1))
+		return conn.ConnectionState().DidResume, <-serverErr
+	}
+
+	if _, err := handshake(); err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := handshake()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resumed {
+		t.Fatalf("expected the TLS session is resumed")
+	}
+
+	// the revoked client is rejected even if it resumes the session
+	writeTestFile(t, crlFile, newTestCRL(t, ca, caKey, 2), time.Now().Add(time.Minute))
+	if _, err := handshake(); err == nil {
+		t.Errorf("expected the resumed session of the revoked client is rejected")
+	}
+}
+
+func verifiedChains(certs ...*x509.Certificate) tls.ConnectionState {
+	return tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{certs}}
+}
+
+func newTestKeyPair(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, serial int64,
+	usage x509.ExtKeyUsage) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func newTestCRL(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, revokedSerials ...int64) []byte {
+	return newTestCRLWithNextUpdate(t, ca, caKey, time.Now().Add(time.Hour), revokedSerials...)
+}
+
+func newTestCRLWithNextUpdate(t *testing.T, ca *x509.Certificate, caKey crypto.Signer, nextUpdate time.Time,
+	revokedSerials ...int64) []byte {
+	entries := []x509.RevocationListEntry{}
+	for _, serial := range revokedSerials {
+		entries = append(entries, x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(time.Now().UnixNano()),
+		ThisUpdate:                nextUpdate.Add(-2 * time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, ca, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pemEncode("X509 CRL", der)
+}
+
+func pemEncode(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func writeTestFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+)
+
+// ReadinessCheck returns an error if the server is not ready to serve the requests, e.g. the broker services
+// are not registered or the caches are not synced.
+type ReadinessCheck func(ctx context.Context) error
+
+// healthChecker sets the serving status of the health service with the readiness checks, the server is serving
+// only if all the checks pass.
+type healthChecker struct {
+	server   *health.Server
+	names    []string
+	checks   map[string]ReadinessCheck
+	interval time.Duration
+}
+
+func newHealthChecker(server *health.Server, checks map[string]ReadinessCheck, interval time.Duration) *healthChecker {
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return &healthChecker{server: server, names: names, checks: checks, interval: interval}
+}
+
+// run checks the readiness periodically until the context is done.
+func (h *healthChecker) run(ctx context.Context) {
+	logger := klog.FromContext(ctx)
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		err := h.check(ctx)
+		switch {
+		case err != nil && (lastErr == nil || err.Error() != lastErr.Error()):
+			logger.Info("gRPC server is not ready", "reason", err.Error())
+		case err == nil && lastErr != nil:
+			logger.Info("gRPC server is ready")
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check runs the readiness checks and sets the serving status of the server.
+func (h *healthChecker) check(ctx context.Context) error {
+	errs := []error{}
+	for _, name := range h.names {
+		if err := h.checks[name](ctx); err != nil {
+			errs = append(errs, fmt.Errorf("readiness check %s failed: %v", name, err))
+		}
+	}
+
+	// the status of the server is not changed once it is shutting down
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if len(errs) != 0 {
+		h.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		return utilerrors.NewAggregate(errs)
+	}
+	h.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	return nil
+}
+
+// drainer closes the long-lived streams when the server is shutting down, the closed streams get the Unavailable
+// status, so the clients reconnect to another server.
+type drainer struct {
+	mu       sync.Mutex
+	draining bool
+	streams  map[*drainableStream]struct{}
+}
+
+func newDrainer() *drainer {
+	return &drainer{streams: map[*drainableStream]struct{}{}}
+}
+
+// drain closes the current streams and rejects the new streams.
+func (d *drainer) drain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.draining = true
+	for stream := range d.streams {
+		stream.cancel()
+	}
+}
+
+// streamInterceptor returns a stream interceptor that closes the stream once the server is draining, it should be
+// the last interceptor of the chain, so the stream handler gets the closable context.
+func (d *drainer) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+		stream := &drainableStream{ServerStream: ss, ctx: ctx, cancel: cancel}
+
+		d.mu.Lock()
+		if d.draining {
+			d.mu.Unlock()
+			return drainingError()
+		}
+		d.streams[stream] = struct{}{}
+		d.mu.Unlock()
+
+		err := handler(srv, stream)
+
+		d.mu.Lock()
+		delete(d.streams, stream)
+		draining := d.draining
+		d.mu.Unlock()
+
+		if draining {
+			return drainingError()
+		}
+		return err
+	}
+}
+
+// drainableStream is a stream with a context that is canceled once the server is draining.
+type drainableStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (s *drainableStream) Context() context.Context {
+	return s.ctx
+}
+
+func drainingError() error {
+	return status.Error(codes.Unavailable, "the server is shutting down, reconnect to another server")
+}
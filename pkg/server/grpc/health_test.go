@@ -0,0 +1,274 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	certutil "k8s.io/client-go/util/cert"
+
+	pbv1 "open-cluster-management.io/sdk-go/pkg/cloudevents/generic/options/grpc/protobuf/v1"
+	sar "open-cluster-management.io/sdk-go/pkg/cloudevents/server/grpc/authz/kube"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
+)
+
+func TestHealthChecker(t *testing.T) {
+	var synced atomic.Bool
+	server := health.NewServer()
+	checker := newHealthChecker(server, map[string]ReadinessCheck{
+		"always": func(ctx context.Context) error { return nil },
+		"synced": func(ctx context.Context) error {
+			if !synced.Load() {
+				return fmt.Errorf("caches are not synced")
+			}
+			return nil
+		},
+	}, time.Minute)
+
+	ctx := context.Background()
+	if err := checker.check(ctx); err == nil {
+		t.Errorf("expected the readiness check fails")
+	}
+	assertServingStatus(t, server, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	synced.Store(true)
+	if err := checker.check(ctx); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	assertServingStatus(t, server, healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestGRPCServer_HealthAndDrain(t *testing.T) {
+	opt, port := newTestServerOptions(t)
+	opt.HealthCheckInterval = 50 * time.Millisecond
+	opt.ShutdownGracePeriod = 2 * time.Second
+	opt.EnableReflection = true
+
+	var ready atomic.Bool
+	builder := NewGRPCServer(opt).
+		WithAuthenticator(&testAuthenticator{}).
+		WithUnaryAuthorizer(&allowAllAuthorizer{}).
+		WithStreamAuthorizer(&allowAllAuthorizer{}).
+		WithReadinessCheck("test", func(ctx context.Context) error {
+			if !ready.Load() {
+				return fmt.Errorf("not ready")
+			}
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error)
+	go func() {
+		stopped <- builder.Run(ctx)
+	}()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	healthClient := healthpb.NewHealthClient(conn)
+
+	// the server is not serving until the readiness check passes
+	waitForServingStatus(t, healthClient, healthpb.HealthCheckResponse_NOT_SERVING)
+	ready.Store(true)
+	waitForServingStatus(t, healthClient, healthpb.HealthCheckResponse_SERVING)
+
+	// the reflection service is registered
+	reflectionStream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reflectionStream.Recv(); err != nil {
+		t.Errorf("expected the reflection service is registered, but got %v", err)
+	}
+	_ = reflectionStream.CloseSend()
+
+	// the long-lived watch stream is closed once the server is shutting down
+	watchStream, err := healthClient.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := watchStream.Recv(); err != nil || resp.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("expected serving, but got %v, %v", resp, err)
+	}
+
+	cancel()
+	for {
+		resp, err := watchStream.Recv()
+		if err == nil {
+			// the not serving status is sent before the stream is closed
+			if resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+				t.Errorf("expected not serving, but got %v", resp.Status)
+			}
+			continue
+		}
+		if status.Code(err) != codes.Unavailable {
+			t.Errorf("expected the stream is closed with unavailable, but got %v", err)
+		}
+		break
+	}
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected the server is stopped within the grace period")
+	}
+}
+
+func TestGRPCServer_HealthWithoutAuthentication(t *testing.T) {
+	opt, port := newTestServerOptions(t)
+	opt.EnableReflection = true
+
+	// the client has no certificate, so the requests are rejected by the mTLS authenticator, and the SAR
+	// authorizer has no opinion on the health and reflection services.
+	builder := NewGRPCServer(opt).
+		WithRegisterFunc(registerTestService).
+		WithAuthenticator(authn.NewMtlsAuthenticator()).
+		WithUnaryAuthorizer(sar.NewSARAuthorizer(kubefake.NewSimpleClientset())).
+		WithStreamAuthorizer(sar.NewSARAuthorizer(kubefake.NewSimpleClientset()))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = builder.Run(ctx)
+	}()
+
+	conn, err := grpc.NewClient(fmt.Sprintf("127.0.0.1:%d", port),
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	waitForServingStatus(t, healthpb.NewHealthClient(conn), healthpb.HealthCheckResponse_SERVING)
+
+	reflectionStream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reflectionStream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reflectionStream.Recv(); err != nil {
+		t.Errorf("expected the reflection service is not authenticated, but got %v", err)
+	}
+	_ = reflectionStream.CloseSend()
+
+	// the other services are still authenticated
+	if err := invokeTestService(conn); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected the request of the test service is unauthenticated, but got %v", err)
+	}
+}
+
+func TestIsUnauthenticatedMethod(t *testing.T) {
+	cases := []struct {
+		method            string
+		reflectionEnabled bool
+		expected          bool
+	}{
+		{method: healthpb.Health_Check_FullMethodName, expected: true},
+		{method: healthpb.Health_Watch_FullMethodName, expected: true},
+		{method: reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName, expected: false},
+		{method: reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName, reflectionEnabled: true, expected: true},
+		{method: pbv1.CloudEventService_Publish_FullMethodName, reflectionEnabled: true, expected: false},
+		{method: "/grpc.health.v1.HealthExtra/Check", expected: false},
+	}
+	for _, c := range cases {
+		if actual := isUnauthenticatedMethod(c.method, c.reflectionEnabled); actual != c.expected {
+			t.Errorf("expected %v for %s (reflection %v), but got %v", c.expected, c.method, c.reflectionEnabled, actual)
+		}
+	}
+}
+
+// newTestServerOptions returns the options of a server that listens on a free local port with a self-signed
+// serving certificate.
+func newTestServerOptions(t *testing.T) (*GRPCServerOptions, int) {
+	cert, key, err := certutil.GenerateSelfSignedCertKey("localhost", []net.IP{net.ParseIP("127.0.0.1")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, cert, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, key, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := lis.Addr().(*net.TCPAddr).Port
+	lis.Close()
+
+	opt := NewGRPCServerOptions()
+	opt.ClientCAFile = ""
+	opt.TLSKeyFile = keyFile
+	opt.TLSCertFile = certFile
+	opt.ServerBindPort = fmt.Sprintf("%d", port)
+	return opt, port
+}
+
+type allowAllAuthorizer struct{}
+
+func (a *allowAllAuthorizer) AuthorizeRequest(ctx context.Context, req any) (authz.Decision, error) {
+	return authz.DecisionAllow, nil
+}
+
+func (a *allowAllAuthorizer) AuthorizeStream(ctx context.Context, ss grpc.ServerStream, info *grpc.StreamServerInfo) (authz.Decision, grpc.ServerStream, error) {
+	return authz.DecisionAllow, ss, nil
+}
+
+func assertServingStatus(t *testing.T, server *health.Server, expected healthpb.HealthCheckResponse_ServingStatus) {
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != expected {
+		t.Errorf("expected status %v, but got %v", expected, resp.Status)
+	}
+}
+
+func waitForServingStatus(t *testing.T, client healthpb.HealthClient, expected healthpb.HealthCheckResponse_ServingStatus) {
+	var lastErr error
+	for i := 0; i < 50; i++ {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err == nil && resp.Status == expected {
+			return
+		}
+		lastErr = err
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("expected status %v, last error %v", expected, lastErr)
+}
@@ -0,0 +1,198 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ListenerType is the type of an additional listener.
+type ListenerType string
+
+const (
+	// ListenerTypeUnix is a Unix domain socket listener, the peer process is identified by its credentials that
+	// are read with SO_PEERCRED.
+	ListenerTypeUnix ListenerType = "unix"
+	// ListenerTypeLoopback is a plaintext TCP listener that is bound to a loopback address.
+	ListenerTypeLoopback ListenerType = "loopback"
+)
+
+// defaultSocketMode is the file permission of the Unix domain socket if it is not specified.
+const defaultSocketMode = "0600"
+
+// ListenerOptions configures an additional listener of the server, e.g. for the sidecars in the same pod or the
+// local tools, so they do not need the certificates. The listeners share the registered services with the TLS
+// listener, and each listener has its own authenticators that are added with WithListenerAuthenticator.
+type ListenerOptions struct {
+	// Name is the unique name of the listener.
+	Name string `json:"name" yaml:"name"`
+	// Type is the type of the listener, unix or loopback.
+	Type ListenerType `json:"type" yaml:"type"`
+	// Address is the socket path of a unix listener, or the host:port of a loopback listener, e.g. 127.0.0.1:8091.
+	Address string `json:"address" yaml:"address"`
+	// SocketMode is the octal file permission of the socket of a unix listener, defaults to 0600.
+	SocketMode string `json:"socket_mode,omitempty" yaml:"socket_mode,omitempty"`
+}
+
+func (o ListenerOptions) validate() error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("the name of the listener is required")
+	}
+	if len(o.Address) == 0 {
+		return fmt.Errorf("the address of the listener %s is required", o.Name)
+	}
+
+	switch o.Type {
+	case ListenerTypeUnix:
+		if _, err := o.socketMode(); err != nil {
+			return fmt.Errorf("invalid socket_mode %q of the listener %s: %v", o.SocketMode, o.Name, err)
+		}
+	case ListenerTypeLoopback:
+		host, _, err := net.SplitHostPort(o.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address %q of the listener %s: %v", o.Address, o.Name, err)
+		}
+		if host != "localhost" {
+			ip := net.ParseIP(host)
+			if ip == nil || !ip.IsLoopback() {
+				return fmt.Errorf("the address %q of the listener %s is not a loopback address", o.Address, o.Name)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %q of the listener %s", o.Type, o.Name)
+	}
+	return nil
+}
+
+func (o ListenerOptions) socketMode() (fs.FileMode, error) {
+	mode := o.SocketMode
+	if len(mode) == 0 {
+		mode = defaultSocketMode
+	}
+	perm, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return 0, err
+	}
+	if perm > 0777 {
+		return 0, fmt.Errorf("the permission bits are out of range")
+	}
+	return fs.FileMode(perm), nil
+}
+
+// listen creates the listener and returns it with the transport credentials of its connections.
+func (o ListenerOptions) listen() (net.Listener, credentials.TransportCredentials, error) {
+	switch o.Type {
+	case ListenerTypeUnix:
+		mode, err := o.socketMode()
+		if err != nil {
+			return nil, nil, err
+		}
+		// remove the socket that is left by the last run
+		if info, err := os.Lstat(o.Address); err == nil && info.Mode()&fs.ModeSocket != 0 {
+			if err := os.Remove(o.Address); err != nil {
+				return nil, nil, fmt.Errorf("failed to remove the stale socket %s: %v", o.Address, err)
+			}
+		}
+		lis, err := listenUnix(o.Address, mode)
+		if err != nil {
+			return nil, nil, err
+		}
+		return lis, &unixPeerCredentials{}, nil
+	case ListenerTypeLoopback:
+		lis, err := net.Listen("tcp", o.Address)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to listen: %v", err)
+		}
+		return lis, insecure.NewCredentials(), nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported type %q of the listener %s", o.Type, o.Name)
+	}
+}
+
+// listenUnix creates the socket in a private directory next to the address, and moves it to the address once its
+// permission is set, so the socket is never accessible with the permission of the umask.
+func listenUnix(address string, mode fs.FileMode) (net.Listener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(address), ".grpc-socket-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the directory of the socket %s: %v", address, err)
+	}
+	defer os.RemoveAll(dir)
+
+	tmpAddress := filepath.Join(dir, filepath.Base(address))
+	lis, err := net.Listen("unix", tmpAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %v", err)
+	}
+	// the socket is moved, so it is removed with its final address once the listener is closed
+	lis.(*net.UnixListener).SetUnlinkOnClose(false)
+
+	if err := os.Chmod(tmpAddress, mode); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to set the permission of the socket %s: %v", address, err)
+	}
+	if err := os.Rename(tmpAddress, address); err != nil {
+		lis.Close()
+		return nil, fmt.Errorf("failed to move the socket to %s: %v", address, err)
+	}
+	return &unixListener{Listener: lis, address: address}, nil
+}
+
+// unixListener is a Unix domain socket listener whose socket is moved to the address.
+type unixListener struct {
+	net.Listener
+	address string
+}
+
+func (l *unixListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: l.address, Net: "unix"}
+}
+
+func (l *unixListener) Close() error {
+	err := l.Listener.Close()
+	if removeErr := os.Remove(l.address); removeErr != nil && !os.IsNotExist(removeErr) && err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// unixPeerCredentials is the server side transport credentials of the Unix domain socket connections, the
+// connections are not encrypted, and their auth info is the credentials of the peer process.
+type unixPeerCredentials struct{}
+
+func (c *unixPeerCredentials) ClientHandshake(_ context.Context, _ string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("unix peer credentials are only supported by the server")
+}
+
+func (c *unixPeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected connection type %T", conn)
+	}
+
+	peerInfo, err := getPeerCredentials(unixConn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get the peer credentials: %v", err)
+	}
+	peerInfo.SecurityLevel = credentials.NoSecurity
+	return conn, peerInfo, nil
+}
+
+func (c *unixPeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "unix"}
+}
+
+func (c *unixPeerCredentials) Clone() credentials.TransportCredentials {
+	return &unixPeerCredentials{}
+}
+
+func (c *unixPeerCredentials) OverrideServerName(string) error {
+	return nil
+}
@@ -0,0 +1,262 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
+)
+
+func TestListenerOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		listener    ListenerOptions
+		expectError bool
+	}{
+		{
+			name:     "unix listener",
+			listener: ListenerOptions{Name: "sidecar", Type: ListenerTypeUnix, Address: "/tmp/grpc.sock", SocketMode: "0660"},
+		},
+		{
+			name:        "invalid socket mode",
+			listener:    ListenerOptions{Name: "sidecar", Type: ListenerTypeUnix, Address: "/tmp/grpc.sock", SocketMode: "rw"},
+			expectError: true,
+		},
+		{
+			name:     "loopback listener",
+			listener: ListenerOptions{Name: "local", Type: ListenerTypeLoopback, Address: "localhost:8091"},
+		},
+		{
+			name:     "ipv6 loopback listener",
+			listener: ListenerOptions{Name: "local", Type: ListenerTypeLoopback, Address: "[::1]:8091"},
+		},
+		{
+			name:        "loopback listener on all the interfaces",
+			listener:    ListenerOptions{Name: "local", Type: ListenerTypeLoopback, Address: ":8091"},
+			expectError: true,
+		},
+		{
+			name:        "unsupported type",
+			listener:    ListenerOptions{Name: "local", Type: "tcp", Address: "127.0.0.1:8091"},
+			expectError: true,
+		},
+		{
+			name:        "no name",
+			listener:    ListenerOptions{Type: ListenerTypeLoopback, Address: "127.0.0.1:8091"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.listener.validate()
+			if tt.expectError && err == nil {
+				t.Errorf("expected error, but got nil")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestGRPCServer_AdditionalListeners(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the peer credentials are only supported on linux")
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	loopbackAddr := lis.Addr().String()
+	lis.Close()
+
+	// the TLS listener is disabled, so no certificates are required
+	socket := filepath.Join(t.TempDir(), "grpc.sock")
+	opt := NewGRPCServerOptions()
+	opt.ServerBindPort = ""
+	opt.Listeners = []ListenerOptions{
+		{Name: "sidecar", Type: ListenerTypeUnix, Address: socket, SocketMode: "0660"},
+		{Name: "local", Type: ListenerTypeLoopback, Address: loopbackAddr},
+	}
+
+	authorizer := &recordingAuthorizer{}
+	builder := NewGRPCServer(opt).
+		WithListenerAuthenticator("sidecar", authn.NewUnixPeerAuthenticator(
+			authn.WithUnixUser(uint32(os.Getuid()), "sidecar", "system:sidecars"))).
+		WithListenerAuthenticator("local", &staticAuthenticator{user: "local"}).
+		WithUnaryAuthorizer(authorizer).
+		WithRegisterFunc(registerTestService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error)
+	go func() {
+		stopped <- builder.Run(ctx)
+	}()
+
+	for target, expectedUser := range map[string]string{
+		"unix://" + socket: "sidecar",
+		loopbackAddr:       "local",
+	} {
+		conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitForServingStatus(t, healthpb.NewHealthClient(conn), healthpb.HealthCheckResponse_SERVING)
+		err = invokeTestService(conn)
+		conn.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if user := authorizer.lastUser(); user != expectedUser {
+			t.Errorf("expected user %s on %s, but got %s", expectedUser, target, user)
+		}
+	}
+
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0660 {
+		t.Errorf("expected socket mode 0660, but got %v", info.Mode().Perm())
+	}
+
+	cancel()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("expected the server is stopped")
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the socket permission is not supported on windows")
+	}
+
+	dir := t.TempDir()
+	socket := filepath.Join(dir, "grpc.sock")
+	// the stale socket of the last run is replaced
+	stale, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	lis, _, err := ListenerOptions{Name: "sidecar", Type: ListenerTypeUnix, Address: socket}.listen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lis.Addr().String() != socket {
+		t.Errorf("expected the listener address %s, but got %s", socket, lis.Addr())
+	}
+
+	info, err := os.Stat(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Errorf("expected a socket with mode 0600, but got %v", info.Mode())
+	}
+	// the private directory of the socket is removed
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 1 {
+		t.Errorf("expected only the socket in %s, but got %v, %v", dir, entries, err)
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatalf("expected the socket is connectable, but got %v", err)
+	}
+	conn.Close()
+
+	if err := lis.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("expected the socket is removed once the listener is closed, but got %v", err)
+	}
+}
+
+func TestGRPCServer_ListenerWithoutAuthenticator(t *testing.T) {
+	opt := NewGRPCServerOptions()
+	opt.ServerBindPort = ""
+	opt.Listeners = []ListenerOptions{{Name: "local", Type: ListenerTypeLoopback, Address: "127.0.0.1:0"}}
+
+	if err := NewGRPCServer(opt).Run(context.Background()); err == nil {
+		t.Errorf("expected error for the listener without authenticator")
+	}
+}
+
+// registerTestService registers a service that checks the health, unlike the health service, it is authenticated
+// and authorized as the other services.
+func registerTestService(s *grpc.Server) {
+	healthServer := health.NewServer()
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "test.Health",
+		HandlerType: (*healthpb.HealthServer)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Check",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+				req := &healthpb.HealthCheckRequest{}
+				if err := dec(req); err != nil {
+					return nil, err
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Health/Check"}
+				return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+					return srv.(healthpb.HealthServer).Check(ctx, req.(*healthpb.HealthCheckRequest))
+				})
+			},
+		}},
+	}, healthServer)
+}
+
+func invokeTestService(conn *grpc.ClientConn) error {
+	return conn.Invoke(context.Background(), "/test.Health/Check", &healthpb.HealthCheckRequest{}, &healthpb.HealthCheckResponse{})
+}
+
+type staticAuthenticator struct {
+	user string
+}
+
+func (a *staticAuthenticator) Authenticate(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, authn.ContextUserKey, a.user), nil
+}
+
+// recordingAuthorizer allows all the requests and records the user of the last request
+type recordingAuthorizer struct {
+	mu   sync.Mutex
+	user string
+}
+
+func (a *recordingAuthorizer) AuthorizeRequest(ctx context.Context, req any) (authz.Decision, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	user, _ := userInfo(ctx)
+	a.user = user
+	return authz.DecisionAllow, nil
+}
+
+func (a *recordingAuthorizer) lastUser() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.user
+}
@@ -29,6 +29,8 @@ const (
 	grpcMetricsCodeLabel       = "grpc_code"
 	grpcMetricsRemoteAddrLabel = "remote_addr"
 	grpcMetricsLocalAddrLabel  = "local_addr"
+	grpcMetricsCacheLabel      = "cache"
+	grpcMetricsResultLabel     = "result"
 )
 
 // grpcMetricsLabels - Array of labels added to grpc server metrics:
@@ -44,11 +46,26 @@ var grpcConnMetricsLabels = []string{
 	grpcMetricsLocalAddrLabel,
 }
 
+// grpcAuthCacheMetricsLabels - Array of labels added to grpc server authentication and authorization cache metrics:
+var grpcAuthCacheMetricsLabels = []string{
+	grpcMetricsCacheLabel,
+	grpcMetricsResultLabel,
+}
+
+// Values of the grpc server authentication and authorization cache metric labels:
+const (
+	TokenReviewCache         = "token_review"
+	SubjectAccessReviewCache = "subject_access_review"
+	CacheHit                 = "hit"
+	CacheMiss                = "miss"
+)
+
 // Names of the grpc server metrics:
 const (
 	activeConnectionsMetric = "active_connections"
 	msgRevBytesCountMetric  = "msg_received_bytes_total"
 	msgSentBytesCountMetric = "msg_sent_bytes_total"
+	authCacheRequestsMetric = "auth_cache_requests_total"
 )
 
 // grpcServerConnections is a gauge metric that tracks the number of
@@ -78,6 +95,20 @@ var grpcServerMsgSentBytes = k8smetrics.NewCounterVec(&k8smetrics.CounterOpts{
 	Help:           "Total number of bytes sent by the gRPC server.",
 }, grpcMetricsLabels)
 
+// grpcServerAuthCacheRequests is a counter metric that tracks the total number of the authentication and
+// authorization cache lookups on the gRPC server, the result is hit or miss.
+var grpcServerAuthCacheRequests = k8smetrics.NewCounterVec(&k8smetrics.CounterOpts{
+	Subsystem:      grpcMetricsSubsystem,
+	Name:           authCacheRequestsMetric,
+	StabilityLevel: k8smetrics.ALPHA,
+	Help:           "Total number of the authentication and authorization cache lookups on the gRPC server.",
+}, grpcAuthCacheMetricsLabels)
+
+// IncreaseAuthCacheRequests increases the lookup counter of the given authentication or authorization cache.
+func IncreaseAuthCacheRequests(cache, result string) {
+	grpcServerAuthCacheRequests.WithLabelValues(cache, result).Inc()
+}
+
 // NewGRPCMetricsUnaryInterceptor creates a chained unary interceptor for server metrics that
 // include grpc prometheus server metrics and cloud events metrics.
 func NewGRPCMetricsUnaryInterceptor(promServerMetrics *prom.ServerMetrics) grpc.UnaryServerInterceptor {
@@ -99,11 +130,12 @@ func NewGRPCMetricsStreamInterceptor(promServerMetrics *prom.ServerMetrics) grpc
 // Register all the grpc server metrics.
 func RegisterGRPCMetrics(promServerMetrics *prom.ServerMetrics, extraMetrics ...k8smetrics.Registerable) {
 	once.Do(func() {
-		metrics := make([]k8smetrics.Registerable, 0, 3+len(extraMetrics))
+		metrics := make([]k8smetrics.Registerable, 0, 4+len(extraMetrics))
 		metrics = append(metrics,
 			grpcServerConnections,
 			grpcServerMsgRevBytes,
 			grpcServerMsgSentBytes,
+			grpcServerAuthCacheRequests,
 		)
 
 		metrics = append(metrics, extraMetrics...)
@@ -149,6 +149,7 @@ grpc_server_msg_received_bytes_total{grpc_method="Subscribe",grpc_service="io.cl
 # HELP grpc_server_msg_received_total Total number of RPC stream messages received on the server.
 # TYPE grpc_server_msg_received_total counter
 grpc_server_msg_received_total{grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 1
+grpc_server_msg_received_total{grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_msg_received_total{grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 1
 # HELP grpc_server_msg_sent_bytes_total [ALPHA] Total number of bytes sent by the gRPC server.
 # TYPE grpc_server_msg_sent_bytes_total counter
@@ -156,46 +157,65 @@ grpc_server_msg_sent_bytes_total{grpc_method="Publish",grpc_service="io.cloudeve
 # HELP grpc_server_msg_sent_total Total number of gRPC stream messages sent by the server.
 # TYPE grpc_server_msg_sent_total counter
 grpc_server_msg_sent_total{grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 1
+grpc_server_msg_sent_total{grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_msg_sent_total{grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 # HELP grpc_server_started_total Total number of RPCs started on the server.
 # TYPE grpc_server_started_total counter
 grpc_server_started_total{grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 1
+grpc_server_started_total{grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_started_total{grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 1
 # HELP grpc_server_handled_total Total number of RPCs completed on the server, regardless of success or failure.
 # TYPE grpc_server_handled_total counter
 grpc_server_handled_total{grpc_code="Aborted",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Aborted",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Aborted",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="AlreadyExists",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="AlreadyExists",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="AlreadyExists",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Canceled",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Canceled",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Canceled",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="DataLoss",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="DataLoss",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="DataLoss",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="DeadlineExceeded",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="DeadlineExceeded",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="DeadlineExceeded",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="FailedPrecondition",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="FailedPrecondition",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="FailedPrecondition",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Internal",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Internal",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Internal",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="InvalidArgument",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="InvalidArgument",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="InvalidArgument",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="NotFound",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="NotFound",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="NotFound",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="OK",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 1
+grpc_server_handled_total{grpc_code="OK",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="OK",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="OutOfRange",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="OutOfRange",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="OutOfRange",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="PermissionDenied",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="PermissionDenied",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="PermissionDenied",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="ResourceExhausted",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="ResourceExhausted",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="ResourceExhausted",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Unauthenticated",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Unauthenticated",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Unauthenticated",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Unavailable",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Unavailable",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Unavailable",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Unimplemented",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Unimplemented",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Unimplemented",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 grpc_server_handled_total{grpc_code="Unknown",grpc_method="Publish",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="unary"} 0
+grpc_server_handled_total{grpc_code="Unknown",grpc_method="PublishStream",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="bidi_stream"} 0
 grpc_server_handled_total{grpc_code="Unknown",grpc_method="Subscribe",grpc_service="io.cloudevents.v1.CloudEventService",grpc_type="server_stream"} 0
 `
 
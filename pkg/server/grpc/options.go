@@ -11,6 +11,8 @@ import (
 	"gopkg.in/yaml.v2"
 	"k8s.io/klog/v2"
 
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/admission"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
 	pkgtls "open-cluster-management.io/sdk-go/pkg/tls"
 )
 
@@ -18,6 +20,7 @@ type GRPCServerOptions struct {
 	TLSCertFile             string        `json:"tls_cert_file" yaml:"tls_cert_file"`
 	TLSKeyFile              string        `json:"tls_key_file" yaml:"tls_key_file"`
 	ClientCAFile            string        `json:"client_ca_file" yaml:"client_ca_file"`
+	ClientCRLFile           string        `json:"client_crl_file" yaml:"client_crl_file"`
 	TLSMinVersion           string        `json:"tls_min_version" yaml:"tls_min_version"`
 	TLSMaxVersion           string        `json:"tls_max_version" yaml:"tls_max_version"`
 	CipherSuites            string        `json:"cipher_suites" yaml:"cipher_suites"`
@@ -34,6 +37,17 @@ type GRPCServerOptions struct {
 	ServerPingTimeout       time.Duration `json:"server_ping_timeout" yaml:"server_ping_timeout"`
 	PermitPingWithoutStream bool          `json:"permit_ping_without_stream" yaml:"permit_ping_without_stream"`
 	CertWatchInterval       time.Duration `json:"cert_watch_interval" yaml:"cert_watch_interval"`
+	HealthCheckInterval     time.Duration `json:"health_check_interval" yaml:"health_check_interval"`
+	ShutdownGracePeriod     time.Duration `json:"shutdown_grace_period" yaml:"shutdown_grace_period"`
+	EnableReflection        bool          `json:"enable_reflection" yaml:"enable_reflection"`
+	// Admission configures the rate limits and the concurrent subscriptions of the requests.
+	Admission admission.Options `json:"admission" yaml:"admission"`
+	// IdentityRules map the client certificates to the users and groups, see authn.IdentityRule. If no rule is
+	// specified, the identity is the common name and organizations of the certificate subject.
+	IdentityRules []authn.IdentityRule `json:"identity_rules,omitempty" yaml:"identity_rules,omitempty"`
+	// Listeners are the additional listeners of the server, e.g. a Unix domain socket or a plaintext loopback port.
+	// The TLS listener is disabled if the ServerBindPort is empty.
+	Listeners []ListenerOptions `json:"listeners,omitempty" yaml:"listeners,omitempty"`
 
 	// Parsed TLS settings, populated by Validate().
 	tlsMinVersion  uint16
@@ -83,6 +97,8 @@ func NewGRPCServerOptions() *GRPCServerOptions {
 		WriteBufferSize:       32 * 1024,
 		ReadBufferSize:        32 * 1024,
 		CertWatchInterval:     1 * time.Minute, // Default: 1 minute
+		HealthCheckInterval:   10 * time.Second,
+		ShutdownGracePeriod:   30 * time.Second,
 	}
 }
 
@@ -102,7 +118,11 @@ func (o *GRPCServerOptions) AddFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.TLSCertFile, "grpc-tls-cert-file", o.TLSCertFile, "The path to the tls.crt file")
 	flags.StringVar(&o.TLSKeyFile, "grpc-tls-key-file", o.TLSKeyFile, "The path to the tls.key file")
 	flags.StringVar(&o.ClientCAFile, "grpc-client-ca-file", o.ClientCAFile, "The path to the client ca file, must specify if using mtls authentication type")
+	flags.StringVar(&o.ClientCRLFile, "grpc-client-crl-file", o.ClientCRLFile, "The path to the PEM encoded certificate revocation lists of the client certificates, the lists must be signed by the client CAs, and the clients are rejected once the lists are stale")
 	flags.DurationVar(&o.CertWatchInterval, "grpc-cert-watch-interval", o.CertWatchInterval, "Certificate watch interval for polling certificate file changes")
+	flags.DurationVar(&o.HealthCheckInterval, "grpc-health-check-interval", o.HealthCheckInterval, "Interval of the readiness checks that set the serving status of the gRPC health service")
+	flags.DurationVar(&o.ShutdownGracePeriod, "grpc-shutdown-grace-period", o.ShutdownGracePeriod, "Duration to wait for the in-flight requests to complete before the gRPC server is stopped forcibly on shutdown")
+	flags.BoolVar(&o.EnableReflection, "grpc-enable-reflection", o.EnableReflection, "Register the gRPC server reflection service")
 }
 
 // Validate checks option ranges and cross-field constraints.
@@ -127,10 +147,53 @@ func (o *GRPCServerOptions) Validate() error {
 	if o.CertWatchInterval <= 30*time.Second {
 		return fmt.Errorf("cert_watch_interval (%v) must be greater than 30 seconds", o.CertWatchInterval)
 	}
+	if o.HealthCheckInterval <= 0 {
+		return fmt.Errorf("health_check_interval (%v) must be greater than 0", o.HealthCheckInterval)
+	}
+	if o.ShutdownGracePeriod < 0 {
+		return fmt.Errorf("shutdown_grace_period (%v) must not be negative", o.ShutdownGracePeriod)
+	}
+	if err := o.Admission.Validate(); err != nil {
+		return err
+	}
+	if len(o.IdentityRules) != 0 {
+		if len(o.ClientCAFile) == 0 {
+			return fmt.Errorf("client_ca_file is required if identity_rules are specified")
+		}
+		if _, err := authn.NewIdentityMapper(o.IdentityRules...); err != nil {
+			return fmt.Errorf("invalid identity_rules: %w", err)
+		}
+	}
+	if len(o.ServerBindPort) == 0 && len(o.Listeners) == 0 {
+		return fmt.Errorf("server_bind_port is required if no listeners are specified")
+	}
+	names := map[string]bool{}
+	for _, listener := range o.Listeners {
+		if err := listener.validate(); err != nil {
+			return err
+		}
+		if names[listener.Name] {
+			return fmt.Errorf("duplicate listener name %s", listener.Name)
+		}
+		names[listener.Name] = true
+	}
 
 	return o.parseCipherSuiteIDs()
 }
 
+// NewMtlsAuthenticator returns the mTLS authenticator that maps the client certificates with the identity rules.
+func (o *GRPCServerOptions) NewMtlsAuthenticator() (*authn.MtlsAuthenticator, error) {
+	if len(o.IdentityRules) == 0 {
+		return authn.NewMtlsAuthenticator(), nil
+	}
+
+	mapper, err := authn.NewIdentityMapper(o.IdentityRules...)
+	if err != nil {
+		return nil, err
+	}
+	return authn.NewMtlsAuthenticator(authn.WithIdentityMapper(mapper)), nil
+}
+
 // ApplyTLSFlags overrides TLS settings loaded from the config file with values
 // from --tls-min-version and --tls-cipher-suites command-line flags.
 // Called after LoadGRPCServerOptions so flags take precedence over the config file.
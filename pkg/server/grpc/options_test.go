@@ -9,6 +9,9 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/admission"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
 )
 
 func TestLoadGRPCServerOptions(t *testing.T) {
@@ -71,6 +74,8 @@ cert_watch_interval: 60s
 				ServerPingTimeout:       20 * time.Second,
 				PermitPingWithoutStream: true,
 				CertWatchInterval:       60 * time.Second,
+				HealthCheckInterval:     defaultOpts.HealthCheckInterval,
+				ShutdownGracePeriod:     defaultOpts.ShutdownGracePeriod,
 			},
 			expectErr: false,
 		},
@@ -149,9 +154,176 @@ connection_timeout: 90s
 				ServerPingTimeout:       defaultOpts.ServerPingTimeout,
 				PermitPingWithoutStream: false, // a bool's zero value is false
 				CertWatchInterval:       defaultOpts.CertWatchInterval,
+				HealthCheckInterval:     defaultOpts.HealthCheckInterval,
+				ShutdownGracePeriod:     defaultOpts.ShutdownGracePeriod,
+			},
+			expectErr: false,
+		},
+		{
+			name: "Admission config",
+			setup: func(t *testing.T) string {
+				content := `
+admission:
+  per_user:
+    qps: 10
+    burst: 20
+  per_cluster:
+    qps: 5
+  max_subscriptions_per_cluster: 2
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "admission-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
+			},
+			expectedOpts: func() *GRPCServerOptions {
+				opts := NewGRPCServerOptions()
+				opts.Admission = admission.Options{
+					PerUser:                    admission.RateLimit{QPS: 10, Burst: 20},
+					PerCluster:                 admission.RateLimit{QPS: 5},
+					MaxSubscriptionsPerCluster: 2,
+				}
+				return opts
+			}(),
+			expectErr: false,
+		},
+		{
+			name: "Invalid admission config",
+			setup: func(t *testing.T) string {
+				content := `
+admission:
+  per_data_type:
+    qps: -1
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "admission-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
+			},
+			expectedOpts: nil,
+			expectErr:    true,
+		},
+		{
+			name: "Listeners config",
+			setup: func(t *testing.T) string {
+				content := `
+server_bind_port: ""
+listeners:
+  - name: sidecar
+    type: unix
+    address: /var/run/grpc/grpc.sock
+    socket_mode: "0660"
+  - name: local
+    type: loopback
+    address: 127.0.0.1:8091
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "listeners-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
 			},
+			expectedOpts: func() *GRPCServerOptions {
+				opts := NewGRPCServerOptions()
+				opts.ServerBindPort = ""
+				opts.Listeners = []ListenerOptions{
+					{Name: "sidecar", Type: ListenerTypeUnix, Address: "/var/run/grpc/grpc.sock", SocketMode: "0660"},
+					{Name: "local", Type: ListenerTypeLoopback, Address: "127.0.0.1:8091"},
+				}
+				return opts
+			}(),
+			expectErr: false,
+		},
+		{
+			name: "Listener not bound to loopback",
+			setup: func(t *testing.T) string {
+				content := `
+listeners:
+  - name: local
+    type: loopback
+    address: 0.0.0.0:8091
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "listeners-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
+			},
+			expectedOpts: nil,
+			expectErr:    true,
+		},
+		{
+			name: "Identity rules config",
+			setup: func(t *testing.T) string {
+				content := `
+identity_rules:
+  - source: URISAN
+    pattern: ^spiffe://hub\.example\.com/cluster/(?P<cluster>[a-z0-9-]+)/agent$
+    user: agent:${cluster}
+    groups: ["system:open-cluster-management:${cluster}"]
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "identity-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
+			},
+			expectedOpts: func() *GRPCServerOptions {
+				opts := NewGRPCServerOptions()
+				opts.IdentityRules = []authn.IdentityRule{{
+					Source:  authn.IdentitySourceURISAN,
+					Pattern: `^spiffe://hub\.example\.com/cluster/(?P<cluster>[a-z0-9-]+)/agent$`,
+					User:    "agent:${cluster}",
+					Groups:  []string{"system:open-cluster-management:${cluster}"},
+				}}
+				return opts
+			}(),
 			expectErr: false,
 		},
+		{
+			name: "Invalid identity rules config",
+			setup: func(t *testing.T) string {
+				content := `
+identity_rules:
+  - source: URISAN
+    pattern: "("
+    user: agent
+`
+				tmpFile, err := os.CreateTemp(t.TempDir(), "identity-*.yaml")
+				if err != nil {
+					t.Fatalf("Failed to create temp file: %v", err)
+				}
+				if _, err := tmpFile.Write([]byte(content)); err != nil {
+					t.Fatalf("Failed to write to temp file: %v", err)
+				}
+				tmpFile.Close()
+				return tmpFile.Name()
+			},
+			expectedOpts: nil,
+			expectErr:    true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -0,0 +1,31 @@
+//go:build linux
+
+package grpc
+
+import (
+	"net"
+	"syscall"
+
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+)
+
+// getPeerCredentials reads the credentials of the peer process from the socket with SO_PEERCRED.
+func getPeerCredentials(conn *net.UnixConn) (authn.UnixPeerInfo, error) {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return authn.UnixPeerInfo{}, err
+	}
+
+	var ucred *syscall.Ucred
+	var credErr error
+	if err := rawConn.Control(func(fd uintptr) {
+		ucred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return authn.UnixPeerInfo{}, err
+	}
+	if credErr != nil {
+		return authn.UnixPeerInfo{}, credErr
+	}
+
+	return authn.UnixPeerInfo{PID: ucred.Pid, UID: ucred.Uid, GID: ucred.Gid}, nil
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package grpc
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
+)
+
+// getPeerCredentials is not supported on this platform, SO_PEERCRED is only available on linux.
+func getPeerCredentials(conn *net.UnixConn) (authn.UnixPeerInfo, error) {
+	return authn.UnixPeerInfo{}, fmt.Errorf("the peer credentials are not supported on %s", runtime.GOOS)
+}
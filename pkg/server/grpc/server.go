@@ -3,16 +3,23 @@ package grpc
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net"
-	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionalphapb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
 	"k8s.io/apimachinery/pkg/util/errors"
 	k8smetrics "k8s.io/component-base/metrics"
@@ -21,6 +28,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/admission"
+	"open-cluster-management.io/sdk-go/pkg/server/grpc/audit"
 	"open-cluster-management.io/sdk-go/pkg/server/grpc/authn"
 	"open-cluster-management.io/sdk-go/pkg/server/grpc/authz"
 	"open-cluster-management.io/sdk-go/pkg/server/grpc/metrics"
@@ -33,6 +42,10 @@ type GRPCServer struct {
 	authenticators    []authn.Authenticator
 	unaryAuthorizers  []authz.UnaryAuthorizer
 	streamAuthorizers []authz.StreamAuthorizer
+	auditor           *audit.Auditor
+	readinessChecks   map[string]ReadinessCheck
+	// listenerAuthenticators are the authenticators of the additional listeners, keyed by the listener name
+	listenerAuthenticators map[string][]authn.Authenticator
 }
 
 func NewGRPCServer(opt *GRPCServerOptions) *GRPCServer {
@@ -56,6 +69,16 @@ func (b *GRPCServer) WithAuthenticator(authenticator authn.Authenticator) *GRPCS
 	return b
 }
 
+// WithListenerAuthenticator adds an authenticator for the additional listener with the given name, the requests on
+// the listener are authenticated with its own authenticators instead of the authenticators of the TLS listener.
+func (b *GRPCServer) WithListenerAuthenticator(listener string, authenticator authn.Authenticator) *GRPCServer {
+	if b.listenerAuthenticators == nil {
+		b.listenerAuthenticators = map[string][]authn.Authenticator{}
+	}
+	b.listenerAuthenticators[listener] = append(b.listenerAuthenticators[listener], authenticator)
+	return b
+}
+
 func (b *GRPCServer) WithUnaryAuthorizer(authorizer authz.UnaryAuthorizer) *GRPCServer {
 	b.unaryAuthorizers = append(b.unaryAuthorizers, authorizer)
 	return b
@@ -66,10 +89,32 @@ func (b *GRPCServer) WithStreamAuthorizer(authorizer authz.StreamAuthorizer) *GR
 	return b
 }
 
+// WithAuditor audits the requests with the auditor, the requests are audited after they are authenticated,
+// so the denied requests are audited as well.
+func (b *GRPCServer) WithAuditor(auditor *audit.Auditor) *GRPCServer {
+	b.auditor = auditor
+	b.extraMetrics = append(b.extraMetrics, audit.EventsMetric)
+	return b
+}
+
+// WithReadinessCheck adds a named readiness check, the health service reports serving once all the checks pass.
+func (b *GRPCServer) WithReadinessCheck(name string, check ReadinessCheck) *GRPCServer {
+	if b.readinessChecks == nil {
+		b.readinessChecks = map[string]ReadinessCheck{}
+	}
+	b.readinessChecks[name] = check
+	return b
+}
+
 func (b *GRPCServer) Run(ctx context.Context) error {
 	if err := b.options.Validate(); err != nil {
 		return err
 	}
+	for _, listener := range b.options.Listeners {
+		if len(b.listenerAuthenticators[listener.Name]) == 0 {
+			return fmt.Errorf("no authenticator is added for the listener %s", listener.Name)
+		}
+	}
 
 	var grpcServerOptions []grpc.ServerOption
 	grpcServerOptions = append(grpcServerOptions, grpc.MaxRecvMsgSize(b.options.MaxReceiveMessageSize))
@@ -88,12 +133,210 @@ func (b *GRPCServer) Run(ctx context.Context) error {
 		Timeout:          b.options.ServerPingTimeout,
 	}))
 
+	// append the stats handler for metrics
+	grpcServerOptions = append(grpcServerOptions, grpc.StatsHandler(metrics.NewGRPCMetricsHandler()))
+
+	// init prometheus middleware for grpc server
+	promMiddleware := grpcprom.NewServerMetrics(
+		// enable grpc handling time histogram to measure latency distributions of RPCs
+		grpcprom.WithServerHandlingTimeHistogram(
+			grpcprom.WithHistogramBuckets(k8smetrics.ExponentialBuckets(10e-7, 10, 10)),
+		),
+	)
+
+	// the interceptors after the authentication are shared by all the listeners
+	var unaryInterceptors []grpc.UnaryServerInterceptor
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if b.auditor != nil {
+		unaryInterceptors = append(unaryInterceptors, audit.NewUnaryInterceptor(b.auditor, userInfo))
+		streamInterceptors = append(streamInterceptors, audit.NewStreamInterceptor(b.auditor, userInfo))
+	}
+	// the requests are admitted with the per user limit before they are authorized, so the unauthorized requests
+	// are limited as well, the limits keyed on the client provided cluster and data type are applied after the
+	// authorization.
+	extraMetrics := b.extraMetrics
+	var admissionController *admission.Controller
+	if b.options.Admission.Enabled() {
+		admissionController = admission.NewController(b.options.Admission, func(ctx context.Context) string {
+			user, _ := userInfo(ctx)
+			return user
+		})
+		unaryInterceptors = append(unaryInterceptors, admissionController.UnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, admissionController.StreamInterceptor())
+		extraMetrics = append(extraMetrics, admission.RejectionsMetric)
+	}
+	unaryInterceptors = append(unaryInterceptors, newAuthzUnaryInterceptor(b.unaryAuthorizers...))
+	streamInterceptors = append(streamInterceptors, newAuthzStreamInterceptor(b.streamAuthorizers))
+	if admissionController != nil {
+		unaryInterceptors = append(unaryInterceptors, admissionController.AuthorizedUnaryInterceptor())
+		streamInterceptors = append(streamInterceptors, admissionController.AuthorizedStreamInterceptor())
+	}
+	// the health and reflection services are served without the authentication, audit, admission and authorization,
+	// so that they are available to the probes and tools that have no identity on the server.
+	skip := func(fullMethod string) bool {
+		return isUnauthenticatedMethod(fullMethod, b.options.EnableReflection)
+	}
+	for i, interceptor := range unaryInterceptors {
+		unaryInterceptors[i] = skipUnaryInterceptor(skip, interceptor)
+	}
+	for i, interceptor := range streamInterceptors {
+		streamInterceptors[i] = skipStreamInterceptor(skip, interceptor)
+	}
+	// the streams are closed once the server is draining
+	drainer := newDrainer()
+	streamInterceptors = append(streamInterceptors, drainer.streamInterceptor())
+
+	// register all the general grpc server metrics
+	metrics.RegisterGRPCMetrics(promMiddleware, extraMetrics...)
+
+	// the health service is shared by all the listeners, the server is serving once all the readiness checks pass
+	healthServer := health.NewServer()
+	if len(b.readinessChecks) != 0 {
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+		go newHealthChecker(healthServer, b.readinessChecks, b.options.HealthCheckInterval).run(ctx)
+	}
+
+	// newServer creates a grpc server for a listener, the servers of the listeners have the same services, and each
+	// of them authenticates the requests with its own authenticators.
+	newServer := func(creds credentials.TransportCredentials, authenticators []authn.Authenticator) *grpc.Server {
+		serverOptions := append(slices.Clone(grpcServerOptions),
+			grpc.Creds(creds),
+			grpc.ChainUnaryInterceptor(append([]grpc.UnaryServerInterceptor{
+				metrics.NewGRPCMetricsUnaryInterceptor(promMiddleware),
+				skipUnaryInterceptor(skip, newAuthnUnaryInterceptor(authenticators...)),
+			}, unaryInterceptors...)...),
+			grpc.ChainStreamInterceptor(append([]grpc.StreamServerInterceptor{
+				metrics.NewGRPCMetricsStreamInterceptor(promMiddleware),
+				skipStreamInterceptor(skip, newAuthnStreamInterceptor(authenticators...)),
+			}, streamInterceptors...)...),
+		)
+
+		grpcServer := grpc.NewServer(serverOptions...)
+		for _, r := range b.registerFuncs {
+			r(grpcServer)
+		}
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		if b.options.EnableReflection {
+			reflection.Register(grpcServer)
+		}
+		// initialize grpc server metrics with appropriate value.
+		promMiddleware.InitializeMetrics(grpcServer)
+		return grpcServer
+	}
+
+	servers := []*listenerServer{}
+	defer func() {
+		// close the listeners that are not served if the server fails to start
+		for _, s := range servers {
+			if !s.serving {
+				s.listener.Close()
+			}
+		}
+	}()
+
+	if len(b.options.ServerBindPort) != 0 {
+		creds, err := b.tlsCredentials(ctx)
+		if err != nil {
+			return err
+		}
+		lis, err := net.Listen("tcp", ":"+b.options.ServerBindPort)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %v", err)
+		}
+		servers = append(servers, &listenerServer{name: "tls", listener: lis, server: newServer(creds, b.authenticators)})
+	}
+
+	for _, listener := range b.options.Listeners {
+		lis, creds, err := listener.listen()
+		if err != nil {
+			return fmt.Errorf("failed to start the listener %s: %v", listener.Name, err)
+		}
+		servers = append(servers, &listenerServer{
+			name:     listener.Name,
+			listener: lis,
+			server:   newServer(creds, b.listenerAuthenticators[listener.Name]),
+		})
+	}
+
+	// the auditor is stopped after the server is stopped, so the audit events of the drained requests are sent
+	auditCtx, stopAuditor := context.WithCancel(context.WithoutCancel(ctx))
+	auditorDone := make(chan struct{})
+	go func() {
+		defer close(auditorDone)
+		if b.auditor != nil {
+			b.auditor.Run(auditCtx)
+		}
+	}()
+	defer func() {
+		stopAuditor()
+		<-auditorDone
+	}()
+
+	// Start gRPC server
+	logger := klog.FromContext(ctx)
+	serveErrCh := make(chan error, len(servers))
+	for _, s := range servers {
+		logger.Info("Starting gRPC server", "listener", s.name, "addr", s.listener.Addr().String())
+		s.serving = true
+		go func(s *listenerServer) {
+			if err := s.server.Serve(s.listener); err != nil {
+				serveErrCh <- fmt.Errorf("failed to serve gRPC server on the listener %s: %w", s.name, err)
+			} else {
+				serveErrCh <- nil
+			}
+		}(s)
+	}
+
+	select {
+	case <-ctx.Done():
+		logger.Info("Shutting down gRPC server", "gracePeriod", b.options.ShutdownGracePeriod)
+		// report not serving, so no new requests are routed to this server, and close the long-lived streams,
+		// so the clients reconnect to another server.
+		healthServer.Shutdown()
+		drainer.drain()
+
+		// GracefulStop sends GOAWAY to the clients and waits for the in-flight requests
+		stopped := make(chan struct{})
+		go func() {
+			var wg sync.WaitGroup
+			for _, s := range servers {
+				wg.Add(1)
+				go func(s *listenerServer) {
+					defer wg.Done()
+					s.server.GracefulStop()
+				}(s)
+			}
+			wg.Wait()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-time.After(b.options.ShutdownGracePeriod):
+			logger.Info("Stopping gRPC server forcibly after the grace period")
+			for _, s := range servers {
+				s.server.Stop()
+			}
+		}
+		return nil
+	case err := <-serveErrCh:
+		// If Serve returns early with error, stop the other listeners and surface it
+		for _, s := range servers {
+			s.server.Stop()
+		}
+		return err
+	}
+}
+
+// tlsCredentials returns the transport credentials of the TLS listener, the serving certificate and the client CAs
+// are reloaded once they are changed.
+func (b *GRPCServer) tlsCredentials(ctx context.Context) (credentials.TransportCredentials, error) {
 	// Set textlogger with verbosity level 4 for controller-runtime logging
 	log.SetLogger(textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(4))))
 	// Serve with TLS - use certwatcher for dynamic certificate reloading
 	certWatcher, err := certwatcher.New(b.options.TLSCertFile, b.options.TLSKeyFile)
 	if err != nil {
-		return fmt.Errorf("failed to create certificate watcher: %v", err)
+		return nil, fmt.Errorf("failed to create certificate watcher: %v", err)
 	}
 
 	// Configure watch interval from options (default is 1 minute, configurable via --grpc-cert-watch-interval flag or YAML config)
@@ -120,79 +363,65 @@ func (b *GRPCServer) Run(ctx context.Context) error {
 	}
 
 	if b.options.ClientCAFile != "" {
-		certPool := x509.NewCertPool()
-		caPEM, err := os.ReadFile(b.options.ClientCAFile)
+		// the client CA and revocation list files are reloaded on the TLS handshakes once they are changed
+		clientCA, err := newDynamicClientCA(b.options.ClientCAFile, b.options.ClientCRLFile)
 		if err != nil {
-			return fmt.Errorf("failed to read server client CA file: %v", err)
-		}
-		if ok := certPool.AppendCertsFromPEM(caPEM); !ok {
-			return fmt.Errorf("failed to append server client CA to cert pool")
+			return nil, err
 		}
-		tlsConfig.ClientCAs = certPool
 		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		tlsConfig.VerifyConnection = clientCA.VerifyConnection
+		tlsConfig.GetConfigForClient = clientCA.GetConfigForClient(tlsConfig.Clone())
 	}
 
-	grpcServerOptions = append(grpcServerOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
-
-	// append the stats handler for metrics
-	grpcServerOptions = append(grpcServerOptions, grpc.StatsHandler(metrics.NewGRPCMetricsHandler()))
-
-	// init prometheus middleware for grpc server
-	promMiddleware := grpcprom.NewServerMetrics(
-		// enable grpc handling time histogram to measure latency distributions of RPCs
-		grpcprom.WithServerHandlingTimeHistogram(
-			grpcprom.WithHistogramBuckets(k8smetrics.ExponentialBuckets(10e-7, 10, 10)),
-		),
-	)
-
-	grpcServerOptions = append(grpcServerOptions,
-		grpc.ChainUnaryInterceptor(
-			metrics.NewGRPCMetricsUnaryInterceptor(promMiddleware),
-			newAuthnUnaryInterceptor(b.authenticators...),
-			newAuthzUnaryInterceptor(b.unaryAuthorizers...),
-		),
-		grpc.ChainStreamInterceptor(
-			metrics.NewGRPCMetricsStreamInterceptor(promMiddleware),
-			newAuthnStreamInterceptor(b.authenticators...),
-			newAuthzStreamInterceptor(b.streamAuthorizers),
-		))
+	return credentials.NewTLS(tlsConfig), nil
+}
 
-	grpcServer := grpc.NewServer(grpcServerOptions...)
-	// register all the general grpc server metrics
-	metrics.RegisterGRPCMetrics(promMiddleware, b.extraMetrics...)
-	// initialize grpc server metrics with appropriate value.
-	promMiddleware.InitializeMetrics(grpcServer)
+// listenerServer is the grpc server of a listener.
+type listenerServer struct {
+	name     string
+	listener net.Listener
+	server   *grpc.Server
+	serving  bool
+}
 
-	for _, r := range b.registerFuncs {
-		r(grpcServer)
+// isUnauthenticatedMethod returns true for the methods of the health service, and the methods of the reflection
+// service if it is enabled.
+func isUnauthenticatedMethod(fullMethod string, reflectionEnabled bool) bool {
+	if strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return true
 	}
+	return reflectionEnabled &&
+		(strings.HasPrefix(fullMethod, "/"+reflectionpb.ServerReflection_ServiceDesc.ServiceName+"/") ||
+			strings.HasPrefix(fullMethod, "/"+reflectionalphapb.ServerReflection_ServiceDesc.ServiceName+"/"))
+}
 
-	// Start gRPC server
-	logger := klog.FromContext(ctx)
-	addr := ":" + b.options.ServerBindPort
-	lis, err := net.Listen("tcp", addr)
-	if err != nil {
-		return fmt.Errorf("failed to listen: %v", err)
+// skipUnaryInterceptor bypasses the interceptor for the methods that are skipped.
+func skipUnaryInterceptor(skip func(fullMethod string) bool, interceptor grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if skip(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		return interceptor(ctx, req, info, handler)
 	}
-	logger.Info("Starting gRPC server", "addr", lis.Addr().String())
+}
 
-	serveErrCh := make(chan error, 1)
-	go func() {
-		if err := grpcServer.Serve(lis); err != nil {
-			serveErrCh <- fmt.Errorf("failed to serve gRPC server: %w", err)
-		} else {
-			serveErrCh <- nil
+// skipStreamInterceptor bypasses the interceptor for the methods that are skipped.
+func skipStreamInterceptor(skip func(fullMethod string) bool, interceptor grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if skip(info.FullMethod) {
+			return handler(srv, ss)
 		}
-	}()
-
-	select {
-	case <-ctx.Done():
-		logger.Info("Shutting down gRPC server")
-		grpcServer.GracefulStop()
-		return nil
-	case err := <-serveErrCh:
-		// If Serve returns early with error, surface it
-		return err
+		return interceptor(srv, ss, info, handler)
 	}
 }
 
@@ -250,6 +479,13 @@ func newAuthzUnaryInterceptor(authorizers ...authz.UnaryAuthorizer) grpc.UnarySe
 	}
 }
 
+// userInfo returns the user and groups that are added to the context by the authenticators.
+func userInfo(ctx context.Context) (string, []string) {
+	user, _ := ctx.Value(authn.ContextUserKey).(string)
+	groups, _ := ctx.Value(authn.ContextGroupsKey).([]string)
+	return user, groups
+}
+
 // wrappedAuthStream wraps a grpc.ServerStream associated with an incoming RPC, and
 // a custom context containing the user and groups derived from the client certificate
 // specified in the incoming RPC metadata